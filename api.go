@@ -17,12 +17,16 @@
 package pilosa
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/binary"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +37,7 @@ import (
 	"github.com/pilosa/pilosa/stats"
 	"github.com/pilosa/pilosa/tracing"
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -143,6 +148,15 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 	if err != nil {
 		return QueryResponse{}, errors.Wrap(err, "parsing")
 	}
+	// Writes are only gated where they enter the cluster. A remote query was
+	// already let through by the node which forwarded it, and that node holds
+	// its gate until the forwarded query completes.
+	if q.HasWriteCall() && !req.Remote {
+		if err := api.holder.writes.enter(); err != nil {
+			return QueryResponse{}, err
+		}
+		defer api.holder.writes.exit()
+	}
 	execOpts := &execOptions{
 		Remote:          req.Remote,
 		ExcludeRowAttrs: req.ExcludeRowAttrs, // NOTE: Kept for Pilosa 1.x compat.
@@ -157,6 +171,39 @@ func (api *API) Query(ctx context.Context, req *QueryRequest) (QueryResponse, er
 	return resp, nil
 }
 
+// QueryStream parses and executes a PQL query, passing each result to fn as
+// soon as it is available. The columns of row results are passed one shard
+// at a time, in shard order.
+func (api *API) QueryStream(ctx context.Context, req *QueryRequest, fn func(call int, result interface{}) error) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.QueryStream")
+	defer span.Finish()
+
+	if err := api.validate(apiQuery); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	q, err := pql.NewParser(strings.NewReader(req.Query)).Parse()
+	if err != nil {
+		return errors.Wrap(err, "parsing")
+	}
+	if q.HasWriteCall() && !req.Remote {
+		if err := api.holder.writes.enter(); err != nil {
+			return err
+		}
+		defer api.holder.writes.exit()
+	}
+	execOpts := &execOptions{
+		Remote:          req.Remote,
+		ExcludeRowAttrs: req.ExcludeRowAttrs,
+		ExcludeColumns:  req.ExcludeColumns,
+		ColumnAttrs:     req.ColumnAttrs,
+	}
+	if err := api.server.executor.ExecuteStream(ctx, req.Index, q, req.Shards, execOpts, fn); err != nil {
+		return errors.Wrap(err, "executing")
+	}
+	return nil
+}
+
 // CreateIndex makes a new Pilosa index.
 func (api *API) CreateIndex(ctx context.Context, indexName string, options IndexOptions) (*Index, error) {
 	span, _ := tracing.StartSpanFromContext(ctx, "API.CreateIndex")
@@ -254,6 +301,28 @@ func (api *API) CreateField(ctx context.Context, indexName string, fieldName str
 		return nil, newNotFoundError(ErrIndexNotFound)
 	}
 
+	// Ensure a foreign index refers to an existing index with keys.
+	if fo.ForeignIndex != "" {
+		if fo.Type != FieldTypeInt {
+			return nil, NewBadRequestError(errors.New("foreign index only applies to int fields"))
+		} else if fi := api.holder.Index(fo.ForeignIndex); fi == nil {
+			return nil, NewBadRequestError(errors.Wrap(ErrIndexNotFound, "foreign index"))
+		} else if !fi.Keys() {
+			return nil, NewBadRequestError(errors.New("foreign index must have the 'keys' option enabled"))
+		}
+	}
+
+	if fo.TTL != 0 && fo.Type != FieldTypeTime {
+		return nil, NewBadRequestError(errors.New("ttl only applies to time fields"))
+	}
+	if fo.Rollup != "" {
+		if fo.Type != FieldTypeTime {
+			return nil, NewBadRequestError(errors.New("rollup only applies to time fields"))
+		} else if err := fo.Rollup.validate(fo.TimeQuantum); err != nil {
+			return nil, NewBadRequestError(errors.Wrap(err, "invalid rollup policy"))
+		}
+	}
+
 	// Create field.
 	field, err := index.CreateField(fieldName, opts...)
 	if err != nil {
@@ -373,6 +442,14 @@ func (api *API) ImportRoaring(ctx context.Context, indexName, fieldName string,
 		return errors.Wrap(err, "validating api method")
 	}
 
+	// Imports forwarded by another node were already gated there.
+	if !remote {
+		if err = api.holder.writes.enter(); err != nil {
+			return err
+		}
+		defer api.holder.writes.exit()
+	}
+
 	nodes := api.cluster.shardNodes(indexName, shard)
 
 	field := api.holder.Field(indexName, fieldName)
@@ -930,6 +1007,14 @@ func (api *API) Import(ctx context.Context, req *ImportRequest, opts ...ImportOp
 		return errors.Wrap(err, "setting up import options")
 	}
 
+	// Imports forwarded by the coordinator were already gated there.
+	if !options.IgnoreKeyCheck {
+		if err := api.holder.writes.enter(); err != nil {
+			return err
+		}
+		defer api.holder.writes.exit()
+	}
+
 	index, field, err := api.indexField(req.Index, req.Field, req.Shard)
 	if err != nil {
 		return errors.Wrap(err, "getting index and field")
@@ -1041,11 +1126,34 @@ func (api *API) ImportValue(ctx context.Context, req *ImportValueRequest, opts .
 		return errors.Wrap(err, "setting up import options")
 	}
 
+	// Imports forwarded by the coordinator were already gated there.
+	if !options.IgnoreKeyCheck {
+		if err := api.holder.writes.enter(); err != nil {
+			return err
+		}
+		defer api.holder.writes.exit()
+	}
+
 	index, field, err := api.indexField(req.Index, req.Field, req.Shard)
 	if err != nil {
 		return errors.Wrap(err, "getting index and field")
 	}
 
+	// Convert fractional values to their scaled representation.
+	if len(req.FloatValues) > 0 {
+		if field.Type() != FieldTypeDecimal {
+			return NewBadRequestError(errors.New("float values can only be imported into decimal fields"))
+		}
+		scale := field.Options().Scale
+		req.Values = make([]int64, len(req.FloatValues))
+		for i, v := range req.FloatValues {
+			if req.Values[i], err = scaleDecimal(v, scale, decimalRoundNearest); err != nil {
+				return NewBadRequestError(errors.Wrap(err, "scaling value"))
+			}
+		}
+		req.FloatValues = nil
+	}
+
 	// Unless explicitly ignoring key validation (meaning keys have been
 	// translate to ids in a previous step at the coordinator node), then
 	// check to see if keys need translation.
@@ -1121,6 +1229,61 @@ func importExistenceColumns(index *Index, columnIDs []uint64) error {
 	return ef.Import(existenceRowIDs, columnIDs, nil)
 }
 
+// DeleteColumns deletes columns from every field of an index and removes their
+// column attributes. Columns are given by key if the index uses string keys.
+// It returns the number of columns given, not counting unknown keys.
+func (api *API) DeleteColumns(ctx context.Context, indexName string, columnIDs []uint64, columnKeys []string) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.DeleteColumns")
+	defer span.Finish()
+
+	if err := api.validate(apiDeleteColumns); err != nil {
+		return 0, errors.Wrap(err, "validating api method")
+	}
+
+	index := api.holder.Index(indexName)
+	if index == nil {
+		return 0, newNotFoundError(ErrIndexNotFound)
+	}
+
+	// Look up column keys without creating ids for unknown keys, which have
+	// no columns to delete. Keys may not have been replicated to this node
+	// yet, so the request is forwarded to the coordinator, which holds the
+	// primary translate store, unless every key is known here.
+	if index.Keys() {
+		if len(columnIDs) != 0 {
+			return 0, NewBadRequestError(errors.New("column ids cannot be used because index uses string keys"))
+		}
+		var ok bool
+		columnIDs, ok = api.holder.translateFile.lookupColumns(index.Name(), columnKeys)
+		if !ok && api.holder.translateFile.isReadOnly() && !api.cluster.isCoordinator() {
+			coord := api.cluster.coordinatorNode()
+			if coord == nil {
+				return 0, errors.New("coordinator node not found")
+			}
+			n, err := api.server.defaultClient.DeleteColumns(ctx, &coord.URI, indexName, columnKeys)
+			return n, errors.Wrap(err, "forwarding to coordinator")
+		}
+	} else if len(columnKeys) != 0 {
+		return 0, NewBadRequestError(errors.New("column keys cannot be used because index does not use string keys"))
+	}
+	if len(columnIDs) == 0 {
+		return 0, nil
+	}
+
+	// Requests forwarded to the coordinator are gated there.
+	if err := api.holder.writes.enter(); err != nil {
+		return 0, err
+	}
+	defer api.holder.writes.exit()
+
+	q := &pql.Query{Calls: []*pql.Call{{Name: "Delete", Args: map[string]interface{}{"ids": columnIDs}}}}
+	resp, err := api.server.executor.Execute(ctx, indexName, q, nil, &execOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "executing")
+	}
+	return resp.Results[0].(uint64), nil
+}
+
 // MaxShards returns the maximum shard number for each index in a map.
 // TODO (2.0): This method has been deprecated. Instead, use
 // AvailableShardsByIndex.
@@ -1255,6 +1418,549 @@ func (api *API) ResizeAbort() error {
 	return errors.Wrap(err, "complete current job")
 }
 
+// Backup writes a point-in-time consistent archive of the cluster to w. Writes
+// are paused on every node until the archive is complete. Backup must be run
+// on the coordinator.
+//
+// If prev is not nil then an incremental backup is written which only
+// contains the fragment blocks and key translations which changed since the
+// backup described by prev.
+func (api *API) Backup(ctx context.Context, w io.Writer, prev *BackupManifest) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.Backup")
+	defer span.Finish()
+
+	if err := api.validate(apiBackup); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if !api.cluster.isCoordinator() {
+		return ErrNodeNotCoordinator
+	}
+
+	// Pause writes on every node and wait for those in flight to complete.
+	// Writes are resumed on all nodes even if pausing fails on some of them.
+	defer func() {
+		if err := api.server.SendSync(&PauseWritesMessage{Pause: false}); err != nil {
+			api.server.logger.Printf("resuming writes: %s", err)
+		}
+		api.holder.writes.resume()
+	}()
+	api.holder.writes.pause(0)
+	if err := api.server.SendSync(&PauseWritesMessage{Pause: true, Timeout: writePauseTimeout}); err != nil {
+		return errors.Wrap(err, "pausing writes")
+	}
+
+	// The other nodes resume writes on their own unless the pause is
+	// renewed, so renew it until the archive is written. The backup is
+	// abandoned if the pause cannot be renewed.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	renewed := make(chan error, 1)
+	go func() {
+		err := api.renewWritePause(ctx)
+		cancel()
+		renewed <- err
+	}()
+
+	id, err := api.writeBackup(ctx, w, prev)
+	cancel()
+	if rerr := <-renewed; rerr != nil {
+		return errors.Wrap(rerr, "renewing write pause")
+	} else if err != nil {
+		return err
+	}
+	return api.holder.setLastBackupID(id)
+}
+
+// renewWritePause periodically renews the pause of writes on the other
+// nodes of the cluster until ctx is done.
+func (api *API) renewWritePause(ctx context.Context) error {
+	ticker := time.NewTicker(writePauseTimeout / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := api.server.SendSync(&PauseWritesMessage{Pause: true, Timeout: writePauseTimeout, Renew: true}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeBackup writes the archive of a backup to w while writes are paused
+// and returns the ID of the backup.
+func (api *API) writeBackup(ctx context.Context, w io.Writer, prev *BackupManifest) (string, error) {
+	manifest := &BackupManifest{
+		ID:         uuid.NewV4().String(),
+		KeysOffset: api.holder.translateFile.size(),
+		Fragments:  make(map[string][]FragmentBlock),
+	}
+	var keysOffset int64
+	if prev != nil {
+		manifest.Parent, keysOffset = prev.ID, prev.KeysOffset
+	}
+
+	// Collect the block checksums of every fragment from the node which
+	// owns it so that they can be compared with the next backup.
+	var frags []backupFragment
+	for _, index := range api.holder.Indexes() {
+		for _, shard := range index.AvailableShards().Slice() {
+			node, err := api.backupNode(index.Name(), shard)
+			if err != nil {
+				return "", errors.Wrapf(err, "backing up shard %d of %s", shard, index.Name())
+			}
+			for _, field := range index.Fields() {
+				for _, view := range field.views() {
+					frag := backupFragment{node: node, index: index.Name(), field: field.Name(), view: view.name, shard: shard}
+					blocks, err := api.backupBlocks(ctx, frag)
+					if err == ErrFragmentNotFound {
+						continue
+					} else if err != nil {
+						return "", errors.Wrapf(err, "getting blocks of %s", frag.path())
+					}
+					frag.blocks = blocks
+					frags = append(frags, frag)
+					manifest.Fragments[frag.path()] = blocks
+				}
+			}
+		}
+	}
+
+	tw := tar.NewWriter(w)
+
+	// Write the manifest first so it can be read without reading the
+	// whole archive.
+	if data, err := json.Marshal(manifest); err != nil {
+		return "", errors.Wrap(err, "marshaling manifest")
+	} else if err := writeBackupFile(tw, backupManifestName, data); err != nil {
+		return "", errors.Wrap(err, "writing manifest")
+	}
+
+	// Write the schema. Views are created as their fragments are restored.
+	schema, err := json.Marshal(&Schema{Indexes: api.holder.limitedSchema()})
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling schema")
+	} else if err := writeBackupFile(tw, backupSchemaName, schema); err != nil {
+		return "", errors.Wrap(err, "writing schema")
+	}
+
+	if err := writeBackupKeys(tw, api.holder.translateFile, keysOffset, manifest.KeysOffset); err != nil {
+		return "", errors.Wrap(err, "writing keys")
+	}
+
+	for _, index := range api.holder.Indexes() {
+		data, err := encodeAttrStore(index.ColumnAttrStore())
+		if err != nil {
+			return "", errors.Wrap(err, "encoding column attrs")
+		} else if err := writeBackupFile(tw, backupAttrsPath(index.Name(), ""), data); err != nil {
+			return "", errors.Wrap(err, "writing column attrs")
+		}
+
+		for _, field := range index.Fields() {
+			data, err := encodeAttrStore(field.RowAttrStore())
+			if err != nil {
+				return "", errors.Wrap(err, "encoding row attrs")
+			} else if err := writeBackupFile(tw, backupAttrsPath(index.Name(), field.Name()), data); err != nil {
+				return "", errors.Wrap(err, "writing row attrs")
+			}
+		}
+	}
+
+	for _, frag := range frags {
+		if prev == nil {
+			data, err := api.backupFragmentData(ctx, frag)
+			if err != nil {
+				return "", errors.Wrapf(err, "backing up %s", frag.path())
+			} else if err := writeBackupFile(tw, frag.path(), data); err != nil {
+				return "", errors.Wrap(err, "writing fragment")
+			}
+			continue
+		}
+
+		for _, id := range changedBlocks(prev.Fragments[frag.path()], frag.blocks) {
+			data, err := api.backupBlockData(ctx, frag, id)
+			if err != nil {
+				return "", errors.Wrapf(err, "backing up block %d of %s", id, frag.path())
+			} else if err := writeBackupFile(tw, backupBlockPath(frag.index, frag.field, frag.view, frag.shard, id), data); err != nil {
+				return "", errors.Wrap(err, "writing block")
+			}
+		}
+	}
+
+	// Clear the blocks of fragments which no longer exist.
+	if prev != nil {
+		for name, blocks := range prev.Fragments {
+			if _, ok := manifest.Fragments[name]; ok {
+				continue
+			}
+			for _, id := range changedBlocks(blocks, nil) {
+				data, err := encodeBlock(nil, nil)
+				if err != nil {
+					return "", errors.Wrap(err, "encoding block")
+				} else if err := writeBackupFile(tw, path.Join(name, backupBlocksDir, strconv.Itoa(id)), data); err != nil {
+					return "", errors.Wrap(err, "writing block")
+				}
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", errors.Wrap(err, "closing archive")
+	}
+	return manifest.ID, nil
+}
+
+// backupFragment identifies a fragment being backed up and the node it is
+// read from.
+type backupFragment struct {
+	node   *Node
+	index  string
+	field  string
+	view   string
+	shard  uint64
+	blocks []FragmentBlock
+}
+
+// path returns the archive path of the fragment.
+func (f backupFragment) path() string {
+	return backupFragmentPath(f.index, f.field, f.view, f.shard)
+}
+
+// backupNode returns the node a shard is backed up from. Shards are read
+// locally if this node owns them, otherwise from their primary owner.
+func (api *API) backupNode(index string, shard uint64) (*Node, error) {
+	nodes := api.cluster.shardNodes(index, shard)
+	if len(nodes) == 0 {
+		return nil, errShardUnavailable
+	}
+	for _, n := range nodes {
+		if n.ID == api.server.nodeID {
+			return n, nil
+		}
+	}
+	return nodes[0], nil
+}
+
+// backupBlocks returns the block checksums of a fragment.
+func (api *API) backupBlocks(ctx context.Context, f backupFragment) ([]FragmentBlock, error) {
+	if f.node.ID != api.server.nodeID {
+		return api.server.defaultClient.FragmentBlocks(ctx, &f.node.URI, f.index, f.field, f.view, f.shard)
+	}
+	frag := api.holder.fragment(f.index, f.field, f.view, f.shard)
+	if frag == nil {
+		return nil, ErrFragmentNotFound
+	}
+	return frag.Blocks(), nil
+}
+
+// backupFragmentData returns the data of a fragment as written by its
+// WriteTo method.
+func (api *API) backupFragmentData(ctx context.Context, f backupFragment) ([]byte, error) {
+	var buf bytes.Buffer
+	if f.node.ID == api.server.nodeID {
+		frag := api.holder.fragment(f.index, f.field, f.view, f.shard)
+		if frag == nil {
+			return nil, ErrFragmentNotFound
+		}
+		if _, err := frag.WriteTo(&buf); err != nil {
+			return nil, errors.Wrap(err, "writing fragment")
+		}
+		return buf.Bytes(), nil
+	}
+
+	rd, err := api.server.defaultClient.RetrieveShardFromURI(ctx, f.index, f.field, f.view, f.shard, f.node.URI)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving fragment")
+	}
+	defer rd.Close()
+	if _, err := io.Copy(&buf, rd); err != nil {
+		return nil, errors.Wrap(err, "copying fragment")
+	}
+	return buf.Bytes(), nil
+}
+
+// backupBlockData returns the bits of a single fragment block encoded by
+// encodeBlock.
+func (api *API) backupBlockData(ctx context.Context, f backupFragment, block int) ([]byte, error) {
+	if f.node.ID != api.server.nodeID {
+		rowIDs, columnIDs, err := api.server.defaultClient.BlockData(ctx, &f.node.URI, f.index, f.field, f.view, f.shard, block)
+		if err != nil {
+			return nil, errors.Wrap(err, "retrieving block data")
+		}
+		return encodeBlock(rowIDs, columnIDs)
+	}
+	frag := api.holder.fragment(f.index, f.field, f.view, f.shard)
+	if frag == nil {
+		return nil, ErrFragmentNotFound
+	}
+	return encodeBlock(frag.blockData(block))
+}
+
+// Restore reads an archive written by Backup and restores it onto the
+// cluster. Full backups can only be restored onto an empty cluster, and
+// incremental backups onto a cluster holding the backup they are based on.
+// Fragments are sent to the nodes which own them in this cluster, so the
+// cluster may have a different number of nodes than the one the backup was
+// taken from. Restore must be run on the coordinator.
+func (api *API) Restore(ctx context.Context, r io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.Restore")
+	defer span.Finish()
+
+	if err := api.validate(apiRestore); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+	if !api.cluster.isCoordinator() {
+		return ErrNodeNotCoordinator
+	}
+
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil && err != io.EOF {
+		return errors.Wrap(err, "reading archive")
+	}
+
+	// Archives without a manifest are full backups.
+	var manifest *BackupManifest
+	if err == nil && hdr.Name == backupManifestName {
+		if manifest, err = readBackupManifest(tr); err != nil {
+			return errors.Wrap(err, "reading manifest")
+		}
+		hdr, err = tr.Next()
+	}
+	if (manifest == nil || manifest.Parent == "") && len(api.holder.Indexes()) > 0 {
+		return ErrRestoreNotEmpty
+	}
+
+	// Incremental backups must be restored onto the backup they are based on.
+	if manifest != nil && manifest.Parent != "" {
+		if id, err := api.holder.lastBackupID(); err != nil {
+			return err
+		} else if id != manifest.Parent {
+			return ErrBackupParent
+		}
+	}
+
+	for ; err == nil; hdr, err = tr.Next() {
+		switch hdr.Name {
+		case backupManifestName:
+			return errors.Wrap(ErrInvalidBackup, "unexpected manifest")
+
+		case backupSchemaName:
+			schema := &Schema{}
+			if err := json.NewDecoder(tr).Decode(schema); err != nil {
+				return errors.Wrap(err, "decoding schema")
+			} else if err := api.ApplySchema(ctx, schema, false); err != nil {
+				return errors.Wrap(err, "applying schema")
+			}
+
+		case backupKeysName:
+			// Incremental backups only contain the entries appended
+			// since the backup they are based on.
+			var offset int64
+			if manifest != nil {
+				offset = manifest.KeysOffset - hdr.Size
+			}
+			if err := api.holder.translateFile.restoreEntries(tr, offset); err != nil {
+				return errors.Wrap(err, "restoring keys")
+			}
+
+		default:
+			f, err := parseBackupPath(hdr.Name)
+			if err != nil {
+				return err
+			}
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return errors.Wrapf(err, "reading %s", hdr.Name)
+			}
+
+			// Attributes are stored on every node while fragments are
+			// only stored on the nodes which own the shard.
+			nodes := api.cluster.Nodes()
+			if !f.Attrs {
+				nodes = api.cluster.shardNodes(f.Index, f.Shard)
+			}
+			for _, node := range nodes {
+				if err := api.restoreNode(ctx, node, f, data); err != nil {
+					return errors.Wrapf(err, "restoring %s to %s", hdr.Name, node.ID)
+				}
+			}
+		}
+	}
+	if err != io.EOF {
+		return errors.Wrap(err, "reading archive")
+	}
+
+	// Archives without a manifest cannot be followed by an incremental backup.
+	if manifest == nil {
+		return nil
+	}
+	return api.holder.setLastBackupID(manifest.ID)
+}
+
+// restoreNode restores a single attribute store, fragment or fragment block
+// onto node.
+func (api *API) restoreNode(ctx context.Context, node *Node, f backupFile, data []byte) error {
+	local := node.ID == api.server.nodeID
+	switch {
+	case f.Attrs && local:
+		return api.RestoreAttrs(ctx, f.Index, f.Field, bytes.NewReader(data))
+	case f.Attrs:
+		return api.server.defaultClient.RestoreAttrs(ctx, &node.URI, f.Index, f.Field, bytes.NewReader(data))
+	case f.IsBlock && local:
+		return api.RestoreBlock(ctx, f.Index, f.Field, f.View, f.Shard, f.Block, bytes.NewReader(data))
+	case f.IsBlock:
+		return api.server.defaultClient.RestoreBlock(ctx, &node.URI, f.Index, f.Field, f.View, f.Shard, f.Block, bytes.NewReader(data))
+	case local:
+		return api.RestoreFragment(ctx, f.Index, f.Field, f.View, f.Shard, bytes.NewReader(data))
+	default:
+		return api.server.defaultClient.RestoreFragment(ctx, &node.URI, f.Index, f.Field, f.View, f.Shard, bytes.NewReader(data))
+	}
+}
+
+// RestoreAttrs sets the attributes encoded in r on the local node. The
+// column attributes of the index are set if fieldName is blank, otherwise
+// the row attributes of the field. Existing attributes which are not in r are
+// deleted, since backups contain every attribute of the store.
+func (api *API) RestoreAttrs(ctx context.Context, indexName, fieldName string, r io.Reader) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.RestoreAttrs")
+	defer span.Finish()
+
+	if err := api.validate(apiRestore); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	var store AttrStore
+	if fieldName == "" {
+		index := api.holder.Index(indexName)
+		if index == nil {
+			return newNotFoundError(ErrIndexNotFound)
+		}
+		store = index.ColumnAttrStore()
+	} else {
+		field := api.holder.Field(indexName, fieldName)
+		if field == nil {
+			return newNotFoundError(ErrFieldNotFound)
+		}
+		store = field.RowAttrStore()
+	}
+
+	m, err := decodeAttrStore(r)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+
+	// Delete attributes which were deleted since the restored backup was
+	// taken. Setting an attribute to nil deletes it.
+	blks, err := store.Blocks()
+	if err != nil {
+		return errors.Wrap(err, "getting blocks")
+	}
+	for _, blk := range blks {
+		data, err := store.BlockData(blk.ID)
+		if err != nil {
+			return errors.Wrap(err, "getting block data")
+		}
+		for id, attrs := range data {
+			for k := range attrs {
+				if _, ok := m[id][k]; ok {
+					continue
+				} else if m[id] == nil {
+					m[id] = make(map[string]interface{})
+				}
+				m[id][k] = nil
+			}
+		}
+	}
+	return errors.Wrap(store.SetBulkAttrs(m), "setting attrs")
+}
+
+// RestoreFragment replaces the local fragment with the fragment data in r,
+// as written by the fragment's WriteTo method.
+func (api *API) RestoreFragment(ctx context.Context, indexName, fieldName, viewName string, shard uint64, r io.Reader) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.RestoreFragment")
+	defer span.Finish()
+
+	if err := api.validate(apiRestore); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound)
+	}
+	view, err := field.createViewIfNotExists(viewName)
+	if err != nil {
+		return errors.Wrap(err, "creating view")
+	}
+	frag, err := view.CreateFragmentIfNotExists(shard)
+	if err != nil {
+		return errors.Wrap(err, "creating fragment")
+	}
+	if _, err := frag.ReadFrom(r); err != nil {
+		return errors.Wrap(err, "reading fragment")
+	}
+	return nil
+}
+
+// RestoreBlock replaces the bits of a single block of the local fragment with
+// the bits encoded in r by an incremental backup.
+func (api *API) RestoreBlock(ctx context.Context, indexName, fieldName, viewName string, shard uint64, block int, r io.Reader) error {
+	span, _ := tracing.StartSpanFromContext(ctx, "API.RestoreBlock")
+	defer span.Finish()
+
+	if err := api.validate(apiRestore); err != nil {
+		return errors.Wrap(err, "validating api method")
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "reading block")
+	}
+	bm := roaring.NewBitmap()
+	if err := bm.UnmarshalBinary(data); err != nil {
+		return NewBadRequestError(errors.Wrap(err, "decoding block"))
+	}
+
+	field := api.holder.Field(indexName, fieldName)
+	if field == nil {
+		return newNotFoundError(ErrFieldNotFound)
+	}
+
+	// Don't create fragments only to clear blocks in them.
+	if !bm.Any() && api.holder.fragment(indexName, fieldName, viewName, shard) == nil {
+		return nil
+	}
+
+	view, err := field.createViewIfNotExists(viewName)
+	if err != nil {
+		return errors.Wrap(err, "creating view")
+	}
+	frag, err := view.CreateFragmentIfNotExists(shard)
+	if err != nil {
+		return errors.Wrap(err, "creating fragment")
+	}
+	return errors.Wrap(frag.replaceBlock(block, bm.Slice()), "replacing block")
+}
+
+// ChangeEvents returns up to limit change events logged by the local node,
+// starting at offset, and the offset to read the following events from. If
+// no events are available then it waits until one is logged or ctx is done.
+func (api *API) ChangeEvents(ctx context.Context, offset int64, limit int) ([]ChangeEvent, int64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "API.ChangeEvents")
+	defer span.Finish()
+
+	if err := api.validate(apiChangeEvents); err != nil {
+		return nil, offset, errors.Wrap(err, "validating api method")
+	}
+	if api.holder.changeLog == nil {
+		return nil, offset, ErrChangeLogDisabled
+	}
+	return api.holder.changeLog.ReadEvents(ctx, offset, limit)
+}
+
 // GetTranslateData provides a reader for key translation logs starting at offset.
 func (api *API) GetTranslateData(ctx context.Context, offset int64) (io.ReadCloser, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "API.GetTranslateData")
@@ -1377,6 +2083,10 @@ const (
 	//apiVersion // not implemented
 	apiViews
 	apiApplySchema
+	apiBackup
+	apiRestore
+	apiChangeEvents
+	apiDeleteColumns
 )
 
 var methodsCommon = map[apiMethod]struct{}{
@@ -1399,6 +2109,7 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiExportCSV:            {},
 	apiFragmentBlockData:    {},
 	apiFragmentBlocks:       {},
+	apiFragmentData:         {},
 	apiField:                {},
 	apiFieldAttrDiff:        {},
 	apiImport:               {},
@@ -1411,4 +2122,8 @@ var methodsNormal = map[apiMethod]struct{}{
 	apiShardNodes:           {},
 	apiViews:                {},
 	apiApplySchema:          {},
+	apiBackup:               {},
+	apiRestore:              {},
+	apiDeleteColumns:        {},
+	apiChangeEvents:         {},
 }
@@ -15,14 +15,18 @@
 package pilosa_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/encoding/proto"
+	"github.com/pilosa/pilosa/roaring"
 	"github.com/pilosa/pilosa/server"
 	"github.com/pilosa/pilosa/test"
 )
@@ -242,6 +246,186 @@ func TestAPI_ImportValue(t *testing.T) {
 	})
 }
 
+func TestAPI_QueryStream(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	// Set a bit in more shards than are executed in a single batch.
+	const shardN = 30
+	var buf strings.Builder
+	for shard := uint64(0); shard < shardN; shard++ {
+		fmt.Fprintf(&buf, "Set(%d, f=1)\n", shard*pilosa.ShardWidth+shard)
+	}
+	c.Query(t, "i", buf.String())
+
+	var rows []*pilosa.Row
+	var counts []interface{}
+	if err := c[1].API.QueryStream(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Row(f=1) Row(f=2) Count(Row(f=1))`}, func(call int, result interface{}) error {
+		switch call {
+		case 0:
+			rows = append(rows, result.(*pilosa.Row))
+		case 1:
+			if cols := result.(*pilosa.Row).Columns(); len(cols) != 0 {
+				t.Fatalf("unexpected columns: %v", cols)
+			}
+		case 2:
+			counts = append(counts, result)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each shard should be passed as a separate row, in order.
+	if len(rows) != shardN {
+		t.Fatalf("unexpected number of rows: %d", len(rows))
+	}
+	for shard, row := range rows {
+		if cols := row.Columns(); !reflect.DeepEqual(cols, []uint64{uint64(shard)*pilosa.ShardWidth + uint64(shard)}) {
+			t.Fatalf("unexpected columns for shard %d: %v", shard, cols)
+		}
+	}
+	if !reflect.DeepEqual(counts, []interface{}{uint64(shardN)}) {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+}
+
+func TestAPI_ChangeEvents(t *testing.T) {
+	c := test.MustRunCluster(t, 1, []server.CommandOption{
+		server.OptCommandServerOptions(pilosa.OptServerChangeDataCapture(true, 0)),
+	})
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	if _, err := c[0].API.CreateField(context.Background(), "i", "n", pilosa.OptFieldTypeInt(-100, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Query(t, "i", `Set(1, f=10) Set(2, n=-5) Clear(1, f=10) SetRowAttrs(f, 10, x=1)`)
+
+	events, next, err := c[0].API.ChangeEvents(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	if exp := []string{pilosa.ChangeEventSet, pilosa.ChangeEventSetValue, pilosa.ChangeEventClear, pilosa.ChangeEventSetRowAttrs}; !reflect.DeepEqual(types, exp) {
+		t.Fatalf("unexpected event types: %v", types)
+	} else if e := events[1]; e.Field != "n" || e.Column != 2 || e.Value != -5 {
+		t.Fatalf("unexpected value event: %+v", e)
+	} else if e := events[3]; e.Row != 10 || !reflect.DeepEqual(e.Attrs, map[string]interface{}{"x": float64(1)}) {
+		t.Fatalf("unexpected attrs event: %+v", e)
+	}
+
+	// Reading from the end of the log waits for new events.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if events, _, err := c[0].API.ChangeEvents(ctx, next, 0); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 0 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
+
+func TestAPI_DeleteColumns(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{Keys: true, TrackExistence: true}, "f")
+	c.Query(t, "i", `Set("a", f=1) Set("b", f=1) Set("c", f=2)`)
+
+	// Unknown keys are not counted.
+	if n, err := c[1].API.DeleteColumns(context.Background(), "i", nil, []string{"a", "c", "z"}); err != nil {
+		t.Fatal(err)
+	} else if n != 2 {
+		t.Fatalf("unexpected deleted count: %d", n)
+	} else if n, err := c[0].API.DeleteColumns(context.Background(), "i", nil, []string{"y"}); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Fatalf("unexpected deleted count: %d", n)
+	}
+
+	if res := c.Query(t, "i", `Row(f=1)`).Results[0].(*pilosa.Row); !reflect.DeepEqual(res.Keys, []string{"b"}) {
+		t.Fatalf("unexpected keys: %v", res.Keys)
+	} else if res := c.Query(t, "i", `Count(Row(f=2))`).Results[0]; res != uint64(0) {
+		t.Fatalf("unexpected count: %v", res)
+	}
+
+	if _, err := c[0].API.DeleteColumns(context.Background(), "i", []uint64{1}, nil); err == nil || !strings.Contains(err.Error(), "index uses string keys") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure writes are only rejected by a paused node where they enter the
+// cluster, and not when they are forwarded to it by a node which let them in.
+func TestAPI_WritesPaused(t *testing.T) {
+	c := test.MustRunCluster(t, 2,
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerNodeID("node0"),
+				pilosa.OptServerClusterHasher(&offsetModHasher{}),
+			)},
+		[]server.CommandOption{
+			server.OptCommandServerOptions(
+				pilosa.OptServerNodeID("node1"),
+				pilosa.OptServerClusterHasher(&offsetModHasher{}),
+			)},
+	)
+	defer c.Close()
+	ctx := context.Background()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "f")
+
+	// Pause writes on node1 only, which owns shard 0.
+	pause := func(pause bool) {
+		buf, err := pilosa.MarshalInternalMessage(&pilosa.PauseWritesMessage{Pause: pause}, proto.Serializer{})
+		if err != nil {
+			t.Fatal(err)
+		} else if err := c[1].API.ClusterMessage(ctx, bytes.NewReader(buf)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pause(true)
+
+	t.Run("Entry", func(t *testing.T) {
+		if _, err := c[1].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Set(1, f=1)`}); err != pilosa.ErrWritesPaused {
+			t.Fatalf("expected ErrWritesPaused, got: %v", err)
+		}
+	})
+
+	t.Run("ForwardedQuery", func(t *testing.T) {
+		if _, err := c[0].API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: `Set(2, f=1)`}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ForwardedImport", func(t *testing.T) {
+		req := &pilosa.ImportRequest{Index: "k", Field: "f", RowIDs: []uint64{1}, ColumnKeys: []string{"a"}}
+		if err := c[0].API.Import(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ForwardedImportRoaring", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := roaring.NewBitmap(pilosa.ShardWidth + 3).WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		req := &pilosa.ImportRoaringRequest{Views: map[string][]byte{"": buf.Bytes()}}
+		if err := c[0].API.ImportRoaring(ctx, "i", "f", 0, false, req); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	pause(false)
+	if res := c.Query(t, "i", `Row(f=1)`).Results[0].(*pilosa.Row); !reflect.DeepEqual(res.Columns(), []uint64{2, 3}) {
+		t.Fatalf("unexpected columns: %v", res.Columns())
+	} else if res := c.Query(t, "k", `Row(f=1)`).Results[0].(*pilosa.Row); !reflect.DeepEqual(res.Keys, []string{"a"}) {
+		t.Fatalf("unexpected keys: %v", res.Keys)
+	}
+}
+
 // offsetModHasher represents a simple, mod-based hashing offset by 1.
 type offsetModHasher struct{}
 
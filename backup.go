@@ -0,0 +1,425 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilosa/pilosa/roaring"
+	"github.com/pkg/errors"
+)
+
+// Backup archive file names. Attributes and fragments are stored below
+// backupIndexesDir, e.g. "indexes/i/fields/f/views/standard/fragments/0".
+// Incremental backups store single blocks of a fragment below the fragment's
+// path, e.g. "indexes/i/fields/f/views/standard/fragments/0/blocks/2".
+const (
+	backupManifestName = "manifest"
+	backupSchemaName   = "schema"
+	backupKeysName     = "keys"
+	backupIndexesDir   = "indexes"
+	backupAttrsName    = "attrs"
+	backupBlocksDir    = "blocks"
+)
+
+// backupIDName is the name of the file in the data directory which holds the
+// ID of the last backup taken or restored by the coordinator.
+const backupIDName = ".backup"
+
+// BackupManifest describes the contents of a backup archive. It is the first
+// file in every archive so that the next incremental backup can be based on it.
+type BackupManifest struct {
+	// Unique identifier of the backup.
+	ID string `json:"id"`
+
+	// Identifier of the backup this incremental backup is based on. Blank
+	// for full backups.
+	Parent string `json:"parent,omitempty"`
+
+	// Size of the key translation log when the backup was taken.
+	KeysOffset int64 `json:"keysOffset"`
+
+	// Block checksums of every fragment when the backup was taken, keyed by
+	// the fragment's archive path.
+	Fragments map[string][]FragmentBlock `json:"fragments"`
+}
+
+// ReadBackupManifest returns the manifest of the backup archive read from r.
+func ReadBackupManifest(r io.Reader) (*BackupManifest, error) {
+	hdr, err := tar.NewReader(r).Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading archive")
+	} else if hdr.Name != backupManifestName {
+		return nil, errors.Wrap(ErrInvalidBackup, "missing manifest")
+	}
+	return readBackupManifest(r)
+}
+
+// readBackupManifest decodes the manifest file of an archive from r.
+func readBackupManifest(r io.Reader) (*BackupManifest, error) {
+	m := &BackupManifest{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, errors.Wrap(ErrInvalidBackup, err.Error())
+	}
+	return m, nil
+}
+
+// lastBackupID returns the ID of the last backup taken or restored by the
+// holder. Returns a blank ID if there is none.
+func (h *Holder) lastBackupID() (string, error) {
+	buf, err := ioutil.ReadFile(filepath.Join(h.Path, backupIDName))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "reading backup id")
+	}
+	return strings.TrimSpace(string(buf)), nil
+}
+
+// setLastBackupID records the ID of a backup taken or restored by the holder.
+func (h *Holder) setLastBackupID(id string) error {
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(h.Path, backupIDName), []byte(id), 0666), "writing backup id")
+}
+
+// writePauseTimeout is how long a backup pauses writes on the other nodes
+// of the cluster unless it renews the pause. It allows writes to resume if
+// the coordinator fails while taking a backup.
+const writePauseTimeout = time.Minute
+
+// writeGate blocks new mutations while paused and tracks the number of
+// mutations in flight so that a pause can wait for them to complete.
+type writeGate struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	paused   bool
+	deadline time.Time
+	n        int
+}
+
+// isPaused returns true if the gate is paused and the pause has not expired.
+// The caller must hold g.mu.
+func (g *writeGate) isPaused() bool {
+	return g.paused && (g.deadline.IsZero() || time.Now().Before(g.deadline))
+}
+
+// enter registers a new mutation. Returns ErrWritesPaused if the gate is
+// paused. Every successful call must be followed by a call to exit.
+func (g *writeGate) enter() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.isPaused() {
+		return ErrWritesPaused
+	}
+	g.n++
+	return nil
+}
+
+// exit marks a mutation registered by enter as complete.
+func (g *writeGate) exit() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.n--
+	if g.n == 0 && g.cond != nil {
+		g.cond.Broadcast()
+	}
+}
+
+// pause rejects new mutations and waits for those in flight to complete.
+// The pause expires after timeout unless it is renewed. A zero timeout
+// pauses until resume is called.
+func (g *writeGate) pause(timeout time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cond == nil {
+		g.cond = sync.NewCond(&g.mu)
+	}
+	g.paused, g.deadline = true, time.Time{}
+	if timeout > 0 {
+		g.deadline = time.Now().Add(timeout)
+	}
+	for g.n > 0 {
+		g.cond.Wait()
+	}
+}
+
+// renew extends a pause so that it expires after timeout. Returns
+// ErrWritesResumed if the gate is no longer paused, since mutations may have
+// been accepted after the pause expired.
+func (g *writeGate) renew(timeout time.Duration) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.isPaused() {
+		return ErrWritesResumed
+	}
+	g.deadline = time.Now().Add(timeout)
+	return nil
+}
+
+// resume allows mutations again.
+func (g *writeGate) resume() {
+	g.mu.Lock()
+	g.paused, g.deadline = false, time.Time{}
+	g.mu.Unlock()
+}
+
+// backupIndexPath returns the archive directory of an index, or of a field
+// within the index if field is not blank.
+func backupIndexPath(index, field string) string {
+	if field == "" {
+		return path.Join(backupIndexesDir, index)
+	}
+	return path.Join(backupIndexesDir, index, "fields", field)
+}
+
+// backupAttrsPath returns the archive path of the column attributes of an
+// index, or the row attributes of a field if field is not blank.
+func backupAttrsPath(index, field string) string {
+	return path.Join(backupIndexPath(index, field), backupAttrsName)
+}
+
+// backupFragmentPath returns the archive path of a fragment.
+func backupFragmentPath(index, field, view string, shard uint64) string {
+	return path.Join(backupIndexPath(index, field), "views", view, "fragments", strconv.FormatUint(shard, 10))
+}
+
+// backupBlockPath returns the archive path of a single block of a fragment.
+func backupBlockPath(index, field, view string, shard uint64, block int) string {
+	return path.Join(backupFragmentPath(index, field, view, shard), backupBlocksDir, strconv.Itoa(block))
+}
+
+// backupFile identifies a single attribute store, fragment or fragment block
+// in a backup.
+type backupFile struct {
+	Index string
+	Field string
+	View  string
+	Shard uint64
+
+	// True if the file contains attributes rather than fragment data.
+	Attrs bool
+
+	// True if the file contains a single block of a fragment.
+	IsBlock bool
+	Block   int
+}
+
+// parseBackupPath parses an archive path created by backupAttrsPath,
+// backupFragmentPath or backupBlockPath.
+func parseBackupPath(name string) (backupFile, error) {
+	var f backupFile
+	a := strings.Split(name, "/")
+	if len(a) < 3 || a[0] != backupIndexesDir {
+		return f, errors.Wrapf(ErrInvalidBackup, "unexpected file %q", name)
+	}
+	f.Index, a = a[1], a[2:]
+
+	if len(a) == 1 && a[0] == backupAttrsName {
+		f.Attrs = true
+		return f, nil
+	} else if len(a) < 3 || a[0] != "fields" {
+		return f, errors.Wrapf(ErrInvalidBackup, "unexpected file %q", name)
+	}
+	f.Field, a = a[1], a[2:]
+
+	if len(a) == 1 && a[0] == backupAttrsName {
+		f.Attrs = true
+		return f, nil
+	} else if len(a) < 4 || a[0] != "views" || a[2] != "fragments" {
+		return f, errors.Wrapf(ErrInvalidBackup, "unexpected file %q", name)
+	}
+	f.View = a[1]
+
+	shard, err := strconv.ParseUint(a[3], 10, 64)
+	if err != nil {
+		return f, errors.Wrapf(ErrInvalidBackup, "invalid shard in %q", name)
+	}
+	f.Shard, a = shard, a[4:]
+
+	if len(a) == 0 {
+		return f, nil
+	} else if len(a) != 2 || a[0] != backupBlocksDir {
+		return f, errors.Wrapf(ErrInvalidBackup, "unexpected file %q", name)
+	}
+
+	block, err := strconv.Atoi(a[1])
+	if err != nil || block < 0 {
+		return f, errors.Wrapf(ErrInvalidBackup, "invalid block in %q", name)
+	}
+	f.IsBlock, f.Block = true, block
+	return f, nil
+}
+
+// changedBlocks returns the IDs of blocks whose checksums differ between prev
+// and blocks, including blocks which only exist in one of them. Both slices
+// must be sorted by block ID.
+func changedBlocks(prev, blocks []FragmentBlock) []int {
+	var ids []int
+	i, j := 0, 0
+	for i < len(prev) || j < len(blocks) {
+		switch {
+		case j == len(blocks) || (i < len(prev) && prev[i].ID < blocks[j].ID):
+			ids = append(ids, prev[i].ID)
+			i++
+		case i == len(prev) || blocks[j].ID < prev[i].ID:
+			ids = append(ids, blocks[j].ID)
+			j++
+		default:
+			if !bytes.Equal(prev[i].Checksum, blocks[j].Checksum) {
+				ids = append(ids, blocks[j].ID)
+			}
+			i++
+			j++
+		}
+	}
+	return ids
+}
+
+// encodeBlock encodes the bits of a block, as returned by the fragment's
+// blockData method, as a roaring bitmap of fragment positions.
+func encodeBlock(rowIDs, columnIDs []uint64) ([]byte, error) {
+	positions := make([]uint64, len(rowIDs))
+	for i := range rowIDs {
+		positions[i] = rowIDs[i]*ShardWidth + columnIDs[i]%ShardWidth
+	}
+
+	var buf bytes.Buffer
+	if _, err := roaring.NewBitmap(positions...).WriteTo(&buf); err != nil {
+		return nil, errors.Wrap(err, "writing bitmap")
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBackupFile writes data to tw as a single file.
+func writeBackupFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0666,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Wrap(err, "writing header")
+	}
+	if _, err := tw.Write(data); err != nil {
+		return errors.Wrap(err, "writing data")
+	}
+	return nil
+}
+
+// writeBackupKeys writes the key translation log of s from offset up to sz
+// to tw.
+func writeBackupKeys(tw *tar.Writer, s *TranslateFile, offset, sz int64) error {
+	if offset > sz {
+		return errors.Wrap(ErrInvalidBackup, "key translation log is smaller than in previous backup")
+	}
+
+	file, err := os.Open(s.Path)
+	if err != nil {
+		return errors.Wrap(err, "opening file")
+	}
+	defer file.Close()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    backupKeysName,
+		Mode:    0666,
+		Size:    sz - offset,
+		ModTime: time.Now(),
+	}); err != nil {
+		return errors.Wrap(err, "writing header")
+	}
+	if _, err := io.Copy(tw, io.NewSectionReader(file, offset, sz-offset)); err != nil {
+		return errors.Wrap(err, "copying")
+	}
+	return nil
+}
+
+// encodeAttrStore encodes every attribute set in store. Each set is written
+// as the uvarint id and length of the set followed by the encoded set, so
+// that attribute value types are preserved.
+func encodeAttrStore(store AttrStore) ([]byte, error) {
+	blks, err := store.Blocks()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting blocks")
+	}
+
+	var buf []byte
+	for _, blk := range blks {
+		m, err := store.BlockData(blk.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting block data")
+		}
+
+		ids := make([]uint64, 0, len(m))
+		for id := range m {
+			ids = append(ids, id)
+		}
+		sort.Sort(uint64Slice(ids))
+
+		for _, id := range ids {
+			data, err := EncodeAttrs(m[id])
+			if err != nil {
+				return nil, errors.Wrap(err, "encoding attrs")
+			}
+			var hdr [2 * binary.MaxVarintLen64]byte
+			n := binary.PutUvarint(hdr[:], id)
+			n += binary.PutUvarint(hdr[n:], uint64(len(data)))
+			buf = append(buf, hdr[:n]...)
+			buf = append(buf, data...)
+		}
+	}
+	return buf, nil
+}
+
+// decodeAttrStore decodes attribute sets encoded by encodeAttrStore.
+func decodeAttrStore(r io.Reader) (map[uint64]map[string]interface{}, error) {
+	br := bufio.NewReader(r)
+	m := make(map[uint64]map[string]interface{})
+	for {
+		id, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return m, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "reading id")
+		}
+
+		sz, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading length")
+		}
+		data := make([]byte, sz)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, errors.Wrap(err, "reading attrs")
+		}
+
+		attrs, err := DecodeAttrs(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding attrs")
+		}
+		m[id] = attrs
+	}
+}
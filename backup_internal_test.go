@@ -0,0 +1,109 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteGate(t *testing.T) {
+	var g writeGate
+	if err := g.enter(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pausing waits for the write in flight to complete.
+	paused := make(chan struct{})
+	go func() { g.pause(0); close(paused) }()
+	select {
+	case <-paused:
+		t.Fatal("pause returned before write completed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	g.exit()
+	<-paused
+
+	if err := g.enter(); err != ErrWritesPaused {
+		t.Fatalf("expected ErrWritesPaused, got: %v", err)
+	}
+
+	g.resume()
+	if err := g.enter(); err != nil {
+		t.Fatal(err)
+	}
+	g.exit()
+
+	// A pause expires unless it is renewed in time.
+	g.pause(20 * time.Millisecond)
+	if err := g.renew(20 * time.Millisecond); err != nil {
+		t.Fatal(err)
+	} else if err := g.enter(); err != ErrWritesPaused {
+		t.Fatalf("expected ErrWritesPaused, got: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := g.enter(); err != nil {
+		t.Fatal(err)
+	}
+	g.exit()
+	if err := g.renew(20 * time.Millisecond); err != ErrWritesResumed {
+		t.Fatalf("expected ErrWritesResumed, got: %v", err)
+	}
+}
+
+func TestParseBackupPath(t *testing.T) {
+	for _, tt := range []backupFile{
+		{Index: "i", Attrs: true},
+		{Index: "i", Field: "f", Attrs: true},
+		{Index: "i", Field: "f", View: "standard", Shard: 3},
+		{Index: "i", Field: "f", View: "bsig_f", Shard: 0},
+		{Index: "i", Field: "f", View: "standard", Shard: 3, IsBlock: true, Block: 7},
+	} {
+		var name string
+		if tt.Attrs {
+			name = backupAttrsPath(tt.Index, tt.Field)
+		} else if tt.IsBlock {
+			name = backupBlockPath(tt.Index, tt.Field, tt.View, tt.Shard, tt.Block)
+		} else {
+			name = backupFragmentPath(tt.Index, tt.Field, tt.View, tt.Shard)
+		}
+		if f, err := parseBackupPath(name); err != nil {
+			t.Fatalf("parsing %q: %v", name, err)
+		} else if f != tt {
+			t.Fatalf("parsing %q: expected %+v, got %+v", name, tt, f)
+		}
+	}
+
+	for _, name := range []string{"foo", "indexes/i", "indexes/i/fields/f", "indexes/i/fields/f/views/standard/fragments/x", "indexes/i/fields/f/views/standard/fragments/0/blocks/-1"} {
+		if _, err := parseBackupPath(name); err == nil {
+			t.Fatalf("expected error parsing %q", name)
+		}
+	}
+}
+
+func TestChangedBlocks(t *testing.T) {
+	prev := []FragmentBlock{{ID: 0, Checksum: []byte{1}}, {ID: 1, Checksum: []byte{2}}, {ID: 3, Checksum: []byte{3}}}
+	blocks := []FragmentBlock{{ID: 0, Checksum: []byte{1}}, {ID: 1, Checksum: []byte{9}}, {ID: 2, Checksum: []byte{4}}}
+	if ids := changedBlocks(prev, blocks); !reflect.DeepEqual(ids, []int{1, 2, 3}) {
+		t.Fatalf("unexpected changed blocks: %v", ids)
+	}
+	if ids := changedBlocks(nil, blocks); !reflect.DeepEqual(ids, []int{0, 1, 2}) {
+		t.Fatalf("unexpected changed blocks: %v", ids)
+	}
+	if ids := changedBlocks(prev, prev); ids != nil {
+		t.Fatalf("unexpected changed blocks: %v", ids)
+	}
+}
@@ -50,6 +50,10 @@ type cache interface {
 	// Returns an ordered list of the top ranked bitmaps.
 	Top() []bitmapPair
 
+	// Returns a copy of a count-min sketch of the counts of the rows
+	// currently in the cache.
+	Sketch() CountMinSketch
+
 	// SetStats defines the stats client used in the cache.
 	SetStats(s stats.StatsClient)
 }
@@ -59,6 +63,9 @@ type lruCache struct {
 	cache  *lru.Cache
 	counts map[uint64]uint64
 	stats  stats.StatsClient
+
+	// sketch is built on first use and then kept in step with counts.
+	sketch CountMinSketch
 }
 
 // newLRUCache returns a new instance of LRUCache.
@@ -79,6 +86,9 @@ func (c *lruCache) BulkAdd(id, n uint64) {
 
 // Add adds a count to the cache.
 func (c *lruCache) Add(id, n uint64) {
+	if c.sketch != nil {
+		c.sketch.add(id, n-c.counts[id])
+	}
 	c.cache.Add(id, n)
 	c.counts[id] = n
 }
@@ -122,12 +132,30 @@ func (c *lruCache) Top() []bitmapPair {
 	return a
 }
 
+// Sketch returns a count-min sketch of the counts of the rows currently in
+// the cache. Rows evicted from the cache are removed from the sketch.
+func (c *lruCache) Sketch() CountMinSketch {
+	if c.sketch == nil {
+		c.sketch = newCountMinSketch()
+		for id, n := range c.counts {
+			c.sketch.add(id, n)
+		}
+	}
+	return c.sketch.clone()
+}
+
 // SetStats defines the stats client used in the cache.
 func (c *lruCache) SetStats(s stats.StatsClient) {
 	c.stats = s
 }
 
-func (c *lruCache) onEvicted(key lru.Key, _ interface{}) { delete(c.counts, key.(uint64)) }
+func (c *lruCache) onEvicted(key lru.Key, _ interface{}) {
+	id := key.(uint64)
+	if c.sketch != nil {
+		c.sketch.add(id, -c.counts[id])
+	}
+	delete(c.counts, id)
+}
 
 // Ensure LRUCache implements Cache.
 var _ cache = &lruCache{}
@@ -151,6 +179,9 @@ type rankCache struct {
 	// thresholdValue is the value of the last item in the cache
 	thresholdValue uint64
 
+	// sketch is built on first use and then kept in step with entries.
+	sketch CountMinSketch
+
 	stats stats.StatsClient
 }
 
@@ -175,7 +206,7 @@ func (c *rankCache) Add(id uint64, n uint64) {
 		return
 	}
 
-	c.entries[id] = n
+	c.setEntry(id, n)
 
 	c.invalidate()
 }
@@ -184,10 +215,20 @@ func (c *rankCache) Add(id uint64, n uint64) {
 func (c *rankCache) BulkAdd(id uint64, n uint64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if n < c.thresholdValue {
+	// Ignore if the column count is below the threshold,
+	// unless the count is 0, which clears the cache value.
+	if n < c.thresholdValue && n > 0 {
 		return
 	}
 
+	c.setEntry(id, n)
+}
+
+// setEntry sets the count of an entry and updates the sketch.
+func (c *rankCache) setEntry(id uint64, n uint64) {
+	if c.sketch != nil {
+		c.sketch.add(id, n-c.entries[id])
+	}
 	c.entries[id] = n
 }
 
@@ -274,6 +315,9 @@ func (c *rankCache) recalculate() {
 	if len(c.entries) > c.thresholdBuffer {
 		c.stats.Count("cache.threshold", 1, 1.0)
 		for _, pair := range removeItems {
+			if c.sketch != nil {
+				c.sketch.add(pair.ID, -c.entries[pair.ID])
+			}
 			delete(c.entries, pair.ID)
 		}
 	}
@@ -287,6 +331,22 @@ func (c *rankCache) SetStats(s stats.StatsClient) {
 // Top returns an ordered list of pairs.
 func (c *rankCache) Top() []bitmapPair { return c.rankings }
 
+// Sketch returns a count-min sketch of the counts of the rows currently in
+// the cache. Rows trimmed from the cache when it exceeds its threshold
+// buffer are removed from the sketch, so it does not cover every row of the
+// fragment.
+func (c *rankCache) Sketch() CountMinSketch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sketch == nil {
+		c.sketch = newCountMinSketch()
+		for id, n := range c.entries {
+			c.sketch.add(id, n)
+		}
+	}
+	return c.sketch.clone()
+}
+
 // WriteTo writes the cache to w.
 func (c *rankCache) WriteTo(w io.Writer) (n int64, err error) {
 	panic("FIXME: TODO")
@@ -482,6 +542,8 @@ func (c nopCache) Len() int                   { return 0 }
 func (c nopCache) Recalculate()               {}
 func (c nopCache) SetStats(stats.StatsClient) {}
 
+func (c nopCache) Sketch() CountMinSketch { return nil }
+
 func (c nopCache) Top() []bitmapPair {
 	return []bitmapPair{}
 }
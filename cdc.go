@@ -0,0 +1,402 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Change event types.
+const (
+	ChangeEventSet            = "set"
+	ChangeEventClear          = "clear"
+	ChangeEventClearRow       = "clearRow"
+	ChangeEventSetValue       = "setValue"
+	ChangeEventClearValue     = "clearValue"
+	ChangeEventSetRowAttrs    = "setRowAttrs"
+	ChangeEventSetColumnAttrs = "setColumnAttrs"
+)
+
+// Change log errors.
+var (
+	ErrChangeLogClosed        = errors.New("pilosa: change log closed")
+	ErrChangeLogDisabled      = errors.New("change data capture is disabled")
+	ErrInvalidChangeLogOffset = errors.New("invalid change log offset")
+	ErrChangeLogTruncated     = errors.New("change log offset has been truncated")
+)
+
+// changeLogSegmentN is the number of segments which the maximum size of a
+// change log is split into. Once the last segment holds its share of the
+// maximum size, events are appended to a new segment.
+const changeLogSegmentN = 8
+
+// ChangeEvent represents a single mutation applied to the local node.
+type ChangeEvent struct {
+	// Position of the event in the change log. The next event starts at
+	// the offset returned along with the event.
+	Offset int64 `json:"offset"`
+
+	Type   string `json:"type"`
+	Index  string `json:"index"`
+	Field  string `json:"field,omitempty"`
+	View   string `json:"view,omitempty"`
+	Shard  uint64 `json:"shard"`
+	Row    uint64 `json:"row"`
+	Column uint64 `json:"column"`
+	Value  int64  `json:"value,omitempty"`
+
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// ChangeLog is an append-only log of change events. Each event is stored as
+// a line of JSON and is identified by its byte offset in the log. Events are
+// written to segment files in the Path directory, each named by the offset of
+// its first event. Once the log grows past MaxSize, the segments holding the
+// oldest events are removed and their offsets can no longer be read.
+type ChangeLog struct {
+	mu          sync.RWMutex
+	segments    []*changeLogSegment // ordered by offset; events are appended to the last
+	n           int64               // offset following the last event
+	writeNotify chan struct{}
+
+	once    sync.Once
+	closing chan struct{}
+
+	Path string
+
+	// Maximum number of bytes of events retained. When it is exceeded, the
+	// oldest segments are removed until it is no longer exceeded. Zero
+	// retains every event.
+	MaxSize int64
+}
+
+// changeLogSegment is a file holding a contiguous range of change events.
+type changeLogSegment struct {
+	file *os.File
+	base int64 // offset of the first event in the segment
+	n    int64 // number of bytes of events in the segment
+}
+
+// NewChangeLog returns a new instance of ChangeLog.
+func NewChangeLog() *ChangeLog {
+	return &ChangeLog{
+		writeNotify: make(chan struct{}),
+		closing:     make(chan struct{}),
+	}
+}
+
+// Open opens the change log directory, creating it if it doesn't exist. A
+// partially written event at the end of the last segment is discarded.
+func (l *ChangeLog) Open() error {
+	// Reset closing in case the log is being reopened.
+	l.once = sync.Once{}
+	l.closing = make(chan struct{})
+
+	if err := os.MkdirAll(l.Path, 0777); err != nil {
+		return errors.Wrap(err, "creating directory")
+	}
+
+	if err := func() error {
+		// Find the segments in order of their first event.
+		dir, err := os.Open(l.Path)
+		if err != nil {
+			return errors.Wrap(err, "opening directory")
+		}
+		names, err := dir.Readdirnames(0)
+		dir.Close()
+		if err != nil {
+			return errors.Wrap(err, "reading directory")
+		}
+		var bases []int64
+		for _, name := range names {
+			if base, err := strconv.ParseInt(name, 10, 64); err == nil {
+				bases = append(bases, base)
+			}
+		}
+		sort.Slice(bases, func(i, j int) bool { return bases[i] < bases[j] })
+
+		// Start a new log with an empty segment.
+		if len(bases) == 0 {
+			l.n = 0
+			return l.openSegment(0)
+		}
+
+		for _, base := range bases {
+			if err := l.openSegment(base); err != nil {
+				return err
+			}
+		}
+
+		// Discard a partially written event from the last segment.
+		seg := l.segments[len(l.segments)-1]
+		end, err := lastLineEnd(seg.file, seg.n)
+		if err != nil {
+			return errors.Wrap(err, "finding last event")
+		} else if end != seg.n {
+			if err := seg.file.Truncate(end); err != nil {
+				return errors.Wrap(err, "truncating partial event")
+			}
+			seg.n = end
+		}
+		l.n = seg.base + seg.n
+		return nil
+	}(); err != nil {
+		l.closeSegments()
+		return err
+	}
+	return nil
+}
+
+// segmentPath returns the path of the segment whose first event is at
+// offset base.
+func (l *ChangeLog) segmentPath(base int64) string {
+	return filepath.Join(l.Path, fmt.Sprintf("%020d", base))
+}
+
+// openSegment opens the segment whose first event is at offset base,
+// creating it if it doesn't exist, and adds it to the end of the log.
+func (l *ChangeLog) openSegment(base int64) error {
+	file, err := os.OpenFile(l.segmentPath(base), os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return errors.Wrap(err, "opening segment")
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrap(err, "statting segment")
+	}
+	l.segments = append(l.segments, &changeLogSegment{file: file, base: base, n: fi.Size()})
+	return nil
+}
+
+// closeSegments closes the file of every segment.
+func (l *ChangeLog) closeSegments() (err error) {
+	for _, seg := range l.segments {
+		if e := seg.file.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	l.segments = nil
+	return err
+}
+
+// Close closes the change log.
+func (l *ChangeLog) Close() (err error) {
+	l.once.Do(func() {
+		close(l.closing)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		err = l.closeSegments()
+	})
+	return err
+}
+
+// size returns the offset following the last event in the change log.
+func (l *ChangeLog) size() int64 {
+	l.mu.RLock()
+	n := l.n
+	l.mu.RUnlock()
+	return n
+}
+
+// base returns the offset of the first event retained in the change log.
+func (l *ChangeLog) base() int64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if len(l.segments) == 0 {
+		return l.n
+	}
+	return l.segments[0].base
+}
+
+// WriteNotify returns a channel that is closed when a new event is written.
+func (l *ChangeLog) WriteNotify() <-chan struct{} {
+	l.mu.RLock()
+	ch := l.writeNotify
+	l.mu.RUnlock()
+	return ch
+}
+
+// append writes events to the end of the log in order. The offset of each
+// event is assigned by the log.
+func (l *ChangeLog) append(events ...ChangeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.segments) == 0 {
+		return ErrChangeLogClosed
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range events {
+		events[i].Offset = l.n + int64(buf.Len())
+		if err := enc.Encode(&events[i]); err != nil {
+			return errors.Wrap(err, "encoding event")
+		}
+	}
+
+	seg := l.segments[len(l.segments)-1]
+	if _, err := seg.file.WriteAt(buf.Bytes(), seg.n); err != nil {
+		return errors.Wrap(err, "writing events")
+	}
+	seg.n += int64(buf.Len())
+	l.n += int64(buf.Len())
+
+	// Notify readers of new events.
+	close(l.writeNotify)
+	l.writeNotify = make(chan struct{})
+
+	return errors.Wrap(l.rotate(), "rotating segments")
+}
+
+// rotate starts a new segment once the last one holds its share of MaxSize,
+// and removes the oldest segments while the log exceeds MaxSize. Segments
+// are removed whole so that writes never wait for events to be copied.
+func (l *ChangeLog) rotate() error {
+	if l.MaxSize <= 0 {
+		return nil
+	}
+
+	if seg := l.segments[len(l.segments)-1]; seg.n >= l.MaxSize/changeLogSegmentN {
+		if err := l.openSegment(l.n); err != nil {
+			return err
+		}
+	}
+
+	for len(l.segments) > 1 && l.n-l.segments[0].base > l.MaxSize {
+		seg := l.segments[0]
+		if err := seg.file.Close(); err != nil {
+			return errors.Wrap(err, "closing segment")
+		} else if err := os.Remove(l.segmentPath(seg.base)); err != nil {
+			return errors.Wrap(err, "removing segment")
+		}
+		l.segments = l.segments[1:]
+	}
+	return nil
+}
+
+// ReadEvents returns up to limit events starting at offset, and the offset
+// of the event following the last one returned. If no events are available
+// then it waits until one is written or ctx is done, in which case no events
+// are returned.
+func (l *ChangeLog) ReadEvents(ctx context.Context, offset int64, limit int) ([]ChangeEvent, int64, error) {
+	for {
+		// Obtain notification channel before we check for new events.
+		notify := l.WriteNotify()
+
+		if events, next, err := l.readEvents(offset, limit); err != nil || len(events) > 0 {
+			return events, next, err
+		}
+
+		// Wait for new events or close.
+		select {
+		case <-ctx.Done():
+			return nil, offset, nil
+		case <-l.closing:
+			return nil, offset, ErrChangeLogClosed
+		case <-notify:
+		}
+	}
+}
+
+// readEvents decodes up to limit events written after offset.
+func (l *ChangeLog) readEvents(offset int64, limit int) ([]ChangeEvent, int64, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.segments) == 0 {
+		return nil, offset, ErrChangeLogClosed
+	}
+
+	// Ensure offset is the start of a retained event. The byte before
+	// every event but the first of a segment is the end of the previous
+	// event.
+	if offset < 0 || offset > l.n {
+		return nil, offset, ErrInvalidChangeLogOffset
+	} else if offset < l.segments[0].base {
+		return nil, offset, ErrChangeLogTruncated
+	}
+	i := sort.Search(len(l.segments), func(i int) bool { return l.segments[i].base > offset }) - 1
+	if seg := l.segments[i]; offset > seg.base {
+		var b [1]byte
+		if _, err := seg.file.ReadAt(b[:], offset-seg.base-1); err != nil {
+			return nil, offset, errors.Wrap(err, "reading file")
+		} else if b[0] != '\n' {
+			return nil, offset, ErrInvalidChangeLogOffset
+		}
+	}
+
+	var events []ChangeEvent
+	for ; i < len(l.segments) && (limit <= 0 || len(events) < limit); i++ {
+		seg := l.segments[i]
+		pos := offset - seg.base
+		br := bufio.NewReader(io.NewSectionReader(seg.file, pos, seg.n-pos))
+		for limit <= 0 || len(events) < limit {
+			line, err := br.ReadBytes('\n')
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, offset, errors.Wrap(err, "reading file")
+			}
+
+			var event ChangeEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				return nil, offset, errors.Wrap(err, "decoding event")
+			}
+			events = append(events, event)
+			offset += int64(len(line))
+		}
+	}
+	return events, offset, nil
+}
+
+// lastLineEnd returns the offset following the last newline in the first sz
+// bytes of r, or zero if there is none.
+func lastLineEnd(r io.ReaderAt, sz int64) (int64, error) {
+	buf := make([]byte, 4096)
+	for end := sz; end > 0; {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+		p := buf[:end-start]
+		if _, err := r.ReadAt(p, start); err != nil {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(p, '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
@@ -0,0 +1,293 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/roaring"
+)
+
+// mustOpenChangeLog returns a new, opened change log at a temporary path.
+func mustOpenChangeLog() *ChangeLog {
+	path, err := ioutil.TempDir("", "pilosa-cdc-")
+	if err != nil {
+		panic(err)
+	}
+
+	l := NewChangeLog()
+	l.Path = path
+	if err := l.Open(); err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func TestChangeLog_ReadEvents(t *testing.T) {
+	l := mustOpenChangeLog()
+	defer os.RemoveAll(l.Path)
+	defer l.Close()
+
+	if err := l.append(
+		ChangeEvent{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Row: 1, Column: 2},
+		ChangeEvent{Type: ChangeEventSetValue, Index: "i", Field: "n", Column: 3, Value: -4},
+		ChangeEvent{Type: ChangeEventClearRow, Index: "i", Field: "f", View: viewStandard, Row: 1},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Read the first two events and resume from the returned offset.
+	events, next, err := l.ReadEvents(context.Background(), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(events) != 2 || events[0].Offset != 0 || events[1].Offset == 0 {
+		t.Fatalf("unexpected events: %+v", events)
+	} else if events[1].Type != ChangeEventSetValue || events[1].Value != -4 {
+		t.Fatalf("unexpected event: %+v", events[1])
+	}
+
+	events, next, err = l.ReadEvents(context.Background(), next, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(events) != 1 || events[0].Type != ChangeEventClearRow || events[0].Offset == 0 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Offsets in the middle of an event are rejected.
+	if _, _, err := l.ReadEvents(context.Background(), 1, 0); err != ErrInvalidChangeLogOffset {
+		t.Fatalf("expected ErrInvalidChangeLogOffset, got: %v", err)
+	}
+
+	// Reading past the last event waits for the next one.
+	ch := make(chan []ChangeEvent)
+	go func() {
+		events, _, err := l.ReadEvents(context.Background(), next, 0)
+		if err != nil {
+			t.Error(err)
+		}
+		ch <- events
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if err := l.append(ChangeEvent{Type: ChangeEventClear, Index: "i", Field: "f", Row: 1, Column: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if events := <-ch; len(events) != 1 || events[0].Type != ChangeEventClear || events[0].Offset != next {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	// Waiting stops when the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if events, _, err := l.ReadEvents(ctx, l.size(), 0); err != nil || len(events) != 0 {
+		t.Fatalf("unexpected events: %+v, err: %v", events, err)
+	}
+}
+
+// Ensure a partially written event is discarded when the log is reopened.
+func TestChangeLog_Reopen(t *testing.T) {
+	l := mustOpenChangeLog()
+	defer os.RemoveAll(l.Path)
+	defer l.Close()
+
+	if err := l.append(ChangeEvent{Type: ChangeEventSet, Index: "i", Field: "f", Row: 1, Column: 2}); err != nil {
+		t.Fatal(err)
+	}
+	sz := l.size()
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.OpenFile(l.segmentPath(0), os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err := f.Write([]byte(`{"offset":`)); err != nil {
+		t.Fatal(err)
+	} else if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Open(); err != nil {
+		t.Fatal(err)
+	} else if l.size() != sz {
+		t.Fatalf("unexpected size: %d != %d", l.size(), sz)
+	}
+	if err := l.append(ChangeEvent{Type: ChangeEventClear, Index: "i", Field: "f", Row: 1, Column: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if events, _, err := l.ReadEvents(context.Background(), 0, 0); err != nil {
+		t.Fatal(err)
+	} else if len(events) != 2 || events[1].Type != ChangeEventClear {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+}
+
+// Ensure the oldest segments are removed once the log exceeds its maximum
+// size, and that events are read across segments, including after a reopen.
+func TestChangeLog_Truncate(t *testing.T) {
+	l := mustOpenChangeLog()
+	defer os.RemoveAll(l.Path)
+	defer l.Close()
+	l.MaxSize = 1000
+
+	// Append events until the first ones are truncated.
+	var offsets []int64
+	for l.base() == 0 {
+		events := []ChangeEvent{{Type: ChangeEventSet, Index: "i", Field: "f", Row: 1, Column: uint64(len(offsets))}}
+		if err := l.append(events...); err != nil {
+			t.Fatal(err)
+		}
+		offsets = append(offsets, events[0].Offset)
+	}
+	if sz := l.size() - l.base(); sz > l.MaxSize {
+		t.Fatalf("unexpected retained size: %d", sz)
+	} else if len(l.segments) < 2 {
+		t.Fatalf("unexpected segment count: %d", len(l.segments))
+	}
+
+	// Reading a truncated offset fails while the remaining events are intact.
+	check := func(n int) {
+		t.Helper()
+		if _, _, err := l.ReadEvents(context.Background(), 0, 0); err != ErrChangeLogTruncated {
+			t.Fatalf("expected ErrChangeLogTruncated, got: %v", err)
+		}
+		events, next, err := l.ReadEvents(context.Background(), l.base(), 0)
+		if err != nil {
+			t.Fatal(err)
+		} else if next != l.size() || len(events) != n {
+			t.Fatalf("unexpected events: %+v, next: %d", events, next)
+		}
+		for i, event := range events {
+			if exp := offsets[len(offsets)-n+i]; event.Offset != exp || event.Column != uint64(len(offsets)-n+i) {
+				t.Fatalf("unexpected event %d: %+v, expected offset %d", i, event, exp)
+			}
+		}
+	}
+	var n int
+	for _, offset := range offsets {
+		if offset >= l.base() {
+			n++
+		}
+	}
+	check(n)
+
+	// The offsets of the events are kept when the log is reopened.
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	} else if err := l.Open(); err != nil {
+		t.Fatal(err)
+	}
+	events := []ChangeEvent{{Type: ChangeEventSet, Index: "i", Field: "f", Row: 1, Column: uint64(len(offsets))}}
+	if err := l.append(events...); err != nil {
+		t.Fatal(err)
+	}
+	offsets = append(offsets, events[0].Offset)
+	check(n + 1)
+}
+
+// Ensure fragment mutations are logged in the order they are applied.
+func TestFragment_ChangeLog(t *testing.T) {
+	l := mustOpenChangeLog()
+	defer os.RemoveAll(l.Path)
+	defer l.Close()
+
+	f := mustOpenFragment("i", "f", viewStandard, 1, "")
+	f.changeLog = l
+	defer f.Clean(t)
+
+	col := uint64(ShardWidth + 5)
+	if _, err := f.setBit(1, col); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setBit(1, col); err != nil { // unchanged
+		t.Fatal(err)
+	} else if _, err := f.clearBit(1, col); err != nil {
+		t.Fatal(err)
+	} else if err := f.bulkImport([]uint64{2, 3}, []uint64{col, col + 1}, &ImportOptions{}); err != nil {
+		t.Fatal(err)
+	} else if err := f.bulkImport([]uint64{2, 4}, []uint64{col, col}, &ImportOptions{}); err != nil { // row 2 unchanged
+		t.Fatal(err)
+	}
+
+	// Only the bits which changed are logged for roaring imports.
+	var buf bytes.Buffer
+	if _, err := roaring.NewBitmap(3*ShardWidth+6, 5*ShardWidth+5).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	} else if err := f.importRoaring(context.Background(), buf.Bytes(), false); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.clearRow(2); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _, err := l.ReadEvents(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range events {
+		events[i].Offset = 0
+	}
+	exp := []ChangeEvent{
+		{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 1, Column: col},
+		{Type: ChangeEventClear, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 1, Column: col},
+		{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 2, Column: col},
+		{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 3, Column: col + 1},
+		{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 4, Column: col},
+		{Type: ChangeEventSet, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 5, Column: col},
+		{Type: ChangeEventClearRow, Index: "i", Field: "f", View: viewStandard, Shard: 1, Row: 2},
+	}
+	if !reflect.DeepEqual(events, exp) {
+		t.Fatalf("unexpected events:\n%+v\nexpected:\n%+v", events, exp)
+	}
+}
+
+// Ensure fragments log value events along with the values they apply.
+func TestFragment_ChangeLog_Values(t *testing.T) {
+	l := mustOpenChangeLog()
+	defer os.RemoveAll(l.Path)
+	defer l.Close()
+
+	f0 := mustOpenBSIFragment("i", "n", viewBSIGroupPrefix+"n", 0)
+	f0.changeLog = l
+	defer f0.Clean(t)
+	f1 := mustOpenBSIFragment("i", "n", viewBSIGroupPrefix+"n", 1)
+	f1.changeLog = l
+	defer f1.Clean(t)
+
+	event := func(col uint64, v int64) ChangeEvent {
+		return ChangeEvent{Type: ChangeEventSetValue, Index: "i", Field: "n", View: viewBSIGroupPrefix + "n", Shard: col / ShardWidth, Column: col, Value: v}
+	}
+	if _, err := f0.setValue(1, 8, 3, event(1, 3)); err != nil {
+		t.Fatal(err)
+	} else if _, err := f0.setValue(1, 8, 3, event(1, 3)); err != nil { // unchanged
+		t.Fatal(err)
+	} else if err := f1.importValue([]uint64{ShardWidth + 1}, []int64{100}, 8, false, event(ShardWidth+1, 100)); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _, err := l.ReadEvents(context.Background(), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range events {
+		events[i].Offset = 0
+	}
+	if exp := []ChangeEvent{event(1, 3), event(ShardWidth+1, 100)}; !reflect.DeepEqual(events, exp) {
+		t.Fatalf("unexpected events:\n%+v\nexpected:\n%+v", events, exp)
+	}
+}
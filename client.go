@@ -30,11 +30,13 @@ type Bit struct {
 }
 
 // FieldValue represents the value for a column within a
-// range-encoded field.
+// range-encoded field. FloatValue holds the unscaled value
+// of a column within a decimal field.
 type FieldValue struct {
-	ColumnID  uint64
-	ColumnKey string
-	Value     int64
+	ColumnID   uint64
+	ColumnKey  string
+	Value      int64
+	FloatValue float64
 }
 
 // InternalClient should be implemented by any struct that enables any transport between nodes
@@ -69,6 +71,10 @@ type InternalClient interface {
 	SendMessage(ctx context.Context, uri *URI, msg []byte) error
 	RetrieveShardFromURI(ctx context.Context, index, field, view string, shard uint64, uri URI) (io.ReadCloser, error)
 	ImportRoaring(ctx context.Context, uri *URI, index, field string, shard uint64, remote bool, req *ImportRoaringRequest) error
+	RestoreFragment(ctx context.Context, uri *URI, index, field, view string, shard uint64, rd io.Reader) error
+	RestoreBlock(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int, rd io.Reader) error
+	RestoreAttrs(ctx context.Context, uri *URI, index, field string, rd io.Reader) error
+	DeleteColumns(ctx context.Context, uri *URI, index string, columnKeys []string) (uint64, error)
 }
 
 //===============
@@ -172,3 +178,15 @@ func (n nopInternalClient) SendMessage(ctx context.Context, uri *URI, msg []byte
 func (n nopInternalClient) RetrieveShardFromURI(ctx context.Context, index, field, view string, shard uint64, uri URI) (io.ReadCloser, error) {
 	return nil, nil
 }
+func (n nopInternalClient) RestoreFragment(ctx context.Context, uri *URI, index, field, view string, shard uint64, rd io.Reader) error {
+	return nil
+}
+func (n nopInternalClient) RestoreBlock(ctx context.Context, uri *URI, index, field, view string, shard uint64, block int, rd io.Reader) error {
+	return nil
+}
+func (n nopInternalClient) RestoreAttrs(ctx context.Context, uri *URI, index, field string, rd io.Reader) error {
+	return nil
+}
+func (n nopInternalClient) DeleteColumns(ctx context.Context, uri *URI, index string, columnKeys []string) (uint64, error) {
+	return 0, nil
+}
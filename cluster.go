@@ -2168,3 +2168,12 @@ type FieldStatus struct {
 // RecalculateCaches is an internal message for recalculating all caches
 // within a holder.
 type RecalculateCaches struct{}
+
+// PauseWritesMessage is an internal message for pausing or resuming writes
+// to a holder while a backup is taken. A pause lasts for Timeout unless it
+// is renewed by a message with Renew set.
+type PauseWritesMessage struct {
+	Pause   bool
+	Timeout time.Duration
+	Renew   bool
+}
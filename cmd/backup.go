@@ -0,0 +1,57 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pilosa/pilosa/ctl"
+)
+
+var Backuper *ctl.BackupCommand
+
+func newBackupCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	Backuper = ctl.NewBackupCommand(stdin, stdout, stderr)
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up a whole pilosa cluster.",
+		Long: `
+Writes a point-in-time consistent backup of every index in the cluster to a
+tar archive. If the OUTFILE is not specified then the archive is written to
+STDOUT.
+
+The host must be the coordinator of the cluster. Writes are rejected on every
+node while the backup is taken. The archive can be loaded into an empty
+cluster using "pilosa restore".
+
+If --incremental is set to the archive of a previous backup, only the fragment
+blocks and key translations which changed since that backup are written.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Backuper.Run(context.Background())
+		},
+	}
+	flags := backupCmd.Flags()
+
+	flags.StringVarP(&Backuper.Host, "host", "", "localhost:10101", "host:port of the Pilosa coordinator.")
+	flags.StringVarP(&Backuper.Path, "output-file", "o", "", "File to write backup to - default stdout")
+	flags.StringVarP(&Backuper.Incremental, "incremental", "", "", "Previous backup file to write an incremental backup against")
+	ctl.SetTLSConfig(flags, &Backuper.TLS.CertificatePath, &Backuper.TLS.CertificateKeyPath, &Backuper.TLS.CACertPath, &Backuper.TLS.SkipVerify, &Backuper.TLS.EnableClientVerification)
+
+	return backupCmd
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pilosa/pilosa/ctl"
+)
+
+var Restorer *ctl.RestoreCommand
+
+func newRestoreCommand(stdin io.Reader, stdout, stderr io.Writer) *cobra.Command {
+	Restorer = ctl.NewRestoreCommand(stdin, stdout, stderr)
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a backup onto an empty pilosa cluster.",
+		Long: `
+Restores a backup written by "pilosa backup" onto an empty cluster. If the
+INFILE is not specified then the archive is read from STDIN.
+
+The host must be the coordinator of the cluster. The cluster may have a
+different number of nodes than the cluster the backup was taken from; data is
+sent to the nodes which own it in the new cluster.
+
+Incremental backups given with --incremental are restored in order after the
+INFILE. Each must be based on the backup restored before it. An incremental
+backup may also be given as the INFILE to restore it onto a cluster which
+already holds the backup it is based on.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Restorer.Run(context.Background())
+		},
+	}
+	flags := restoreCmd.Flags()
+
+	flags.StringVarP(&Restorer.Host, "host", "", "localhost:10101", "host:port of the Pilosa coordinator.")
+	flags.StringVarP(&Restorer.Path, "input-file", "i", "", "File to read backup from - default stdin")
+	flags.StringSliceVarP(&Restorer.Incremental, "incremental", "", nil, "Incremental backup files to restore in order after the input file")
+	ctl.SetTLSConfig(flags, &Restorer.TLS.CertificatePath, &Restorer.TLS.CertificateKeyPath, &Restorer.TLS.CACertPath, &Restorer.TLS.SkipVerify, &Restorer.TLS.EnableClientVerification)
+
+	return restoreCmd
+}
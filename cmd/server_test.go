@@ -84,6 +84,10 @@ func TestServerConfig(t *testing.T) {
 		{
 			args: []string{"server",
 				"--anti-entropy.interval", "9m0s",
+				"--durability.mode", "interval",
+				"--durability.interval", "2s",
+				"--cdc.enabled",
+				"--cdc.max-size", "4096",
 				"--profile.block-rate", "4832",
 				"--profile.mutex-fraction", "8290",
 			},
@@ -110,6 +114,10 @@ func TestServerConfig(t *testing.T) {
 				v := validator{}
 				v.Check(cmd.Server.Config.Cluster.Hosts, []string{"localhost:1110", "localhost:1111"})
 				v.Check(cmd.Server.Config.AntiEntropy.Interval, toml.Duration(time.Minute*9))
+				v.Check(cmd.Server.Config.Durability.Mode, "interval")
+				v.Check(cmd.Server.Config.Durability.Interval, toml.Duration(time.Second*2))
+				v.Check(cmd.Server.Config.CDC.Enabled, true)
+				v.Check(cmd.Server.Config.CDC.MaxSize, int64(4096))
 				v.Check(cmd.Server.Config.Translation.MapSize, 100000)
 				v.Check(cmd.Server.Config.Profile.BlockRate, 4832)
 				v.Check(cmd.Server.Config.Profile.MutexFraction, 8290)
@@ -129,6 +137,8 @@ func TestServerConfig(t *testing.T) {
 		]
 	[anti-entropy]
 		interval = "11m0s"
+	[durability]
+		mode = "always"
 	[metric]
 		service = "statsd"
 		host = "127.0.0.1:8125"
@@ -141,6 +151,7 @@ func TestServerConfig(t *testing.T) {
 				v := validator{}
 				v.Check(cmd.Server.Config.Cluster.Hosts, []string{"localhost:19444"})
 				v.Check(cmd.Server.Config.AntiEntropy.Interval, toml.Duration(time.Minute*11))
+				v.Check(cmd.Server.Config.Durability.Mode, "always")
 				v.Check(cmd.Server.Config.LogPath, logFile.Name())
 				v.Check(cmd.Server.Config.Metric.Service, "statsd")
 				v.Check(cmd.Server.Config.Metric.Host, "127.0.0.1:8125")
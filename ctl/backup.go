@@ -0,0 +1,107 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/server"
+	"github.com/pkg/errors"
+)
+
+// BackupCommand represents a command for backing up a whole cluster.
+type BackupCommand struct {
+	// Remote host and port of the coordinator.
+	Host string
+
+	// Filename to write the backup archive to.
+	Path string
+
+	// Filename of a previous backup archive. If set, only the data which
+	// changed since that backup is written.
+	Incremental string
+
+	// Standard input/output
+	*pilosa.CmdIO
+
+	TLS server.TLSConfig
+}
+
+// NewBackupCommand returns a new instance of BackupCommand.
+func NewBackupCommand(stdin io.Reader, stdout, stderr io.Writer) *BackupCommand {
+	return &BackupCommand{
+		CmdIO: pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// Run executes the backup.
+func (cmd *BackupCommand) Run(ctx context.Context) error {
+	// Read the manifest of the previous backup, if specified.
+	var prev *pilosa.BackupManifest
+	if cmd.Incremental != "" {
+		f, err := os.Open(cmd.Incremental)
+		if err != nil {
+			return errors.Wrap(err, "opening previous backup")
+		}
+		prev, err = pilosa.ReadBackupManifest(f)
+		f.Close()
+		if err != nil {
+			return errors.Wrap(err, "reading previous backup")
+		}
+	}
+
+	// Use output file, if specified.
+	// Otherwise use STDOUT.
+	var w io.Writer = cmd.Stdout
+	if cmd.Path != "" {
+		f, err := os.Create(cmd.Path)
+		if err != nil {
+			return errors.Wrap(err, "creating file")
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	// Create a client to the server.
+	client, err := commandClient(cmd)
+	if err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+
+	if err := client.Backup(ctx, w, prev); err != nil {
+		return errors.Wrap(err, "backing up")
+	}
+
+	// Close writer, if applicable.
+	if w, ok := w.(io.Closer); ok {
+		if err := w.Close(); err != nil {
+			return errors.Wrap(err, "closing")
+		}
+	}
+
+	return nil
+}
+
+func (cmd *BackupCommand) TLSHost() string {
+	return cmd.Host
+}
+
+func (cmd *BackupCommand) TLSConfiguration() server.TLSConfig {
+	return cmd.TLS
+}
@@ -0,0 +1,258 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/roaring"
+	"github.com/pilosa/pilosa/test"
+)
+
+func TestBackupCommand_Run(t *testing.T) {
+	src := test.MustRunCluster(t, 1)
+	defer src.Close()
+
+	// Keyed index with set, int and row attribute data.
+	src.CreateField(t, "i", pilosa.IndexOptions{Keys: true}, "f", pilosa.OptFieldKeys())
+	src.CreateField(t, "i", pilosa.IndexOptions{Keys: true}, "n", pilosa.OptFieldTypeInt(0, 100))
+	src.Query(t, "i", `
+		Set("c1", f="a") Set("c2", f="a") Set("c2", f="b")
+		Set("c1", n=10) Set("c2", n=20)
+		SetRowAttrs(f, "a", color="red")
+		SetColumnAttrs("c1", x=1, y=1.5)`)
+
+	// Unkeyed index with data in several shards.
+	src.CreateField(t, "j", pilosa.IndexOptions{}, "g")
+	src.Query(t, "j", `Set(1, g=1) Set(1048577, g=1) Set(2097154, g=1) Set(3145731, g=2)`)
+
+	path := TempFileName("backup-", ".tar")
+	defer os.Remove(path)
+
+	buf := bytes.Buffer{}
+	stdin, stdout, stderr := GetIO(buf)
+	backup := NewBackupCommand(stdin, stdout, stderr)
+	backup.Host = src[0].API.Node().URI.HostPort()
+	backup.Path = path
+	if err := backup.Run(context.Background()); err != nil {
+		t.Fatalf("Backup Run doesn't work: %s", err)
+	}
+
+	// Writes are allowed again once the backup is complete.
+	src.Query(t, "j", `Set(4, g=1)`)
+
+	// Restore onto a cluster with a different number of nodes.
+	dst := test.MustRunCluster(t, 3)
+	defer dst.Close()
+
+	restore := NewRestoreCommand(stdin, stdout, stderr)
+	restore.Host = dst[0].API.Node().URI.HostPort()
+	restore.Path = path
+	if err := restore.Run(context.Background()); err != nil {
+		t.Fatalf("Restore Run doesn't work: %s", err)
+	}
+
+	resp := dst[0].MustQuery(t, &pilosa.QueryRequest{Index: "i", Query: `Row(f="a")`, ColumnAttrs: true})
+	if keys := resp.Results[0].(*pilosa.Row).Keys; !reflect.DeepEqual(keys, []string{"c1", "c2"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	} else if attrs := resp.Results[0].(*pilosa.Row).Attrs; !reflect.DeepEqual(attrs, map[string]interface{}{"color": "red"}) {
+		t.Fatalf("unexpected row attrs: %v", attrs)
+	} else if sets := resp.ColumnAttrSets; len(sets) != 1 || !reflect.DeepEqual(sets[0].Attrs, map[string]interface{}{"x": int64(1), "y": 1.5}) {
+		t.Fatalf("unexpected column attrs: %v", sets)
+	}
+
+	if resp := dst.Query(t, "i", `Sum(field=n)`); resp.Results[0] != (pilosa.ValCount{Val: 30, Count: 2}) {
+		t.Fatalf("unexpected sum: %v", resp.Results[0])
+	}
+
+	if resp := dst.Query(t, "j", `Row(g=1)`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Columns(), []uint64{1, 1048577, 2097154}) {
+		t.Fatalf("unexpected columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+
+	// Restoring requires an empty cluster.
+	if err := restore.Run(context.Background()); err == nil || !strings.Contains(err.Error(), pilosa.ErrRestoreNotEmpty.Error()) {
+		t.Fatalf("expected not empty error, got: %v", err)
+	}
+}
+
+func TestBackupCommand_Incremental(t *testing.T) {
+	src := test.MustRunCluster(t, 1)
+	defer src.Close()
+
+	src.CreateField(t, "i", pilosa.IndexOptions{Keys: true}, "f", pilosa.OptFieldKeys())
+	src.CreateField(t, "j", pilosa.IndexOptions{}, "g")
+	src.Query(t, "i", `Set("c1", f="a") Set("c2", f="a") SetRowAttrs(f, "a", color="red", size=1)`)
+	src.Query(t, "j", `Set(1, g=1) Set(2, g=1) Set(1048577, g=1) Set(1, g=500)`)
+
+	buf := bytes.Buffer{}
+	stdin, stdout, stderr := GetIO(buf)
+	backup := func(path, prev string) {
+		t.Helper()
+		cmd := NewBackupCommand(stdin, stdout, stderr)
+		cmd.Host = src[0].API.Node().URI.HostPort()
+		cmd.Path = path
+		cmd.Incremental = prev
+		if err := cmd.Run(context.Background()); err != nil {
+			t.Fatalf("Backup Run doesn't work: %s", err)
+		}
+	}
+
+	full, inc1, inc2 := TempFileName("full-", ".tar"), TempFileName("inc1-", ".tar"), TempFileName("inc2-", ".tar")
+	defer os.Remove(full)
+	defer os.Remove(inc1)
+	defer os.Remove(inc2)
+
+	backup(full, "")
+	src.Query(t, "i", `Set("c3", f="b") Clear("c1", f="a") SetRowAttrs(f, "a", color=null)`)
+	src.Query(t, "j", `Set(2097154, g=2) Clear(1, g=500)`)
+	backup(inc1, full)
+	src.Query(t, "j", `ClearRow(g=1)`)
+	backup(inc2, inc1)
+
+	// Only changed blocks are written to incremental backups.
+	f, err := os.Open(inc2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var names []string
+	for tr := tar.NewReader(f); ; {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(hdr.Name, "/fragments/") {
+			names = append(names, hdr.Name)
+		}
+	}
+	sort.Strings(names)
+	if exp := []string{
+		"indexes/j/fields/g/views/standard/fragments/0/blocks/0",
+		"indexes/j/fields/g/views/standard/fragments/1/blocks/0",
+	}; !reflect.DeepEqual(names, exp) {
+		t.Fatalf("unexpected fragment files: %v", names)
+	}
+
+	dst := test.MustRunCluster(t, 3)
+	defer dst.Close()
+
+	restore := NewRestoreCommand(stdin, stdout, stderr)
+	restore.Host = dst[0].API.Node().URI.HostPort()
+	restore.Path = full
+
+	// Incremental backups must be restored in order.
+	restore.Incremental = []string{inc2, inc1}
+	if err := restore.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "not based on") {
+		t.Fatalf("expected chain error, got: %v", err)
+	}
+
+	restore.Incremental = []string{inc1, inc2}
+	if err := restore.Run(context.Background()); err != nil {
+		t.Fatalf("Restore Run doesn't work: %s", err)
+	}
+
+	if resp := dst.Query(t, "i", `Row(f="a")`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Keys, []string{"c2"}) {
+		t.Fatalf("unexpected keys: %v", resp.Results[0].(*pilosa.Row).Keys)
+	} else if attrs := resp.Results[0].(*pilosa.Row).Attrs; !reflect.DeepEqual(attrs, map[string]interface{}{"size": int64(1)}) {
+		t.Fatalf("unexpected row attrs: %v", attrs)
+	}
+	if resp := dst.Query(t, "i", `Row(f="b")`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Keys, []string{"c3"}) {
+		t.Fatalf("unexpected keys: %v", resp.Results[0].(*pilosa.Row).Keys)
+	}
+	if resp := dst.Query(t, "j", `Row(g=1)`); len(resp.Results[0].(*pilosa.Row).Columns()) != 0 {
+		t.Fatalf("unexpected columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+	if resp := dst.Query(t, "j", `Row(g=2)`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Columns(), []uint64{2097154}) {
+		t.Fatalf("unexpected columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+	if resp := dst.Query(t, "j", `Row(g=500)`); len(resp.Results[0].(*pilosa.Row).Columns()) != 0 {
+		t.Fatalf("unexpected columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+
+	// The cluster rejects an incremental backup which is not based on the
+	// last backup it restored.
+	restore.Path, restore.Incremental = inc1, nil
+	if err := restore.Run(context.Background()); err == nil || !strings.Contains(err.Error(), pilosa.ErrBackupParent.Error()) {
+		t.Fatalf("expected parent error, got: %v", err)
+	}
+}
+
+// Ensure incremental backups include blocks changed by mutations which
+// replace containers rather than setting bits one at a time.
+func TestBackupCommand_IncrementalBulk(t *testing.T) {
+	src := test.MustRunCluster(t, 1)
+	defer src.Close()
+
+	src.CreateField(t, "j", pilosa.IndexOptions{}, "g")
+	src.Query(t, "j", `Set(1, g=1) Set(2, g=1) Set(3, g=2)`)
+
+	buf := bytes.Buffer{}
+	stdin, stdout, stderr := GetIO(buf)
+	backup := func(path, prev string) {
+		t.Helper()
+		cmd := NewBackupCommand(stdin, stdout, stderr)
+		cmd.Host = src[0].API.Node().URI.HostPort()
+		cmd.Path = path
+		cmd.Incremental = prev
+		if err := cmd.Run(context.Background()); err != nil {
+			t.Fatalf("Backup Run doesn't work: %s", err)
+		}
+	}
+
+	full, inc := TempFileName("full-", ".tar"), TempFileName("inc-", ".tar")
+	defer os.Remove(full)
+	defer os.Remove(inc)
+
+	backup(full, "")
+	src.Query(t, "j", `Store(Row(g=1), g=3)`)
+	var data bytes.Buffer
+	if _, err := roaring.NewBitmap(4*pilosa.ShardWidth+5, 4*pilosa.ShardWidth+6).WriteTo(&data); err != nil {
+		t.Fatal(err)
+	}
+	req := &pilosa.ImportRoaringRequest{Views: map[string][]byte{"": data.Bytes()}}
+	if err := src[0].API.ImportRoaring(context.Background(), "j", "g", 0, false, req); err != nil {
+		t.Fatal(err)
+	}
+	backup(inc, full)
+
+	dst := test.MustRunCluster(t, 1)
+	defer dst.Close()
+
+	restore := NewRestoreCommand(stdin, stdout, stderr)
+	restore.Host = dst[0].API.Node().URI.HostPort()
+	restore.Path = full
+	restore.Incremental = []string{inc}
+	if err := restore.Run(context.Background()); err != nil {
+		t.Fatalf("Restore Run doesn't work: %s", err)
+	}
+
+	if resp := dst.Query(t, "j", `Row(g=3)`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Columns(), []uint64{1, 2}) {
+		t.Fatalf("unexpected stored columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+	if resp := dst.Query(t, "j", `Row(g=4)`); !reflect.DeepEqual(resp.Results[0].(*pilosa.Row).Columns(), []uint64{5, 6}) {
+		t.Fatalf("unexpected imported columns: %v", resp.Results[0].(*pilosa.Row).Columns())
+	}
+}
@@ -162,9 +162,9 @@ func (cmd *ImportCommand) ensureSchema(ctx context.Context) error {
 
 // importPath parses a path into bits and imports it to the server.
 func (cmd *ImportCommand) importPath(ctx context.Context, fieldType string, useColumnKeys, useRowKeys bool, path string) error {
-	// If fieldType is `int`, treat the import data as values to be range-encoded.
-	if fieldType == pilosa.FieldTypeInt {
-		return cmd.bufferValues(ctx, useColumnKeys, path)
+	// If fieldType is `int` or `decimal`, treat the import data as values to be range-encoded.
+	if fieldType == pilosa.FieldTypeInt || fieldType == pilosa.FieldTypeDecimal {
+		return cmd.bufferValues(ctx, fieldType == pilosa.FieldTypeDecimal, useColumnKeys, path)
 	}
 	return cmd.bufferBits(ctx, useColumnKeys, useRowKeys, path)
 }
@@ -286,7 +286,8 @@ func (cmd *ImportCommand) importBits(ctx context.Context, useColumnKeys, useRowK
 }
 
 // bufferValues buffers slices of FieldValues to be imported as a batch.
-func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool, path string) error {
+// If decimal is true then values are parsed as floats.
+func (cmd *ImportCommand) bufferValues(ctx context.Context, decimal, useColumnKeys bool, path string) error {
 	a := make([]pilosa.FieldValue, 0, cmd.BufferSize)
 
 	var r *csv.Reader
@@ -337,11 +338,13 @@ func (cmd *ImportCommand) bufferValues(ctx context.Context, useColumnKeys bool,
 		}
 
 		// Parse FieldValue.
-		value, err := strconv.ParseInt(record[1], 10, 64)
-		if err != nil {
+		if decimal {
+			if val.FloatValue, err = strconv.ParseFloat(record[1], 64); err != nil {
+				return fmt.Errorf("invalid value on row %d: %q", rnum, record[1])
+			}
+		} else if val.Value, err = strconv.ParseInt(record[1], 10, 64); err != nil {
 			return fmt.Errorf("invalid value on row %d: %q", rnum, record[1])
 		}
-		val.Value = value
 
 		a = append(a, val)
 
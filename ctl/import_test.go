@@ -199,6 +199,51 @@ func TestImportCommand_RunValue(t *testing.T) {
 			t.Fatalf("Import Run with values doesn't work: %s", err)
 		}
 	})
+
+	t.Run("decimal", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		stdin, stdout, stderr := GetIO(buf)
+		cm := NewImportCommand(stdin, stdout, stderr)
+		file, err := ioutil.TempFile("", "import-value.csv")
+		if err != nil {
+			t.Fatalf("creating tempfile: %v", err)
+		}
+		_, err = file.Write([]byte("1,2.25\n3,4.5\n5,6"))
+		if err != nil {
+			t.Fatalf("writing to tempfile: %v", err)
+		}
+		ctx := context.Background()
+
+		cluster := test.MustRunCluster(t, 1)
+		defer cluster.Close()
+		cmd := cluster[0]
+		cm.Host = cmd.API.Node().URI.HostPort()
+
+		resp, err := http.DefaultClient.Do(MustNewHTTPRequest("POST", "http://"+cm.Host+"/index/i", strings.NewReader("")))
+		if err != nil {
+			t.Fatalf("http request: %v", err)
+		}
+		resp.Body.Close()
+		resp, err = http.DefaultClient.Do(MustNewHTTPRequest("POST", "http://"+cm.Host+"/index/i/field/f", strings.NewReader(`{"options":{"type": "decimal", "scale": 2, "min": 0, "max": 100}}`)))
+		if err != nil {
+			t.Fatalf("http request: %v", err)
+		}
+		resp.Body.Close()
+
+		cm.Index = "i"
+		cm.Field = "f"
+		cm.Paths = []string{file.Name()}
+		if err := cm.Run(ctx); err != nil {
+			t.Fatalf("Import Run with decimal values doesn't work: %s", err)
+		}
+
+		res, err := cmd.API.Query(ctx, &pilosa.QueryRequest{Index: "i", Query: "Sum(field=f)"})
+		if err != nil {
+			t.Fatalf("querying: %v", err)
+		} else if vc := res.Results[0].(pilosa.ValCount); vc.Val != 1275 || vc.Count != 3 {
+			t.Fatalf("unexpected sum: %+v", vc)
+		}
+	})
 }
 
 // Ensure that import with keys runs.
@@ -0,0 +1,149 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ctl
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pilosa/pilosa"
+	"github.com/pilosa/pilosa/http"
+	"github.com/pilosa/pilosa/server"
+	"github.com/pkg/errors"
+)
+
+// RestoreCommand represents a command for restoring a backup onto an empty
+// cluster, optionally followed by a chain of incremental backups.
+type RestoreCommand struct {
+	// Remote host and port of the coordinator.
+	Host string
+
+	// Filename to read the backup archive from.
+	Path string
+
+	// Filenames of incremental backup archives which are restored in order
+	// after the archive at Path.
+	Incremental []string
+
+	// Standard input/output
+	*pilosa.CmdIO
+
+	TLS server.TLSConfig
+}
+
+// NewRestoreCommand returns a new instance of RestoreCommand.
+func NewRestoreCommand(stdin io.Reader, stdout, stderr io.Writer) *RestoreCommand {
+	return &RestoreCommand{
+		CmdIO: pilosa.NewCmdIO(stdin, stdout, stderr),
+	}
+}
+
+// Run executes the restore.
+func (cmd *RestoreCommand) Run(ctx context.Context) error {
+	// Ensure each incremental backup is based on the one before it.
+	if err := cmd.validateChain(); err != nil {
+		return err
+	}
+
+	// Use input file, if specified.
+	// Otherwise use STDIN.
+	r := cmd.Stdin
+	if cmd.Path != "" {
+		f, err := os.Open(cmd.Path)
+		if err != nil {
+			return errors.Wrap(err, "opening file")
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	// Create a client to the server.
+	client, err := commandClient(cmd)
+	if err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+
+	if err := client.Restore(ctx, r); err != nil {
+		return errors.Wrap(err, "restoring")
+	}
+
+	for _, path := range cmd.Incremental {
+		if err := restoreFile(ctx, client, path); err != nil {
+			return errors.Wrapf(err, "restoring %s", path)
+		}
+	}
+	return nil
+}
+
+// validateChain returns an error if an incremental backup is not based on
+// the backup restored before it.
+func (cmd *RestoreCommand) validateChain() error {
+	if len(cmd.Incremental) == 0 {
+		return nil
+	}
+
+	// The backup read from STDIN can't be checked without consuming it.
+	var prev *pilosa.BackupManifest
+	if cmd.Path != "" {
+		m, err := readManifestFile(cmd.Path)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", cmd.Path)
+		}
+		prev = m
+	}
+
+	for _, path := range cmd.Incremental {
+		m, err := readManifestFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", path)
+		} else if m.Parent == "" {
+			return errors.Errorf("%s is not an incremental backup", path)
+		} else if prev != nil && m.Parent != prev.ID {
+			return errors.Errorf("%s is not based on the backup restored before it", path)
+		}
+		prev = m
+	}
+	return nil
+}
+
+// readManifestFile returns the manifest of the backup archive at path.
+func readManifestFile(path string) (*pilosa.BackupManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening file")
+	}
+	defer f.Close()
+	return pilosa.ReadBackupManifest(f)
+}
+
+// restoreFile restores the backup archive at path.
+func restoreFile(ctx context.Context, client *http.InternalClient, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "opening file")
+	}
+	defer f.Close()
+	return client.Restore(ctx, f)
+}
+
+func (cmd *RestoreCommand) TLSHost() string {
+	return cmd.Host
+}
+
+func (cmd *RestoreCommand) TLSConfiguration() server.TLSConfig {
+	return cmd.TLS
+}
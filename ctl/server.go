@@ -69,6 +69,14 @@ func BuildServerFlags(cmd *cobra.Command, srv *server.Command) {
 	// AntiEntropy
 	flags.DurationVarP((*time.Duration)(&srv.Config.AntiEntropy.Interval), "anti-entropy.interval", "", (time.Duration)(srv.Config.AntiEntropy.Interval), "Interval at which to run anti-entropy routine.")
 
+	// Durability
+	flags.StringVarP(&srv.Config.Durability.Mode, "durability.mode", "", srv.Config.Durability.Mode, "When mutations are synced to disk: none, interval or always.")
+	flags.DurationVarP((*time.Duration)(&srv.Config.Durability.Interval), "durability.interval", "", (time.Duration)(srv.Config.Durability.Interval), "Interval at which mutations are synced to disk when durability.mode is interval.")
+
+	// CDC
+	flags.BoolVarP(&srv.Config.CDC.Enabled, "cdc.enabled", "", srv.Config.CDC.Enabled, "Log every mutation so it can be read from the /cdc endpoint.")
+	flags.Int64VarP(&srv.Config.CDC.MaxSize, "cdc.max-size", "", srv.Config.CDC.MaxSize, "Number of bytes of change events retained before the oldest are truncated.")
+
 	// Metric
 	flags.StringVarP(&srv.Config.Metric.Service, "metric.service", "", srv.Config.Metric.Service, "Where to send stats: can be expvar (in-memory served at /debug/vars), statsd or none.")
 	flags.StringVarP(&srv.Config.Metric.Host, "metric.host", "", srv.Config.Metric.Host, "URI to send metrics when metric.service is statsd.")
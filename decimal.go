@@ -0,0 +1,199 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pilosa/pilosa/pql"
+	"github.com/pkg/errors"
+)
+
+// maxDecimalScale is the largest scale supported by decimal fields. It is
+// the largest power of ten which can be represented by an int64.
+const maxDecimalScale = 18
+
+// ErrInvalidDecimalScale is returned when a decimal field scale is out of range.
+var ErrInvalidDecimalScale = errors.New("invalid decimal scale")
+
+// decimalRounding determines how digits beyond a decimal field's scale are
+// handled when converting a value to its scaled integer representation.
+type decimalRounding int
+
+const (
+	decimalRoundNearest decimalRounding = iota
+	decimalRoundFloor
+	decimalRoundCeil
+)
+
+// scaleDecimal converts v to an integer equal to v * 10^scale. The conversion
+// is done on the shortest decimal representation of v so that values such as
+// 0.29 are not subject to binary floating point error.
+func scaleDecimal(v float64, scale int64, rounding decimalRounding) (int64, error) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.Errorf("invalid decimal value: %v", v)
+	}
+	return parseDecimal(strconv.FormatFloat(v, 'f', -1, 64), scale, rounding)
+}
+
+// parseDecimal converts the decimal string s to an integer equal to
+// s * 10^scale.
+func parseDecimal(s string, scale int64, rounding decimalRounding) (int64, error) {
+	orig := s
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	// Split into integer and fractional digits, then move the decimal point.
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	for int64(len(frac)) < scale {
+		frac += "0"
+	}
+	digits, rest := whole+frac[:scale], frac[scale:]
+	if strings.Trim(rest, "0123456789") != "" {
+		return 0, errors.Errorf("invalid decimal value: %v", orig)
+	}
+
+	mag, err := strconv.ParseUint(digits, 10, 63)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, errors.Errorf("decimal value out of range: %v", orig)
+		}
+		return 0, errors.Errorf("invalid decimal value: %v", orig)
+	}
+
+	// Adjust the magnitude for any remaining digits.
+	if strings.Trim(rest, "0") != "" {
+		switch rounding {
+		case decimalRoundNearest:
+			if rest[0] >= '5' {
+				mag++
+			}
+		case decimalRoundFloor:
+			if neg {
+				mag++
+			}
+		case decimalRoundCeil:
+			if !neg {
+				mag++
+			}
+		}
+	}
+	if mag > math.MaxInt64 {
+		return 0, errors.Errorf("decimal value out of range: %v", orig)
+	}
+
+	if neg {
+		return -int64(mag), nil
+	}
+	return int64(mag), nil
+}
+
+// unscaleDecimal converts a scaled integer back to its fractional value.
+func unscaleDecimal(v int64, scale int64) float64 {
+	return float64(v) / math.Pow10(int(scale))
+}
+
+// formatDecimal returns the exact decimal representation of v / 10^scale.
+func formatDecimal(v int64, scale int64) string {
+	if scale == 0 {
+		return strconv.FormatInt(v, 10)
+	}
+
+	mag := uint64(v)
+	if v < 0 {
+		mag = uint64(-v)
+	}
+	s := strconv.FormatUint(mag, 10)
+	for int64(len(s)) <= scale {
+		s = "0" + s
+	}
+	s = s[:int64(len(s))-scale] + "." + s[int64(len(s))-scale:]
+
+	if v < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// scaleInt converts the whole number v to an integer equal to v * 10^scale.
+func scaleInt(v int64, scale int64) (int64, error) {
+	p := int64(math.Pow10(int(scale)))
+	if v > math.MaxInt64/p || v < math.MinInt64/p {
+		return 0, errors.Errorf("decimal value out of range: %d", v)
+	}
+	return v * p, nil
+}
+
+// decimalValue converts a PQL argument value to a scaled integer.
+func decimalValue(v interface{}, scale int64, rounding decimalRounding) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return scaleInt(v, scale)
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, errors.Errorf("decimal value out of range: %d", v)
+		}
+		return scaleInt(int64(v), scale)
+	case float64:
+		return scaleDecimal(v, scale, rounding)
+	default:
+		return 0, errors.Errorf("invalid decimal value type: %T", v)
+	}
+}
+
+// decimalCondition returns a copy of cond with its values converted to the
+// scaled integer representation used to store decimal values. Digits beyond
+// the scale are rounded in the direction which preserves the meaning of the
+// comparison; equality is rounded to nearest, as it is when values are set.
+func decimalCondition(cond *pql.Condition, scale int64) (*pql.Condition, error) {
+	if cond.Value == nil {
+		return cond, nil
+	}
+
+	switch cond.Op {
+	case pql.BETWEEN:
+		values, ok := cond.Value.([]interface{})
+		if !ok || len(values) != 2 {
+			return nil, errors.New("Row(): BETWEEN condition requires exactly two values")
+		}
+		lo, err := decimalValue(values[0], scale, decimalRoundCeil)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := decimalValue(values[1], scale, decimalRoundFloor)
+		if err != nil {
+			return nil, err
+		}
+		return &pql.Condition{Op: cond.Op, Value: []interface{}{lo, hi}}, nil
+	}
+
+	rounding := decimalRoundNearest
+	switch cond.Op {
+	case pql.GT, pql.LTE:
+		rounding = decimalRoundFloor
+	case pql.LT, pql.GTE:
+		rounding = decimalRoundCeil
+	}
+	value, err := decimalValue(cond.Value, scale, rounding)
+	if err != nil {
+		return nil, err
+	}
+	return &pql.Condition{Op: cond.Op, Value: value}, nil
+}
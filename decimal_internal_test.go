@@ -0,0 +1,109 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/pilosa/pilosa/pql"
+)
+
+func TestScaleDecimal(t *testing.T) {
+	tests := []struct {
+		v        float64
+		scale    int64
+		rounding decimalRounding
+		exp      int64
+		err      bool
+	}{
+		{v: 12.34, scale: 2, exp: 1234},
+		{v: 0.29, scale: 2, exp: 29},
+		{v: -0.29, scale: 2, exp: -29},
+		{v: 12, scale: 2, exp: 1200},
+		{v: 1.5, scale: 0, exp: 2},
+		{v: 1.234, scale: 2, exp: 123},
+		{v: 1.235, scale: 2, exp: 124},
+		{v: -1.235, scale: 2, exp: -124},
+		{v: 1.231, scale: 2, rounding: decimalRoundCeil, exp: 124},
+		{v: -1.231, scale: 2, rounding: decimalRoundCeil, exp: -123},
+		{v: 1.239, scale: 2, rounding: decimalRoundFloor, exp: 123},
+		{v: -1.231, scale: 2, rounding: decimalRoundFloor, exp: -124},
+		{v: 1e18, scale: 2, err: true},
+		{v: math.NaN(), scale: 2, err: true},
+	}
+	for i, test := range tests {
+		v, err := scaleDecimal(test.v, test.scale, test.rounding)
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if v != test.exp {
+			t.Errorf("test %d: expected %d, got %d", i, test.exp, v)
+		}
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		v     int64
+		scale int64
+		exp   string
+	}{
+		{v: 1234, scale: 2, exp: "12.34"},
+		{v: -1234, scale: 2, exp: "-12.34"},
+		{v: 5, scale: 2, exp: "0.05"},
+		{v: -5, scale: 2, exp: "-0.05"},
+		{v: 0, scale: 2, exp: "0.00"},
+		{v: 42, scale: 0, exp: "42"},
+		{v: math.MinInt64, scale: 2, exp: "-92233720368547758.08"},
+	}
+	for i, test := range tests {
+		if s := formatDecimal(test.v, test.scale); s != test.exp {
+			t.Errorf("test %d: expected %s, got %s", i, test.exp, s)
+		}
+	}
+}
+
+func TestDecimalCondition(t *testing.T) {
+	tests := []struct {
+		cond *pql.Condition
+		exp  *pql.Condition
+	}{
+		{cond: &pql.Condition{Op: pql.EQ, Value: 1.234}, exp: &pql.Condition{Op: pql.EQ, Value: int64(123)}},
+		{cond: &pql.Condition{Op: pql.GT, Value: 1.239}, exp: &pql.Condition{Op: pql.GT, Value: int64(123)}},
+		{cond: &pql.Condition{Op: pql.GTE, Value: 1.231}, exp: &pql.Condition{Op: pql.GTE, Value: int64(124)}},
+		{cond: &pql.Condition{Op: pql.LT, Value: 1.231}, exp: &pql.Condition{Op: pql.LT, Value: int64(124)}},
+		{cond: &pql.Condition{Op: pql.LTE, Value: 1.239}, exp: &pql.Condition{Op: pql.LTE, Value: int64(123)}},
+		{cond: &pql.Condition{Op: pql.LT, Value: int64(3)}, exp: &pql.Condition{Op: pql.LT, Value: int64(300)}},
+		{cond: &pql.Condition{Op: pql.NEQ, Value: nil}, exp: &pql.Condition{Op: pql.NEQ, Value: nil}},
+		{
+			cond: &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{1.231, int64(2)}},
+			exp:  &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{int64(124), int64(200)}},
+		},
+	}
+	for i, test := range tests {
+		cond, err := decimalCondition(test.cond, 2)
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if !reflect.DeepEqual(cond, test.exp) {
+			t.Errorf("test %d: expected %v, got %v", i, test.exp, cond)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package proto
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa"
@@ -161,6 +162,14 @@ func (Serializer) Unmarshal(buf []byte, m pilosa.Message) error {
 		}
 		decodeRecalculateCaches(msg, mt)
 		return nil
+	case *pilosa.PauseWritesMessage:
+		msg := &internal.PauseWritesMessage{}
+		err := proto.Unmarshal(buf, msg)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling PauseWritesMessage")
+		}
+		decodePauseWritesMessage(msg, mt)
+		return nil
 	case *pilosa.NodeEvent:
 		msg := &internal.NodeEventMessage{}
 		err := proto.Unmarshal(buf, msg)
@@ -302,6 +311,8 @@ func encodeToProto(m pilosa.Message) proto.Message {
 		return encodeNodeStateMessage(mt)
 	case *pilosa.RecalculateCaches:
 		return encodeRecalculateCaches(mt)
+	case *pilosa.PauseWritesMessage:
+		return encodePauseWritesMessage(mt)
 	case *pilosa.NodeEvent:
 		return encodeNodeEventMessage(mt)
 	case *pilosa.NodeStatus:
@@ -369,12 +380,13 @@ func encodeImportRequest(m *pilosa.ImportRequest) *internal.ImportRequest {
 
 func encodeImportValueRequest(m *pilosa.ImportValueRequest) *internal.ImportValueRequest {
 	return &internal.ImportValueRequest{
-		Index:      m.Index,
-		Field:      m.Field,
-		Shard:      m.Shard,
-		ColumnIDs:  m.ColumnIDs,
-		ColumnKeys: m.ColumnKeys,
-		Values:     m.Values,
+		Index:       m.Index,
+		Field:       m.Field,
+		Shard:       m.Shard,
+		ColumnIDs:   m.ColumnIDs,
+		ColumnKeys:  m.ColumnKeys,
+		Values:      m.Values,
+		FloatValues: m.FloatValues,
 	}
 }
 
@@ -442,6 +454,39 @@ func encodeQueryResponse(m *pilosa.QueryResponse) *internal.QueryResponse {
 		case pilosa.Pair:
 			pb.Results[i].Type = queryResultTypePair
 			pb.Results[i].Pairs = []*internal.Pair{encodePair(result)}
+		case pilosa.DistinctValues:
+			pb.Results[i].Type = queryResultTypeDistinctValues
+			pb.Results[i].DistinctValues = result
+		case pilosa.DistinctKeys:
+			pb.Results[i].Type = queryResultTypeDistinctKeys
+			pb.Results[i].DistinctKeys = result
+		case []pilosa.ColumnValue:
+			pb.Results[i].Type = queryResultTypeColumnValues
+			pb.Results[i].ColumnValues = encodeColumnValues(result)
+		case pilosa.CountSeries:
+			pb.Results[i].Type = queryResultTypeCountSeries
+			pb.Results[i].SeriesCounts = encodeCountSeries(result)
+		case pilosa.FunnelCounts:
+			pb.Results[i].Type = queryResultTypeFunnelCounts
+			pb.Results[i].RowIDs = result
+		case pilosa.RetentionMatrix:
+			pb.Results[i].Type = queryResultTypeRetentionMatrix
+			pb.Results[i].RetentionCohorts = encodeRetentionMatrix(result)
+		case pilosa.TopNSketch:
+			pb.Results[i].Type = queryResultTypeTopNSketch
+			pb.Results[i].Pairs = encodePairs(result.Pairs)
+			pb.Results[i].RowIDs = result.Sketch
+		case pilosa.PercentileCounts:
+			pb.Results[i].Type = queryResultTypePercentileCounts
+			pb.Results[i].RowIDs = result
+		case pilosa.SimilarRows:
+			pb.Results[i].Type = queryResultTypeSimilarRows
+			pb.Results[i].N = result.Count
+			pb.Results[i].RowSimilarities = encodeRowSimilarities(result.Rows)
+		case pilosa.Overlap:
+			pb.Results[i].Type = queryResultTypeOverlap
+			pb.Results[i].RowIDs = []uint64{result.Counts[0], result.Counts[1], result.Intersection, result.Union}
+			pb.Results[i].Similarity = result.Similarity
 		case nil:
 			pb.Results[i].Type = queryResultTypeNil
 		default:
@@ -532,15 +577,20 @@ func encodeFieldOptions(o *pilosa.FieldOptions) *internal.FieldOptions {
 		return nil
 	}
 	return &internal.FieldOptions{
-		Type:        o.Type,
-		CacheType:   o.CacheType,
-		CacheSize:   o.CacheSize,
-		Min:         o.Min,
-		Max:         o.Max,
-		Base:        o.Base,
-		BitDepth:    uint64(o.BitDepth),
-		TimeQuantum: string(o.TimeQuantum),
-		Keys:        o.Keys,
+		Type:         o.Type,
+		CacheType:    o.CacheType,
+		CacheSize:    o.CacheSize,
+		Min:          o.Min,
+		Max:          o.Max,
+		Base:         o.Base,
+		BitDepth:     uint64(o.BitDepth),
+		TimeQuantum:  string(o.TimeQuantum),
+		Keys:         o.Keys,
+		Scale:        o.Scale,
+		TimeUnit:     o.TimeUnit,
+		ForeignIndex: o.ForeignIndex,
+		TTL:          int64(o.TTL),
+		Rollup:       string(o.Rollup),
 	}
 }
 
@@ -722,6 +772,14 @@ func encodeRecalculateCaches(*pilosa.RecalculateCaches) *internal.RecalculateCac
 	return &internal.RecalculateCaches{}
 }
 
+func encodePauseWritesMessage(m *pilosa.PauseWritesMessage) *internal.PauseWritesMessage {
+	return &internal.PauseWritesMessage{
+		Pause:   m.Pause,
+		Timeout: int64(m.Timeout),
+		Renew:   m.Renew,
+	}
+}
+
 func encodeTranslateKeysResponse(response *pilosa.TranslateKeysResponse) *internal.TranslateKeysResponse {
 	return &internal.TranslateKeysResponse{
 		IDs: response.IDs,
@@ -811,6 +869,11 @@ func decodeFieldOptions(options *internal.FieldOptions, m *pilosa.FieldOptions)
 	m.BitDepth = uint(options.BitDepth)
 	m.TimeQuantum = pilosa.TimeQuantum(options.TimeQuantum)
 	m.Keys = options.Keys
+	m.Scale = options.Scale
+	m.TimeUnit = options.TimeUnit
+	m.ForeignIndex = options.ForeignIndex
+	m.TTL = time.Duration(options.TTL)
+	m.Rollup = pilosa.RollupPolicy(options.Rollup)
 }
 
 func decodeNodes(a []*internal.Node, m []*pilosa.Node) {
@@ -956,6 +1019,12 @@ func decodeFieldStatus(pb *internal.FieldStatus, m *pilosa.FieldStatus) {
 
 func decodeRecalculateCaches(pb *internal.RecalculateCaches, m *pilosa.RecalculateCaches) {}
 
+func decodePauseWritesMessage(pb *internal.PauseWritesMessage, m *pilosa.PauseWritesMessage) {
+	m.Pause = pb.Pause
+	m.Timeout = time.Duration(pb.Timeout)
+	m.Renew = pb.Renew
+}
+
 func decodeQueryRequest(pb *internal.QueryRequest, m *pilosa.QueryRequest) {
 	m.Query = pb.Query
 	m.Shards = pb.Shards
@@ -983,6 +1052,7 @@ func decodeImportValueRequest(pb *internal.ImportValueRequest, m *pilosa.ImportV
 	m.ColumnIDs = pb.ColumnIDs
 	m.ColumnKeys = pb.ColumnKeys
 	m.Values = pb.Values
+	m.FloatValues = pb.FloatValues
 }
 
 func decodeImportRoaringRequest(pb *internal.ImportRoaringRequest, m *pilosa.ImportRoaringRequest) {
@@ -1064,6 +1134,16 @@ const (
 	queryResultTypeGroupCounts
 	queryResultTypeRowIdentifiers
 	queryResultTypePair
+	queryResultTypeDistinctValues
+	queryResultTypeDistinctKeys
+	queryResultTypeColumnValues
+	queryResultTypeCountSeries
+	queryResultTypeRetentionMatrix
+	queryResultTypeFunnelCounts
+	queryResultTypeTopNSketch
+	queryResultTypeSimilarRows
+	queryResultTypeOverlap
+	queryResultTypePercentileCounts
 )
 
 func decodeQueryResult(pb *internal.QueryResult) interface{} {
@@ -1088,6 +1168,26 @@ func decodeQueryResult(pb *internal.QueryResult) interface{} {
 		return decodeGroupCounts(pb.GroupCounts)
 	case queryResultTypePair:
 		return decodePair(pb.Pairs[0])
+	case queryResultTypeDistinctValues:
+		return pilosa.DistinctValues(pb.DistinctValues)
+	case queryResultTypeDistinctKeys:
+		return pilosa.DistinctKeys(pb.DistinctKeys)
+	case queryResultTypeColumnValues:
+		return decodeColumnValues(pb.ColumnValues)
+	case queryResultTypeCountSeries:
+		return decodeCountSeries(pb.SeriesCounts)
+	case queryResultTypeFunnelCounts:
+		return pilosa.FunnelCounts(pb.RowIDs)
+	case queryResultTypeRetentionMatrix:
+		return decodeRetentionMatrix(pb.RetentionCohorts)
+	case queryResultTypeTopNSketch:
+		return pilosa.TopNSketch{Pairs: decodePairs(pb.Pairs), Sketch: pilosa.CountMinSketch(pb.RowIDs)}
+	case queryResultTypePercentileCounts:
+		return pilosa.PercentileCounts(pb.RowIDs)
+	case queryResultTypeSimilarRows:
+		return pilosa.SimilarRows{Count: pb.N, Rows: decodeRowSimilarities(pb.RowSimilarities)}
+	case queryResultTypeOverlap:
+		return decodeOverlap(pb.RowIDs, pb.Similarity)
 	}
 	panic(fmt.Sprintf("unknown type: %d", pb.Type))
 }
@@ -1104,9 +1204,74 @@ func decodeRow(pr *internal.Row) *pilosa.Row {
 	for _, v := range pr.Columns {
 		r.SetBit(v)
 	}
+	if pr.Next != nil {
+		r.Next = &pilosa.RowCursor{Column: pr.Next.Column, Key: pr.Next.Key}
+	}
 	return r
 }
 
+func decodeColumnValues(a []*internal.ColumnValue) []pilosa.ColumnValue {
+	other := make([]pilosa.ColumnValue, len(a))
+	for i := range a {
+		other[i] = pilosa.ColumnValue{
+			ID:    a[i].ID,
+			Key:   a[i].Key,
+			Value: a[i].Value,
+		}
+	}
+	return other
+}
+
+func decodeCountSeries(a []*internal.SeriesCount) pilosa.CountSeries {
+	other := make(pilosa.CountSeries, len(a))
+	for i := range a {
+		other[i] = pilosa.SeriesCount{
+			Time:  time.Unix(0, a[i].Timestamp).UTC(),
+			Count: a[i].Count,
+		}
+	}
+	return other
+}
+
+func decodeRetentionMatrix(a []*internal.RetentionCohort) pilosa.RetentionMatrix {
+	other := make(pilosa.RetentionMatrix, len(a))
+	for i := range a {
+		counts := a[i].Counts
+		if counts == nil {
+			counts = []uint64{}
+		}
+		other[i] = pilosa.RetentionCohort{
+			Time:   time.Unix(0, a[i].Timestamp).UTC(),
+			Size:   a[i].CohortSize,
+			Counts: counts,
+		}
+	}
+	return other
+}
+
+func decodeRowSimilarities(a []*internal.RowSimilarity) []pilosa.RowSimilarity {
+	other := make([]pilosa.RowSimilarity, len(a))
+	for i := range a {
+		other[i] = pilosa.RowSimilarity{
+			ID:           a[i].ID,
+			Key:          a[i].Key,
+			Count:        a[i].Count,
+			Intersection: a[i].Intersection,
+			Score:        a[i].Score,
+		}
+	}
+	return other
+}
+
+func decodeOverlap(a []uint64, similarity float64) pilosa.Overlap {
+	other := pilosa.Overlap{Similarity: similarity}
+	if len(a) == 4 {
+		other.Counts = [2]uint64{a[0], a[1]}
+		other.Intersection, other.Union = a[2], a[3]
+	}
+	return other
+}
+
 func decodeAttrs(pb []*internal.Attr) map[string]interface{} {
 	m := make(map[string]interface{}, len(pb))
 	for i := range pb {
@@ -1151,11 +1316,20 @@ func decodeGroupCounts(a []*internal.GroupCount) []pilosa.GroupCount {
 		other[i] = pilosa.GroupCount{
 			Group: decodeFieldRows(a[i].Group),
 			Count: a[i].Count,
+			Sum:   decodeGroupSum(a[i]),
 		}
 	}
 	return other
 }
 
+func decodeGroupSum(a *internal.GroupCount) *int64 {
+	if !a.HasSum {
+		return nil
+	}
+	sum := a.Sum
+	return &sum
+}
+
 func decodeFieldRows(a []*internal.FieldRow) []pilosa.FieldRow {
 	other := make([]pilosa.FieldRow, len(a))
 	for i := range a {
@@ -1188,8 +1362,10 @@ func decodePair(pb *internal.Pair) pilosa.Pair {
 
 func decodeValCount(pb *internal.ValCount) pilosa.ValCount {
 	return pilosa.ValCount{
-		Val:   pb.Val,
-		Count: pb.Count,
+		Val:      pb.Val,
+		Count:    pb.Count,
+		Scale:    pb.Scale,
+		TimeUnit: pb.TimeUnit,
 	}
 }
 
@@ -1214,11 +1390,64 @@ func encodeRow(r *pilosa.Row) *internal.Row {
 		return nil
 	}
 
-	return &internal.Row{
+	pb := &internal.Row{
 		Columns: r.Columns(),
 		Keys:    r.Keys,
 		Attrs:   encodeAttrs(r.Attrs),
 	}
+	if r.Next != nil {
+		pb.Next = &internal.RowCursor{Column: r.Next.Column, Key: r.Next.Key}
+	}
+	return pb
+}
+
+func encodeColumnValues(a []pilosa.ColumnValue) []*internal.ColumnValue {
+	other := make([]*internal.ColumnValue, len(a))
+	for i := range a {
+		other[i] = &internal.ColumnValue{
+			ID:    a[i].ID,
+			Key:   a[i].Key,
+			Value: a[i].Value,
+		}
+	}
+	return other
+}
+
+func encodeCountSeries(a pilosa.CountSeries) []*internal.SeriesCount {
+	other := make([]*internal.SeriesCount, len(a))
+	for i := range a {
+		other[i] = &internal.SeriesCount{
+			Timestamp: a[i].Time.UnixNano(),
+			Count:     a[i].Count,
+		}
+	}
+	return other
+}
+
+func encodeRetentionMatrix(a pilosa.RetentionMatrix) []*internal.RetentionCohort {
+	other := make([]*internal.RetentionCohort, len(a))
+	for i := range a {
+		other[i] = &internal.RetentionCohort{
+			Timestamp:  a[i].Time.UnixNano(),
+			CohortSize: a[i].Size,
+			Counts:     a[i].Counts,
+		}
+	}
+	return other
+}
+
+func encodeRowSimilarities(a []pilosa.RowSimilarity) []*internal.RowSimilarity {
+	other := make([]*internal.RowSimilarity, len(a))
+	for i := range a {
+		other[i] = &internal.RowSimilarity{
+			ID:           a[i].ID,
+			Key:          a[i].Key,
+			Count:        a[i].Count,
+			Intersection: a[i].Intersection,
+			Score:        a[i].Score,
+		}
+	}
+	return other
 }
 
 func encodeRowIdentifiers(r pilosa.RowIdentifiers) *internal.RowIdentifiers {
@@ -1232,9 +1461,15 @@ func encodeRowIdentifiers(r pilosa.RowIdentifiers) *internal.RowIdentifiers {
 func encodeGroupCounts(counts []pilosa.GroupCount) []*internal.GroupCount {
 	result := make([]*internal.GroupCount, len(counts))
 	for i := range counts {
+		var sum int64
+		if counts[i].Sum != nil {
+			sum = *counts[i].Sum
+		}
 		result[i] = &internal.GroupCount{
-			Group: encodeFieldRows(counts[i].Group),
-			Count: counts[i].Count,
+			Group:  encodeFieldRows(counts[i].Group),
+			Count:  counts[i].Count,
+			Sum:    sum,
+			HasSum: counts[i].Sum != nil,
 		}
 	}
 	return result
@@ -1277,8 +1512,10 @@ func encodePair(p pilosa.Pair) *internal.Pair {
 
 func encodeValCount(vc pilosa.ValCount) *internal.ValCount {
 	return &internal.ValCount{
-		Val:   vc.Val,
-		Count: vc.Count,
+		Val:      vc.Val,
+		Count:    vc.Count,
+		Scale:    vc.Scale,
+		TimeUnit: vc.TimeUnit,
 	}
 }
 
@@ -15,14 +15,21 @@
 package pilosa
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pilosa/pilosa/pql"
+	"github.com/pilosa/pilosa/roaring"
 	"github.com/pilosa/pilosa/shardwidth"
 	"github.com/pilosa/pilosa/tracing"
 	"github.com/pkg/errors"
@@ -36,6 +43,18 @@ const (
 	// looking for additional id/count pairs.
 	defaultMinThreshold = 1
 
+	// maxCountSeriesBuckets is the maximum number of intervals which may be
+	// counted by a single CountSeries() call.
+	maxCountSeriesBuckets = 10000
+
+	// maxRetentionPeriods is the maximum number of periods which may be
+	// computed by a single Retention() call.
+	maxRetentionPeriods = 500
+
+	// maxFunnelBuckets is the maximum number of time quantum units between
+	// the from and to times of a single Funnel() call.
+	maxFunnelBuckets = 2000
+
 	columnLabel = "col"
 	rowLabel    = "row"
 )
@@ -208,6 +227,158 @@ func (e *executor) Execute(ctx context.Context, index string, q *pql.Query, shar
 	return resp, nil
 }
 
+// streamShardsPerNode is the number of shards per node in the cluster which
+// are executed at a time when streaming the results of a bitmap call.
+const streamShardsPerNode = 4
+
+// ExecuteStream executes a PQL query and passes each result to fn as soon as
+// it is available. The results of bitmap calls are passed in shard order as
+// one row per shard, so the full set of columns is never held in memory.
+// Batches of shards are only executed once fn has returned for the previous
+// batch. Column attributes are not supported.
+func (e *executor) ExecuteStream(ctx context.Context, index string, q *pql.Query, shards []uint64, opt *execOptions, fn func(call int, result interface{}) error) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.ExecuteStream")
+	defer span.Finish()
+
+	// Verify that an index is set.
+	if index == "" {
+		return ErrIndexRequired
+	}
+
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return ErrIndexNotFound
+	}
+
+	// Verify that the number of writes do not exceed the maximum.
+	if e.MaxWritesPerRequest > 0 && q.WriteCallN() > e.MaxWritesPerRequest {
+		return ErrTooManyWrites
+	}
+
+	// Default options.
+	if opt == nil {
+		opt = &execOptions{}
+	}
+	if opt.Remote {
+		return errors.New("cannot stream a remote query")
+	} else if opt.ColumnAttrs {
+		return errors.New("column attributes cannot be streamed")
+	}
+
+	// Translate query keys to ids, if necessary.
+	if err := e.translateCalls(ctx, index, idx, q.Calls); err != nil {
+		return err
+	}
+
+	// Include all shards if shards aren't specified.
+	if len(shards) == 0 {
+		shards = idx.AvailableShards().Slice()
+		if len(shards) == 0 {
+			shards = []uint64{0}
+		}
+	}
+
+	for i, c := range q.Calls {
+		if err := validateQueryContext(ctx); err != nil {
+			return err
+		}
+
+		// Execute non-bitmap calls as a whole.
+		if !isBitmapCall(c) {
+			v, err := e.executeCall(ctx, index, c, shards, opt)
+			if err != nil {
+				return err
+			}
+			if v, err = e.translateResult(index, idx, c, v); err != nil {
+				return err
+			} else if err := fn(i, v); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := e.executeBitmapCallStream(ctx, index, idx, c, shards, opt, func(row *Row) error {
+			return fn(i, row)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeBitmapCallStream executes a bitmap call in batches of shards and
+// passes the translated row for each shard with columns to fn. A single
+// empty row is passed if no shard has any columns. Row attributes are only
+// included with the first row.
+func (e *executor) executeBitmapCallStream(ctx context.Context, index string, idx *Index, c *pql.Call, shards []uint64, opt *execOptions, fn func(*Row) error) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeBitmapCallStream")
+	defer span.Finish()
+
+	if err := e.validateCallArgs(c); err != nil {
+		return errors.Wrap(err, "validating args")
+	} else if err := e.executeJoins(ctx, index, c, opt); err != nil {
+		return errors.Wrap(err, "executing joins")
+	}
+	e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{fmt.Sprintf("index:%s", index)})
+
+	shards = append([]uint64(nil), shards...)
+	sort.Sort(uint64Slice(shards))
+
+	batchSize := streamShardsPerNode * len(e.Cluster.nodes)
+	if batchSize == 0 {
+		batchSize = streamShardsPerNode
+	}
+
+	var attrs map[string]interface{}
+	var n int
+	for len(shards) > 0 {
+		batch := shards
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		shards = shards[len(batch):]
+
+		row, err := e.executeBitmapCall(ctx, index, c, batch, opt)
+		if err != nil {
+			return err
+		} else if attrs == nil {
+			attrs = row.Attrs
+		}
+
+		for _, segment := range row.Segments() {
+			if segment.Count() == 0 {
+				continue
+			}
+			other := &Row{segments: []rowSegment{segment}}
+			if n == 0 {
+				other.Attrs = attrs
+			}
+			result, err := e.translateResult(index, idx, c, other)
+			if err != nil {
+				return err
+			} else if err := fn(result.(*Row)); err != nil {
+				return err
+			}
+			n++
+		}
+	}
+
+	// Ensure every call has a result.
+	if n == 0 {
+		return fn(&Row{Attrs: attrs})
+	}
+	return nil
+}
+
+// isBitmapCall returns true if c is executed as a row of columns.
+func isBitmapCall(c *pql.Call) bool {
+	switch c.Name {
+	case "Row", "Range", "Difference", "Intersect", "Union", "Xor", "Not", "Shift", "Join":
+		return true
+	}
+	return false
+}
+
 // readColumnAttrSets returns a list of column attribute objects by id.
 func (e *executor) readColumnAttrSets(index *Index, ids []uint64) ([]*ColumnAttrSet, error) {
 	if index == nil {
@@ -285,6 +456,13 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 	}
 	indexTag := fmt.Sprintf("index:%s", index)
 
+	// Execute the inputs of cross-index joins before the call is distributed.
+	if !opt.Remote {
+		if err := e.executeJoins(ctx, index, c, opt); err != nil {
+			return nil, errors.Wrap(err, "executing joins")
+		}
+	}
+
 	// Fixes #2009
 	// See: https://github.com/pilosa/pilosa/issues/2009
 	// TODO: Remove at version 2.0
@@ -303,6 +481,15 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 	case "Max":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
 		return e.executeMax(ctx, index, c, shards, opt)
+	case "Distinct":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeDistinct(ctx, index, c, shards, opt)
+	case "Percentile":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executePercentile(ctx, index, c, shards, opt)
+	case "Sort":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeSort(ctx, index, c, shards, opt)
 	case "MinRow":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
 		return e.executeMinRow(ctx, index, c, shards, opt)
@@ -315,9 +502,32 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 		return e.executeClearRow(ctx, index, c, shards, opt)
 	case "Store":
 		return e.executeSetRow(ctx, index, c, shards, opt)
+	case "Delete":
+		return e.executeDelete(ctx, index, c, shards, opt)
 	case "Count":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
 		return e.executeCount(ctx, index, c, shards, opt)
+	case "CountApprox":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeCountApprox(ctx, index, c, shards, opt)
+	case "Sample":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeSample(ctx, index, c, shards, opt)
+	case "CountSeries":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeCountSeries(ctx, index, c, shards, opt)
+	case "Retention":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeRetention(ctx, index, c, shards, opt)
+	case "Funnel":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeFunnel(ctx, index, c, shards, opt)
+	case "Similar":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeSimilar(ctx, index, c, shards, opt)
+	case "Overlap":
+		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		return e.executeOverlap(ctx, index, c, shards, opt)
 	case "Set":
 		return e.executeSet(ctx, index, c, opt)
 	case "SetRowAttrs":
@@ -326,6 +536,11 @@ func (e *executor) executeCall(ctx context.Context, index string, c *pql.Call, s
 		return nil, e.executeSetColumnAttrs(ctx, index, c, opt)
 	case "TopN":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
+		if approximate, _, err := c.BoolArg("approximate"); err != nil {
+			return nil, errors.Wrap(err, "reading approximate")
+		} else if approximate {
+			return e.executeTopNApprox(ctx, index, c, shards, opt)
+		}
 		return e.executeTopN(ctx, index, c, shards, opt)
 	case "Rows":
 		e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{indexTag})
@@ -402,9 +617,103 @@ func (e *executor) executeOptionsCall(ctx context.Context, index string, c *pql.
 			return nil, errors.New("Query(): shards must be a list of unsigned integers")
 		}
 	}
+
+	// Paginate row results if a limit or cursor is provided.
+	limit, hasLimit, err := c.UintArg("limit")
+	if err != nil {
+		return nil, errors.Wrap(err, "Query(): limit must be an unsigned integer")
+	} else if hasLimit && limit == 0 {
+		return nil, errors.New("Query(): limit must be greater than zero")
+	}
+	after, hasAfter, err := c.UintArg("after")
+	if err != nil {
+		return nil, errors.Wrap(err, "Query(): after must be a column")
+	}
+	if hasLimit || hasAfter {
+		if len(c.Children) == 0 || !isBitmapCall(c.Children[0]) {
+			return nil, errors.New("Query(): limit and after only apply to calls which return a row")
+		}
+		var cursor *uint64
+		if hasAfter {
+			cursor = &after
+		}
+		return e.executeBitmapCallPage(ctx, index, c.Children[0], shards, optCopy, limit, cursor)
+	}
+
 	return e.executeCall(ctx, index, c.Children[0], shards, optCopy)
 }
 
+// executeBitmapCallPage executes a bitmap call and returns at most limit
+// columns which come after the given column. A limit of zero returns all
+// remaining columns. Shards are executed in ascending order, one batch at a
+// time, so only the shards required to fill the page are read. One column
+// beyond the limit is read so that the returned row only has a cursor to the
+// next page if there is one.
+func (e *executor) executeBitmapCallPage(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions, limit uint64, after *uint64) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeBitmapCallPage")
+	defer span.Finish()
+
+	if err := e.validateCallArgs(c); err != nil {
+		return nil, errors.Wrap(err, "validating args")
+	} else if err := e.executeJoins(ctx, index, c, opt); err != nil {
+		return nil, errors.Wrap(err, "executing joins")
+	}
+	e.Holder.Stats.CountWithCustomTags(c.Name, 1, 1.0, []string{fmt.Sprintf("index:%s", index)})
+
+	// Skip shards which precede the cursor.
+	a := make([]uint64, 0, len(shards))
+	for _, shard := range shards {
+		if after == nil || shard >= *after/ShardWidth {
+			a = append(a, shard)
+		}
+	}
+	shards = a
+	sort.Sort(uint64Slice(shards))
+
+	batchSize := len(e.Cluster.nodes)
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	var columns []uint64
+	var attrs map[string]interface{}
+	first := true
+	for len(shards) > 0 && (limit == 0 || uint64(len(columns)) <= limit) {
+		batch := shards
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		shards = shards[len(batch):]
+
+		row, err := e.executeBitmapCall(ctx, index, c, batch, opt)
+		if err != nil {
+			return nil, err
+		} else if first {
+			attrs, first = row.Attrs, false
+		}
+
+		for _, col := range row.Columns() {
+			if after != nil && col <= *after {
+				continue
+			}
+			columns = append(columns, col)
+			if limit != 0 && uint64(len(columns)) > limit {
+				break
+			}
+		}
+	}
+
+	var next *RowCursor
+	if limit != 0 && uint64(len(columns)) > limit {
+		columns = columns[:limit]
+		next = &RowCursor{Column: columns[len(columns)-1]}
+	}
+	row := NewRow(columns...)
+	row.Attrs = attrs
+	row.Next = next
+	return row, nil
+}
+
 // executeSum executes a Sum() call.
 func (e *executor) executeSum(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (ValCount, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSum")
@@ -438,6 +747,7 @@ func (e *executor) executeSum(ctx context.Context, index string, c *pql.Call, sh
 	if other.Count == 0 {
 		return ValCount{}, nil
 	}
+	other.Scale = e.fieldScale(index, c)
 	return other, nil
 }
 
@@ -474,6 +784,8 @@ func (e *executor) executeMin(ctx context.Context, index string, c *pql.Call, sh
 	if other.Count == 0 {
 		return ValCount{}, nil
 	}
+	other.Scale = e.fieldScale(index, c)
+	other.TimeUnit = e.fieldTimeUnit(index, c)
 	return other, nil
 }
 
@@ -510,102 +822,450 @@ func (e *executor) executeMax(ctx context.Context, index string, c *pql.Call, sh
 	if other.Count == 0 {
 		return ValCount{}, nil
 	}
+	other.Scale = e.fieldScale(index, c)
+	other.TimeUnit = e.fieldTimeUnit(index, c)
 	return other, nil
 }
 
-// executeMinRow executes a MinRow() call.
-func (e *executor) executeMinRow(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeMinRow")
+// fieldScale returns the scale of the field referenced by the call's field
+// argument, or zero if it is not a decimal field.
+func (e *executor) fieldScale(index string, c *pql.Call) int64 {
+	fieldName, _ := c.Args["field"].(string)
+	if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeDecimal {
+		return f.Options().Scale
+	}
+	return 0
+}
+
+// fieldTimeUnit returns the unit of the field referenced by the call's field
+// argument, or an empty string if it is not a timestamp field.
+func (e *executor) fieldTimeUnit(index string, c *pql.Call) string {
+	fieldName, _ := c.Args["field"].(string)
+	if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeTimestamp {
+		return f.Options().TimeUnit
+	}
+	return ""
+}
+
+// executeDistinct executes a Distinct() call.
+func (e *executor) executeDistinct(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (DistinctValues, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDistinct")
 	defer span.Finish()
 
-	if field := c.Args["field"]; field == "" {
-		return ValCount{}, errors.New("MinRow(): field required")
+	if field, _ := c.Args["field"].(string); field == "" {
+		return nil, errors.New("Distinct(): field required")
+	} else if e.fieldScale(index, c) != 0 {
+		return nil, errors.New("Distinct(): decimal fields are not supported")
+	}
+
+	if len(c.Children) > 1 {
+		return nil, errors.New("Distinct() only accepts a single bitmap input")
+	}
+
+	// Determine limit so we can use it when reducing.
+	limit := int(^uint(0) >> 1)
+	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+		return nil, err
+	} else if hasLimit {
+		limit = int(lim)
 	}
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeMinRowShard(ctx, index, c, shard)
+		return e.executeDistinctShard(ctx, index, c, shard, limit)
 	}
 
 	// Merge returned results at coordinating node.
 	reduceFn := func(prev, v interface{}) interface{} {
-		// if minRowID exists, and if it is smaller than the other one return it.
-		// otherwise return the minRowID of the one which exists.
-		prevp, _ := prev.(Pair)
-		vp, _ := v.(Pair)
-		if prevp.Count > 0 && vp.Count > 0 {
-			if prevp.ID < vp.ID {
-				return prevp
-			}
-			return vp
-		} else if prevp.Count > 0 {
-			return prevp
-		}
-		return vp
+		other, _ := prev.(DistinctValues)
+		return other.merge(v.(DistinctValues), limit)
 	}
 
-	return e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	other, _ := result.(DistinctValues)
+	if other == nil {
+		other = DistinctValues{}
+	}
+	return other, nil
 }
 
-// executeMinRow executes a MaxRow() call.
-func (e *executor) executeMaxRow(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeMaxRow")
+// executeSort executes a Sort() call. It returns the columns with the
+// highest or lowest values of an int field, along with their values.
+func (e *executor) executeSort(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) ([]ColumnValue, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSort")
 	defer span.Finish()
 
-	if field := c.Args["field"]; field == "" {
-		return ValCount{}, errors.New("MaxRow(): field required")
+	fieldName, _ := c.Args["field"].(string)
+	if fieldName == "" {
+		return nil, errors.New("Sort(): field required")
+	} else if field := e.Holder.Field(index, fieldName); field == nil {
+		return nil, ErrFieldNotFound
+	} else if field.bsiGroup(fieldName) == nil {
+		return nil, errors.Errorf("Sort(): field %q is not an int, decimal or timestamp field", fieldName)
+	}
+
+	if len(c.Children) > 1 {
+		return nil, errors.New("Sort() only accepts a single bitmap input")
+	}
+
+	// Determine limit so we can use it when reducing.
+	limit := int(^uint(0) >> 1)
+	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+		return nil, err
+	} else if hasLimit {
+		limit = int(lim)
+	}
+
+	descending := false
+	if arg, ok := c.Args["desc"]; ok {
+		if descending, ok = arg.(bool); !ok {
+			return nil, errors.New("Sort(): desc must be a bool")
+		}
 	}
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeMaxRowShard(ctx, index, c, shard)
+		return e.executeSortShard(ctx, index, c, shard, limit, descending)
 	}
 
 	// Merge returned results at coordinating node.
 	reduceFn := func(prev, v interface{}) interface{} {
-		// if minRowID exists, and if it is smaller than the other one return it.
-		// otherwise return the minRowID of the one which exists.
-		prevp, _ := prev.(Pair)
-		vp, _ := v.(Pair)
-		if prevp.Count > 0 && vp.Count > 0 {
-			if prevp.ID > vp.ID {
-				return prevp
-			}
-			return vp
-		} else if prevp.Count > 0 {
-			return prevp
-		}
-		return vp
+		other, _ := prev.([]ColumnValue)
+		return mergeColumnValues(other, v.([]ColumnValue), limit, descending)
 	}
 
-	return e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	other, _ := result.([]ColumnValue)
+	if other == nil {
+		other = []ColumnValue{}
+	}
+	scale, unit := e.fieldScale(index, c), e.fieldTimeUnit(index, c)
+	for i := range other {
+		other[i].Scale, other[i].TimeUnit = scale, unit
+	}
+	return other, nil
 }
 
-// executeBitmapCall executes a call that returns a bitmap.
-func (e *executor) executeBitmapCall(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (*Row, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeBitmapCall")
+// percentileThresholds is the number of values counted by each round of the
+// search for a percentile.
+const percentileThresholds = 64
+
+// PercentileCounts is the number of values less than or equal to each
+// threshold of a round of a Percentile() search. It is exported because the
+// proto package needs access to it.
+type PercentileCounts []uint64
+
+// executePercentile executes a Percentile() call. The nth percentile is
+// determined by the nearest-rank method: it is the smallest value in the field
+// for which at least nth percent of the values are less than or equal to it.
+// The value space between the min and max is searched in rounds. Each round
+// sends the call with a "thresholds" arg which splits the remaining range, and
+// each shard counts the values less than or equal to every threshold. Remote
+// nodes return the PercentileCounts of their shards.
+func (e *executor) executePercentile(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executePercentile")
 	defer span.Finish()
 
-	// Execute calls in bulk on each remote node and merge.
-	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeBitmapCallShard(ctx, index, c, shard)
+	fieldName, _ := c.Args["field"].(string)
+	if fieldName == "" {
+		return ValCount{}, errors.New("Percentile(): field required")
+	}
+
+	var nth float64
+	switch v := c.Args["nth"].(type) {
+	case int64:
+		nth = float64(v)
+	case uint64:
+		nth = float64(v)
+	case float64:
+		nth = v
+	case nil:
+		return ValCount{}, errors.New("Percentile(): nth required")
+	default:
+		return ValCount{}, errors.Errorf("Percentile(): invalid nth type %T", v)
+	}
+	if nth < 0 || nth > 100 {
+		return ValCount{}, errors.New("Percentile(): nth must be between 0 and 100")
 	}
 
-	// Merge returned results at coordinating node.
-	reduceFn := func(prev, v interface{}) interface{} {
-		other, _ := prev.(*Row)
-		if other == nil {
-			other = NewRow()
+	filter, _, err := c.CallArg("filter")
+	if err != nil {
+		return ValCount{}, err
+	}
+
+	if _, ok := c.Args["thresholds"]; ok {
+		return e.executePercentileCounts(ctx, index, c, filter, shards, opt)
+	}
+
+	// newCall returns a call of the given name against the field, limited to
+	// the filter if one was given.
+	newCall := func(name string) *pql.Call {
+		call := &pql.Call{Name: name, Args: map[string]interface{}{"field": fieldName}}
+		if filter != nil {
+			call.Children = []*pql.Call{filter}
 		}
-		other.Merge(v.(*Row))
-		return other
+		return call
 	}
 
-	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	// Determine the number of values as well as the bounds of the search.
+	total, err := e.executeSum(ctx, index, newCall("Sum"), shards, opt)
 	if err != nil {
-		return nil, errors.Wrap(err, "map reduce")
+		return ValCount{}, errors.Wrap(err, "counting values")
+	} else if total.Count == 0 {
+		return ValCount{}, nil
 	}
-
+	min, err := e.executeMin(ctx, index, newCall("Min"), shards, opt)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "getting min")
+	}
+	max, err := e.executeMax(ctx, index, newCall("Max"), shards, opt)
+	if err != nil {
+		return ValCount{}, errors.Wrap(err, "getting max")
+	}
+
+	// Determine the rank of the value we are looking for.
+	rank := uint64(math.Ceil(nth / 100 * float64(total.Count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	// Narrow the range [lo, hi] which contains the value until it is a
+	// single value. below is the number of values less than lo.
+	lo, hi, below := min.Val, max.Val, uint64(0)
+	for {
+		thresholds := percentileSplit(lo, hi)
+		other := c.Clone()
+		args := make([]interface{}, len(thresholds))
+		for i := range thresholds {
+			args[i] = thresholds[i]
+		}
+		other.Args["thresholds"] = args
+		result, err := e.executePercentileCounts(ctx, index, other, filter, shards, opt)
+		if err != nil {
+			return ValCount{}, errors.Wrap(err, "counting values")
+		}
+		counts := result
+
+		// Find the first threshold with enough values at or below it. The
+		// last threshold is hi, so it is used if the values have changed.
+		i := sort.Search(len(counts)-1, func(i int) bool { return counts[i] >= rank })
+		if i > 0 {
+			lo, below = thresholds[i-1]+1, counts[i-1]
+		}
+		if lo == thresholds[i] {
+			return ValCount{Val: lo, Count: int64(counts[i] - below), Scale: min.Scale, TimeUnit: min.TimeUnit}, nil
+		}
+		hi = thresholds[i]
+	}
+}
+
+// percentileSplit returns up to percentileThresholds values which split the
+// range [lo, hi] into parts of equal size. The last value is hi.
+func percentileSplit(lo, hi int64) []int64 {
+	// Offsets from lo are unsigned so that the full range of int64 fits.
+	span := uint64(hi) - uint64(lo)
+	width := span/percentileThresholds + 1
+
+	thresholds := make([]int64, 0, percentileThresholds)
+	for off := width - 1; ; off += width {
+		if off >= span || off < width-1 {
+			return append(thresholds, hi)
+		}
+		thresholds = append(thresholds, lo+int64(off))
+	}
+}
+
+// executePercentileCounts counts the values less than or equal to each value
+// in the "thresholds" arg of a Percentile() call.
+func (e *executor) executePercentileCounts(ctx context.Context, index string, c *pql.Call, filter *pql.Call, shards []uint64, opt *execOptions) (PercentileCounts, error) {
+	fieldName, _ := c.Args["field"].(string)
+	var thresholds []int64
+	switch v := c.Args["thresholds"].(type) {
+	case []interface{}:
+		thresholds = make([]int64, len(v))
+		for i := range v {
+			n, ok := v[i].(int64)
+			if !ok {
+				return nil, errors.Errorf("Percentile(): invalid threshold type %T", v[i])
+			}
+			thresholds[i] = n
+		}
+	default:
+		return nil, errors.Errorf("Percentile(): invalid thresholds type %T", v)
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executePercentileCountsShard(ctx, index, fieldName, thresholds, filter, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(PercentileCounts)
+		if other == nil {
+			other = make(PercentileCounts, len(thresholds))
+		}
+		for i, n := range v.(PercentileCounts) {
+			other[i] += n
+		}
+		return other
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	counts, _ := result.(PercentileCounts)
+	if counts == nil {
+		counts = make(PercentileCounts, len(thresholds))
+	}
+	return counts, nil
+}
+
+// executePercentileCountsShard counts the values of a field in a single shard
+// which are less than or equal to each threshold, using fragment.rangeLT.
+func (e *executor) executePercentileCountsShard(ctx context.Context, index string, fieldName string, thresholds []int64, filter *pql.Call, shard uint64) (PercentileCounts, error) {
+	counts := make(PercentileCounts, len(thresholds))
+
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, ErrFieldNotFound
+	}
+	bsig := f.bsiGroup(fieldName)
+	if bsig == nil {
+		return nil, ErrBSIGroupNotFound
+	}
+	frag := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+	if frag == nil {
+		return counts, nil
+	}
+
+	var filterRow *Row
+	if filter != nil {
+		row, err := e.executeBitmapCallShard(ctx, index, filter, shard)
+		if err != nil {
+			return nil, errors.Wrap(err, "executing filter")
+		}
+		filterRow = row
+	}
+
+	for i, threshold := range thresholds {
+		baseValue, outOfRange := bsig.baseValue(pql.LTE, threshold)
+		if outOfRange {
+			continue
+		}
+		row, err := frag.rangeLT(bsig.BitDepth, baseValue, true)
+		if err != nil {
+			return nil, err
+		}
+		if filterRow != nil {
+			counts[i] = row.intersectionCount(filterRow)
+		} else {
+			counts[i] = row.Count()
+		}
+	}
+	return counts, nil
+}
+
+// executeMinRow executes a MinRow() call.
+func (e *executor) executeMinRow(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeMinRow")
+	defer span.Finish()
+
+	if field := c.Args["field"]; field == "" {
+		return ValCount{}, errors.New("MinRow(): field required")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeMinRowShard(ctx, index, c, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		// if minRowID exists, and if it is smaller than the other one return it.
+		// otherwise return the minRowID of the one which exists.
+		prevp, _ := prev.(Pair)
+		vp, _ := v.(Pair)
+		if prevp.Count > 0 && vp.Count > 0 {
+			if prevp.ID < vp.ID {
+				return prevp
+			}
+			return vp
+		} else if prevp.Count > 0 {
+			return prevp
+		}
+		return vp
+	}
+
+	return e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+}
+
+// executeMinRow executes a MaxRow() call.
+func (e *executor) executeMaxRow(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeMaxRow")
+	defer span.Finish()
+
+	if field := c.Args["field"]; field == "" {
+		return ValCount{}, errors.New("MaxRow(): field required")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeMaxRowShard(ctx, index, c, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		// if minRowID exists, and if it is smaller than the other one return it.
+		// otherwise return the minRowID of the one which exists.
+		prevp, _ := prev.(Pair)
+		vp, _ := v.(Pair)
+		if prevp.Count > 0 && vp.Count > 0 {
+			if prevp.ID > vp.ID {
+				return prevp
+			}
+			return vp
+		} else if prevp.Count > 0 {
+			return prevp
+		}
+		return vp
+	}
+
+	return e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+}
+
+// executeBitmapCall executes a call that returns a bitmap.
+func (e *executor) executeBitmapCall(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeBitmapCall")
+	defer span.Finish()
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeBitmapCallShard(ctx, index, c, shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			other = NewRow()
+		}
+		other.Merge(v.(*Row))
+		return other
+	}
+
+	other, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, errors.Wrap(err, "map reduce")
+	}
+
 	// Attach attributes for non-BSI Row() calls.
 	// If the column label is used then return column attributes.
 	// If the row label is used then return bitmap attributes.
@@ -674,6 +1334,8 @@ func (e *executor) executeBitmapCallShard(ctx context.Context, index string, c *
 		return e.executeNotShard(ctx, index, c, shard)
 	case "Shift":
 		return e.executeShiftShard(ctx, index, c, shard)
+	case "Join":
+		return e.executeJoinShard(ctx, index, c, shard)
 	default:
 		return nil, fmt.Errorf("unknown call: %s", c.Name)
 	}
@@ -799,6 +1461,89 @@ func (e *executor) executeMaxShard(ctx context.Context, index string, c *pql.Cal
 	}, nil
 }
 
+// executeDistinctShard returns the distinct bsiGroup values on a shard.
+func (e *executor) executeDistinctShard(ctx context.Context, index string, c *pql.Call, shard uint64, limit int) (DistinctValues, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDistinctShard")
+	defer span.Finish()
+
+	var filter *Row
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		filter = row
+	}
+
+	fieldName, _ := c.Args["field"].(string)
+
+	field := e.Holder.Field(index, fieldName)
+	if field == nil {
+		return nil, nil
+	}
+
+	bsig := field.bsiGroup(fieldName)
+	if bsig == nil {
+		return nil, nil
+	}
+
+	fragment := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+	if fragment == nil {
+		return nil, nil
+	}
+
+	values, err := fragment.distinct(filter, bsig.BitDepth, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range values {
+		values[i] += bsig.Base
+	}
+	return DistinctValues(values), nil
+}
+
+// executeSortShard returns the columns with the highest or lowest bsiGroup
+// values on a shard.
+func (e *executor) executeSortShard(ctx context.Context, index string, c *pql.Call, shard uint64, limit int, descending bool) ([]ColumnValue, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSortShard")
+	defer span.Finish()
+
+	var filter *Row
+	if len(c.Children) == 1 {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		filter = row
+	}
+
+	fieldName, _ := c.Args["field"].(string)
+
+	field := e.Holder.Field(index, fieldName)
+	if field == nil {
+		return nil, nil
+	}
+
+	bsig := field.bsiGroup(fieldName)
+	if bsig == nil {
+		return nil, nil
+	}
+
+	fragment := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+	if fragment == nil {
+		return nil, nil
+	}
+
+	values, err := fragment.sortValues(filter, bsig.BitDepth, limit, descending)
+	if err != nil {
+		return nil, err
+	}
+	for i := range values {
+		values[i].Value += bsig.Base
+	}
+	return values, nil
+}
+
 // executeMinRowShard returns the minimum row ID for a shard.
 func (e *executor) executeMinRowShard(ctx context.Context, index string, c *pql.Call, shard uint64) (Pair, error) {
 	var filter *Row
@@ -938,7 +1683,7 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 	n, _, err := c.UintArg("n")
 	if err != nil {
 		return nil, fmt.Errorf("executeTopNShard: %v", err)
-	} else if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeInt {
+	} else if f := e.Holder.Field(index, fieldName); f != nil && (f.Type() == FieldTypeInt || f.Type() == FieldTypeDecimal || f.Type() == FieldTypeTimestamp) {
 		return nil, fmt.Errorf("cannot compute TopN() on integer field: %q", fieldName)
 	}
 
@@ -974,13 +1719,6 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 		fieldName = defaultField
 	}
 
-	f := e.Holder.fragment(index, fieldName, viewStandard, shard)
-	if f == nil {
-		return nil, nil
-	} else if f.CacheType == CacheTypeNone {
-		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
-	}
-
 	if minThreshold == 0 {
 		minThreshold = defaultMinThreshold
 	}
@@ -988,7 +1726,7 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 	if tanimotoThreshold > 100 {
 		return nil, errors.New("Tanimoto Threshold is from 1 to 100 only")
 	}
-	return f.top(topOptions{
+	opt := topOptions{
 		N:                 int(n),
 		Src:               src,
 		RowIDs:            rowIDs,
@@ -996,22 +1734,113 @@ func (e *executor) executeTopNShard(ctx context.Context, index string, c *pql.Ca
 		FilterValues:      attrValues,
 		MinThreshold:      minThreshold,
 		TanimotoThreshold: tanimotoThreshold,
-	})
-}
-
-// executeDifferenceShard executes a difference() call for a local shard.
-func (e *executor) executeDifferenceShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDifferenceShard")
-	defer span.Finish()
-
-	var other *Row
-	if len(c.Children) == 0 {
-		return nil, fmt.Errorf("empty Difference query is currently not supported")
 	}
-	for i, input := range c.Children {
-		row, err := e.executeBitmapCallShard(ctx, index, input, shard)
+
+	// Time fields rank rows across the views covering the from/to range.
+	if field := e.Holder.Field(index, fieldName); field != nil && field.Type() == FieldTypeTime {
+		views, err := rowsViews(field, c)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(err, "getting views")
+		}
+		if len(views) != 1 || views[0] != viewStandard {
+			if field.Options().CacheType == CacheTypeNone {
+				return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
+			}
+			var frags []*fragment
+			for _, view := range views {
+				if f := e.Holder.fragment(index, fieldName, view, shard); f != nil {
+					frags = append(frags, f)
+				}
+			}
+			return topFragments(frags, opt)
+		}
+	}
+
+	f := e.Holder.fragment(index, fieldName, viewStandard, shard)
+	if f == nil {
+		return nil, nil
+	} else if f.CacheType == CacheTypeNone {
+		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
+	}
+	return f.top(opt)
+}
+
+// executeTopNApprox executes a TopN() call with approximate=true. Each shard
+// returns the top rows of its count cache and a count-min sketch of the
+// counts of the rows still in that cache. The sketches are summed and used to rank the candidates
+// without a second pass. Remote nodes return the merged TopNSketch.
+func (e *executor) executeTopNApprox(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeTopNApprox")
+	defer span.Finish()
+
+	n, _, err := c.UintArg("n")
+	if err != nil {
+		return nil, fmt.Errorf("executeTopNApprox: %v", err)
+	} else if len(c.Children) > 0 {
+		return nil, errors.New("approximate TopN() does not support an input bitmap")
+	}
+	for _, arg := range []string{"ids", "attrName", "attrValues", "threshold", "tanimotoThreshold", "from", "to"} {
+		if _, ok := c.Args[arg]; ok {
+			return nil, fmt.Errorf("approximate TopN() does not support %s", arg)
+		}
+	}
+
+	fieldName, _ := c.Args["_field"].(string)
+	if fieldName == "" {
+		fieldName = defaultField
+	}
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, ErrFieldNotFound
+	}
+	switch f.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		return nil, fmt.Errorf("cannot compute TopN() on integer field: %q", fieldName)
+	}
+	if f.Options().CacheType == CacheTypeNone {
+		return nil, fmt.Errorf("cannot compute TopN(), field has no cache: %q", fieldName)
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		frag := e.Holder.fragment(index, fieldName, viewStandard, shard)
+		if frag == nil {
+			return TopNSketch{}, nil
+		}
+		return frag.topSketch(int(n)), nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(TopNSketch)
+		return other.merge(v.(TopNSketch))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	sketch, _ := result.(TopNSketch)
+
+	if opt.Remote {
+		return sketch, nil
+	}
+	return sketch.top(int(n)), nil
+}
+
+// executeDifferenceShard executes a difference() call for a local shard.
+func (e *executor) executeDifferenceShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDifferenceShard")
+	defer span.Finish()
+
+	var other *Row
+	if len(c.Children) == 0 {
+		return nil, fmt.Errorf("empty Difference query is currently not supported")
+	}
+	for i, input := range c.Children {
+		row, err := e.executeBitmapCallShard(ctx, index, input, shard)
+		if err != nil {
+			return nil, err
 		}
 
 		if i == 0 {
@@ -1068,6 +1897,324 @@ func (r RowIDs) merge(other RowIDs, limit int) RowIDs {
 	return result
 }
 
+// DistinctValues is a query return type for the distinct values of an
+// integer field, in ascending order.
+type DistinctValues []int64
+
+// merge returns the ordered union of two sets of distinct values, up to limit.
+func (d DistinctValues) merge(other DistinctValues, limit int) DistinctValues {
+	i, j := 0, 0
+	result := make(DistinctValues, 0)
+	for i < len(d) && j < len(other) && len(result) < limit {
+		av, bv := d[i], other[j]
+		if av < bv {
+			result = append(result, av)
+			i++
+		} else if av > bv {
+			result = append(result, bv)
+			j++
+		} else {
+			result = append(result, bv)
+			i++
+			j++
+		}
+	}
+	for i < len(d) && len(result) < limit {
+		result = append(result, d[i])
+		i++
+	}
+	for j < len(other) && len(result) < limit {
+		result = append(result, other[j])
+		j++
+	}
+	return result
+}
+
+// DistinctKeys is a query return type for the distinct values of an
+// integer field which references the columns of a keyed index.
+type DistinctKeys []string
+
+// SeriesCount is the number of columns counted within one interval of a
+// CountSeries() call. Time is the start of the interval.
+type SeriesCount struct {
+	Time  time.Time `json:"time"`
+	Count uint64    `json:"count"`
+}
+
+// CountSeries is a query return type for CountSeries() calls, in
+// ascending order of time.
+type CountSeries []SeriesCount
+
+// add returns the sum of the counts of two series over the same intervals.
+func (s CountSeries) add(other CountSeries) CountSeries {
+	if s == nil {
+		return other
+	}
+	result := make(CountSeries, len(s))
+	for i := range s {
+		result[i] = SeriesCount{Time: s[i].Time, Count: s[i].Count + other[i].Count}
+	}
+	return result
+}
+
+// RetentionCohort is one row of the matrix returned by Retention(). Size is
+// the number of columns in the start bitmap during the period beginning at
+// Time, and Counts[i] is the number of those columns in the return bitmap
+// i periods later.
+type RetentionCohort struct {
+	Time   time.Time `json:"time"`
+	Size   uint64    `json:"size"`
+	Counts []uint64  `json:"counts"`
+}
+
+// RetentionMatrix is a query return type for Retention() calls, with one
+// cohort per period in ascending order of time.
+type RetentionMatrix []RetentionCohort
+
+// add returns the sum of two matrices over the same periods.
+func (m RetentionMatrix) add(other RetentionMatrix) RetentionMatrix {
+	if m == nil {
+		return other
+	}
+	result := make(RetentionMatrix, len(m))
+	for i := range m {
+		counts := make([]uint64, len(m[i].Counts))
+		for j := range counts {
+			counts[j] = m[i].Counts[j] + other[i].Counts[j]
+		}
+		result[i] = RetentionCohort{Time: m[i].Time, Size: m[i].Size + other[i].Size, Counts: counts}
+	}
+	return result
+}
+
+// FunnelCounts is a query return type for Funnel() calls, holding the number
+// of columns which completed each step in order.
+type FunnelCounts []uint64
+
+// add returns the sum of the counts of two funnels with the same steps.
+func (f FunnelCounts) add(other FunnelCounts) FunnelCounts {
+	if f == nil {
+		return other
+	}
+	result := make(FunnelCounts, len(f))
+	for i := range f {
+		result[i] = f[i] + other[i]
+	}
+	return result
+}
+
+// Similarity metrics supported by Similar() and Overlap().
+const (
+	similarityJaccard = "jaccard"
+	similarityCosine  = "cosine"
+	similarityOverlap = "overlap"
+)
+
+// similarityMetric returns the "metric" arg of c, which defaults to jaccard.
+func similarityMetric(c *pql.Call) (string, error) {
+	metric, ok := c.Args["metric"]
+	if !ok {
+		return similarityJaccard, nil
+	}
+	switch metric {
+	case similarityJaccard, similarityCosine, similarityOverlap:
+		return metric.(string), nil
+	}
+	return "", fmt.Errorf("invalid similarity metric: %v", metric)
+}
+
+// similarity returns the similarity of two sets with counts a and b which
+// have n members in common. It is zero if either set is empty.
+func similarity(metric string, a, b, n uint64) float64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	switch metric {
+	case similarityCosine:
+		return float64(n) / math.Sqrt(float64(a)*float64(b))
+	case similarityOverlap:
+		if b < a {
+			a = b
+		}
+		return float64(n) / float64(a)
+	default:
+		return float64(n) / float64(a+b-n)
+	}
+}
+
+// RowSimilarity is the similarity of a row to the input bitmap of a
+// Similar() call. Count is the number of columns in the row, and
+// Intersection is the number of those columns in the input bitmap.
+type RowSimilarity struct {
+	ID           uint64  `json:"id"`
+	Key          string  `json:"key,omitempty"`
+	Count        uint64  `json:"count"`
+	Intersection uint64  `json:"intersection"`
+	Score        float64 `json:"score"`
+}
+
+// SimilarRows is a query return type for Similar() calls. Count is the
+// number of columns in the input bitmap, and Rows are in descending order of
+// score.
+type SimilarRows struct {
+	Count uint64          `json:"count"`
+	Rows  []RowSimilarity `json:"rows"`
+}
+
+// add returns the sum of the counts of two results, which must both have
+// rows in ascending order of ID. The rows of the result are not scored.
+func (s SimilarRows) add(other SimilarRows) SimilarRows {
+	result := SimilarRows{
+		Count: s.Count + other.Count,
+		Rows:  make([]RowSimilarity, 0, len(s.Rows)+len(other.Rows)),
+	}
+	i, j := 0, 0
+	for i < len(s.Rows) && j < len(other.Rows) {
+		a, b := s.Rows[i], other.Rows[j]
+		switch {
+		case a.ID < b.ID:
+			result.Rows = append(result.Rows, a)
+			i++
+		case a.ID > b.ID:
+			result.Rows = append(result.Rows, b)
+			j++
+		default:
+			a.Count += b.Count
+			a.Intersection += b.Intersection
+			result.Rows = append(result.Rows, a)
+			i, j = i+1, j+1
+		}
+	}
+	result.Rows = append(result.Rows, s.Rows[i:]...)
+	result.Rows = append(result.Rows, other.Rows[j:]...)
+	return result
+}
+
+// top returns the n rows with the highest scores using metric, or every
+// scored row if n is zero. Rows which do not intersect the input bitmap are
+// omitted. Rows with equal scores are ordered by ID.
+func (s SimilarRows) top(metric string, n int) SimilarRows {
+	rows := make([]RowSimilarity, 0)
+	for _, r := range s.Rows {
+		if r.Intersection > 0 {
+			r.Score = similarity(metric, s.Count, r.Count, r.Intersection)
+			rows = append(rows, r)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Score != rows[j].Score {
+			return rows[i].Score > rows[j].Score
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	if n > 0 && n < len(rows) {
+		rows = rows[:n]
+	}
+	return SimilarRows{Count: s.Count, Rows: rows}
+}
+
+// Overlap is a query return type for Overlap() calls. Counts holds the
+// number of columns in each input bitmap.
+type Overlap struct {
+	Counts       [2]uint64 `json:"counts"`
+	Intersection uint64    `json:"intersection"`
+	Union        uint64    `json:"union"`
+	Similarity   float64   `json:"similarity"`
+}
+
+// add returns the sum of the counts of two overlaps. The similarity of the
+// result is not set.
+func (o Overlap) add(other Overlap) Overlap {
+	return Overlap{
+		Counts:       [2]uint64{o.Counts[0] + other.Counts[0], o.Counts[1] + other.Counts[1]},
+		Intersection: o.Intersection + other.Intersection,
+		Union:        o.Union + other.Union,
+	}
+}
+
+// ColumnValue is a column and its field value, as returned by Sort().
+// ValueKey is set instead of Value when the field has a foreign index.
+// Value is scaled or expressed in TimeUnit units as it is in ValCount.
+type ColumnValue struct {
+	ID       uint64 `json:"id"`
+	Key      string `json:"key,omitempty"`
+	Value    int64  `json:"value"`
+	ValueKey string `json:"-"`
+	Scale    int64  `json:"-"`
+	TimeUnit string `json:"-"`
+}
+
+// MarshalJSON marshals ColumnValue to JSON such that
+// either a Key or an ID is included, and the value
+// is written as a key if it has one.
+func (cv ColumnValue) MarshalJSON() ([]byte, error) {
+	var value interface{} = json.Number(formatDecimal(cv.Value, cv.Scale))
+	if cv.ValueKey != "" {
+		value = cv.ValueKey
+	} else if cv.TimeUnit != "" {
+		value = cv.Timestamp().Format(time.RFC3339Nano)
+	}
+	if cv.Key != "" {
+		return json.Marshal(struct {
+			Key   string      `json:"key"`
+			Value interface{} `json:"value"`
+		}{
+			Key:   cv.Key,
+			Value: value,
+		})
+	}
+	return json.Marshal(struct {
+		ID    uint64      `json:"id"`
+		Value interface{} `json:"value"`
+	}{
+		ID:    cv.ID,
+		Value: value,
+	})
+}
+
+// DecimalValue returns the value with its scale applied.
+func (cv ColumnValue) DecimalValue() float64 {
+	return unscaleDecimal(cv.Value, cv.Scale)
+}
+
+// Timestamp returns the value as a time in its time unit.
+func (cv ColumnValue) Timestamp() time.Time {
+	return valueTimestamp(cv.Value, cv.TimeUnit)
+}
+
+// mergeColumnValues returns the ordered union of two sorted lists of column
+// values, up to limit. Equal values are ordered by column ID.
+func mergeColumnValues(a, b []ColumnValue, limit int, descending bool) []ColumnValue {
+	less := func(x, y ColumnValue) bool {
+		if x.Value != y.Value {
+			return (x.Value < y.Value) != descending
+		}
+		return x.ID < y.ID
+	}
+
+	i, j := 0, 0
+	result := make([]ColumnValue, 0)
+	for i < len(a) && j < len(b) && len(result) < limit {
+		if less(b[j], a[i]) {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+		}
+	}
+	for i < len(a) && len(result) < limit {
+		result = append(result, a[i])
+		i++
+	}
+	for j < len(b) && len(result) < limit {
+		result = append(result, b[j])
+		j++
+	}
+	return result
+}
+
 func (e *executor) executeGroupBy(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) ([]GroupCount, error) {
 	// validate call
 	if len(c.Children) == 0 {
@@ -1083,6 +2230,15 @@ func (e *executor) executeGroupBy(ctx context.Context, index string, c *pql.Call
 	if err != nil {
 		return nil, err
 	}
+	aggregate, _, err := c.CallArg("aggregate")
+	if err != nil {
+		return nil, err
+	}
+	if aggregate != nil {
+		if err := e.validateGroupByAggregate(index, aggregate); err != nil {
+			return nil, err
+		}
+	}
 
 	// perform necessary Rows queries (any that have limit or columns args) -
 	// TODO, call async? would only help if multiple Rows queries had a column
@@ -1120,7 +2276,7 @@ func (e *executor) executeGroupBy(ctx context.Context, index string, c *pql.Call
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(shard uint64) (interface{}, error) {
-		return e.executeGroupByShard(ctx, index, c, filter, shard, childRows)
+		return e.executeGroupByShard(ctx, index, c, filter, aggregate, shard, childRows)
 	}
 	// Merge returned results at coordinating node.
 	reduceFn := func(prev, v interface{}) interface{} {
@@ -1153,6 +2309,29 @@ func (e *executor) executeGroupBy(ctx context.Context, index string, c *pql.Call
 	return results, nil
 }
 
+// validateGroupByAggregate ensures that the aggregate argument of a GroupBy
+// call is a Sum() call against an existing integer field.
+func (e *executor) validateGroupByAggregate(index string, aggregate *pql.Call) error {
+	if aggregate.Name != "Sum" {
+		return errors.Errorf("'%s' is not a valid aggregate for GroupBy, must be 'Sum'", aggregate.Name)
+	}
+	if len(aggregate.Children) > 0 {
+		return errors.New("GroupBy aggregate does not support child calls")
+	}
+	fieldName, _ := aggregate.Args["field"].(string)
+	if fieldName == "" {
+		return errors.New("GroupBy aggregate: field required")
+	}
+	field := e.Holder.Field(index, fieldName)
+	if field == nil {
+		return ErrFieldNotFound
+	}
+	if field.Type() != FieldTypeInt || field.bsiGroup(fieldName) == nil {
+		return errors.Errorf("GroupBy aggregate: field '%s' is not an int field", fieldName)
+	}
+	return nil
+}
+
 // FieldRow is used to distinguish rows in a group by result.
 type FieldRow struct {
 	Field  string `json:"field"`
@@ -1186,10 +2365,23 @@ func (fr FieldRow) String() string {
 	return fmt.Sprintf("%s.%d.%s", fr.Field, fr.RowID, fr.RowKey)
 }
 
-// GroupCount represents a result item for a group by query.
+// GroupCount represents a result item for a group by query. Sum is only set
+// when the query has an aggregate.
 type GroupCount struct {
 	Group []FieldRow `json:"group"`
 	Count uint64     `json:"count"`
+	Sum   *int64     `json:"sum,omitempty"`
+}
+
+// addSums returns the sum of two optional group sums.
+func addSums(a, b *int64) *int64 {
+	if a == nil {
+		return b
+	} else if b == nil {
+		return a
+	}
+	sum := *a + *b
+	return &sum
 }
 
 // mergeGroupCounts merges two slices of GroupCounts throwing away any that go
@@ -1208,6 +2400,7 @@ func mergeGroupCounts(a, b []GroupCount, limit int) []GroupCount {
 			i++
 		case 0:
 			a[i].Count += b[j].Count
+			a[i].Sum = addSums(a[i].Sum, b[j].Sum)
 			ret = append(ret, a[i])
 			i++
 			j++
@@ -1238,7 +2431,7 @@ func (g GroupCount) Compare(o GroupCount) int {
 	return 0
 }
 
-func (e *executor) executeGroupByShard(ctx context.Context, index string, c *pql.Call, filter *pql.Call, shard uint64, childRows []RowIDs) (_ []GroupCount, err error) {
+func (e *executor) executeGroupByShard(ctx context.Context, index string, c *pql.Call, filter *pql.Call, aggregate *pql.Call, shard uint64, childRows []RowIDs) (_ []GroupCount, err error) {
 	var filterRow *Row
 	if filter != nil {
 		if filterRow, err = e.executeBitmapCallShard(ctx, index, filter, shard); err != nil {
@@ -1253,6 +2446,13 @@ func (e *executor) executeGroupByShard(ctx context.Context, index string, c *pql
 	if iter == nil {
 		return []GroupCount{}, nil
 	}
+	if aggregate != nil {
+		fieldName, _ := aggregate.Args["field"].(string)
+		if field := e.Holder.Field(index, fieldName); field != nil {
+			iter.aggBSIGroup = field.bsiGroup(fieldName)
+			iter.aggFragment = e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+		}
+	}
 
 	limit := int(^uint(0) >> 1)
 	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
@@ -1336,27 +2536,73 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 
 	// views contains the list of views to inspect (and merge)
 	// in order to represent `Rows` for the field.
-	var views = []string{viewStandard}
+	views, err := rowsViews(f, c)
+	if err != nil {
+		return nil, err
+	}
 
-	// Handle `time` fields.
-	if f.Type() == FieldTypeTime {
-		var err error
+	start := uint64(0)
+	if previous, ok, err := c.UintArg("previous"); err != nil {
+		return nil, errors.Wrap(err, "getting previous")
+	} else if ok {
+		start = previous + 1
+	}
 
-		// Parse "from" time, if set.
-		var fromTime time.Time
-		if v, ok := c.Args["from"]; ok {
-			if fromTime, err = parseTime(v); err != nil {
-				return nil, errors.Wrap(err, "parsing from time")
-			}
+	filters := []rowFilter{}
+	if columnID, ok, err := c.UintArg("column"); err != nil {
+		return nil, err
+	} else if ok {
+		colShard := columnID >> shardwidth.Exponent
+		if colShard != shard {
+			return rowIDs, nil
 		}
+		filters = append(filters, filterColumn(columnID))
+	}
 
-		// Parse "to" time, if set.
-		var toTime time.Time
-		if v, ok := c.Args["to"]; ok {
-			if toTime, err = parseTime(v); err != nil {
-				return nil, errors.Wrap(err, "parsing to time")
-			}
-		}
+	limit := int(^uint(0) >> 1)
+	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
+		return nil, errors.Wrap(err, "getting limit")
+	} else if hasLimit {
+		filters = append(filters, filterWithLimit(lim))
+		limit = int(lim)
+	}
+
+	for _, view := range views {
+		frag := e.Holder.fragment(index, fieldName, view, shard)
+		if frag == nil {
+			continue
+		}
+
+		viewRows := frag.rows(start, filters...)
+		rowIDs = rowIDs.merge(viewRows, limit)
+	}
+
+	return rowIDs, nil
+}
+
+// rowsViews returns the views of f to traverse for a Rows() call. The
+// "from" and "to" arguments of the call select the time views of a time
+// field; no views are returned if the field has no views in the range.
+func rowsViews(f *Field, c *pql.Call) ([]string, error) {
+	// Handle `time` fields.
+	if f.Type() == FieldTypeTime {
+		var err error
+
+		// Parse "from" time, if set.
+		var fromTime time.Time
+		if v, ok := c.Args["from"]; ok {
+			if fromTime, err = parseTime(v); err != nil {
+				return nil, errors.Wrap(err, "parsing from time")
+			}
+		}
+
+		// Parse "to" time, if set.
+		var toTime time.Time
+		if v, ok := c.Args["to"]; ok {
+			if toTime, err = parseTime(v); err != nil {
+				return nil, errors.Wrap(err, "parsing to time")
+			}
+		}
 
 		// Calculate the views for a range as long as some piece of the range
 		// (from/to) are specified, or if there's no standard view to represent
@@ -1365,7 +2611,7 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 			// If no quantum exists then return an empty result set.
 			q := f.TimeQuantum()
 			if q == "" {
-				return rowIDs, nil
+				return nil, nil
 			}
 
 			// Get min/max based on existing views.
@@ -1377,13 +2623,13 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 
 			// If min/max are empty, there were no time views.
 			if min == "" || max == "" {
-				return rowIDs, nil
+				return nil, nil
 			}
 
 			// Convert min/max from string to time.Time.
 			minTime, err := timeOfView(min, false)
 			if err != nil {
-				return rowIDs, errors.Wrapf(err, "getting min time from view: %s", min)
+				return nil, errors.Wrapf(err, "getting min time from view: %s", min)
 			}
 			if fromTime.IsZero() || fromTime.Before(minTime) {
 				fromTime = minTime
@@ -1391,54 +2637,17 @@ func (e *executor) executeRowsShard(_ context.Context, index string, fieldName s
 
 			maxTime, err := timeOfView(max, true)
 			if err != nil {
-				return rowIDs, errors.Wrapf(err, "getting max time from view: %s", max)
+				return nil, errors.Wrapf(err, "getting max time from view: %s", max)
 			}
 			if toTime.IsZero() || toTime.After(maxTime) {
 				toTime = maxTime
 			}
 
 			// Determine the views based on the specified time range.
-			views = viewsByTimeRange(viewStandard, fromTime, toTime, q)
-		}
-	}
-
-	start := uint64(0)
-	if previous, ok, err := c.UintArg("previous"); err != nil {
-		return nil, errors.Wrap(err, "getting previous")
-	} else if ok {
-		start = previous + 1
-	}
-
-	filters := []rowFilter{}
-	if columnID, ok, err := c.UintArg("column"); err != nil {
-		return nil, err
-	} else if ok {
-		colShard := columnID >> shardwidth.Exponent
-		if colShard != shard {
-			return rowIDs, nil
-		}
-		filters = append(filters, filterColumn(columnID))
-	}
-
-	limit := int(^uint(0) >> 1)
-	if lim, hasLimit, err := c.UintArg("limit"); err != nil {
-		return nil, errors.Wrap(err, "getting limit")
-	} else if hasLimit {
-		filters = append(filters, filterWithLimit(lim))
-		limit = int(lim)
-	}
-
-	for _, view := range views {
-		frag := e.Holder.fragment(index, fieldName, view, shard)
-		if frag == nil {
-			continue
+			return f.viewsByTimeRange(fromTime, toTime, q, time.Now()), nil
 		}
-
-		viewRows := frag.rows(start, filters...)
-		rowIDs = rowIDs.merge(viewRows, limit)
 	}
-
-	return rowIDs, nil
+	return []string{viewStandard}, nil
 }
 
 func (e *executor) executeRowShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
@@ -1512,7 +2721,7 @@ func (e *executor) executeRowShard(ctx context.Context, index string, c *pql.Cal
 	}
 
 	// Union bitmaps across all time-based views.
-	views := viewsByTimeRange(viewStandard, fromTime, toTime, q)
+	views := f.viewsByTimeRange(fromTime, toTime, q, time.Now())
 	rows := make([]*Row, 0, len(views))
 	for _, view := range views {
 		f := e.Holder.fragment(index, fieldName, view, shard)
@@ -1560,6 +2769,24 @@ func (e *executor) executeRowBSIGroupShard(ctx context.Context, index string, c
 		return nil, ErrFieldNotFound
 	}
 
+	// Convert decimal values to their scaled representation.
+	if f.Type() == FieldTypeDecimal {
+		var err error
+		if cond, err = decimalCondition(cond, f.Options().Scale); err != nil {
+			return nil, errors.Wrap(err, "converting decimal condition")
+		}
+	}
+
+	// Convert timestamp values to the field's unit.
+	if f.Type() == FieldTypeTimestamp {
+		var err error
+		if cond, err = timestampCondition(cond, f.Options().TimeUnit); err != nil {
+			return nil, errors.Wrap(err, "converting timestamp condition")
+		} else if cond == nil {
+			return NewRow(), nil
+		}
+	}
+
 	// EQ null           (not implemented: flip frag.NotNull with max ColumnID)
 	// NEQ null          frag.NotNull()
 	// BETWEEN a,b(in)   BETWEEN/frag.RowBetween()
@@ -1760,68 +2987,1143 @@ func (e *executor) executeNotShard(ctx context.Context, index string, c *pql.Cal
 		existenceRow = existenceFrag.row(0)
 	}
 
-	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+	if err != nil {
+		return nil, err
+	}
+
+	return existenceRow.Difference(row), nil
+}
+
+// executeShiftShard executes a shift() call for a local shard.
+func (e *executor) executeShiftShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
+	n, _, err := c.IntArg("n")
+	if err != nil {
+		return nil, fmt.Errorf("executeShiftShard: %v", err)
+	}
+
+	if len(c.Children) == 0 {
+		return nil, errors.New("Shift() requires an input row")
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("Shift() only accepts a single row input")
+	}
+
+	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+	if err != nil {
+		return nil, err
+	}
+
+	return row.Shift(n)
+}
+
+// executeJoins executes the input row of each Join() call within c against
+// its foreign index. The input is replaced by a roaring bitmap of the
+// resulting columns so that the call can be distributed to the nodes which
+// own the outer shards.
+func (e *executor) executeJoins(ctx context.Context, index string, c *pql.Call, opt *execOptions) error {
+	if c.Name != "Join" {
+		for _, child := range c.Children {
+			if err := e.executeJoins(ctx, index, child, opt); err != nil {
+				return err
+			}
+		}
+		for _, arg := range c.Args {
+			if call, ok := arg.(*pql.Call); ok {
+				if err := e.executeJoins(ctx, index, call, opt); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeJoins")
+	defer span.Finish()
+
+	foreignIndex, err := e.joinIndex(index, c)
+	if err != nil {
+		return err
+	}
+
+	// The input has already been executed.
+	if len(c.Children) == 0 {
+		return nil
+	}
+
+	// The input may itself contain joins relative to the foreign index.
+	child := c.Children[0]
+	if err := e.executeJoins(ctx, foreignIndex, child, opt); err != nil {
+		return err
+	}
+
+	shards := e.Holder.Index(foreignIndex).AvailableShards().Slice()
+	if len(shards) == 0 {
+		shards = []uint64{0}
+	}
+	row, err := e.executeBitmapCall(ctx, foreignIndex, child, shards, opt)
+	if err != nil {
+		return errors.Wrap(err, "executing join input")
+	}
+
+	bm := roaring.NewBitmap()
+	for _, seg := range row.Segments() {
+		bm.UnionInPlace(seg.data)
+	}
+	var buf bytes.Buffer
+	if _, err := bm.WriteTo(&buf); err != nil {
+		return errors.Wrap(err, "encoding join input")
+	}
+
+	c.Children = nil
+	c.Args["index"] = foreignIndex
+	c.Args["columns"] = base64.StdEncoding.EncodeToString(buf.Bytes())
+	return nil
+}
+
+// joinIndex returns the name of the foreign index of a Join() call. It
+// defaults to the foreign index of the join field, if it has one.
+func (e *executor) joinIndex(index string, c *pql.Call) (string, error) {
+	fieldName, _ := c.Args["field"].(string)
+	if fieldName == "" {
+		return "", errors.New("Join(): field required")
+	}
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return "", ErrFieldNotFound
+	} else if f.Type() != FieldTypeInt {
+		return "", errors.Errorf("Join(): field %q must be an int field", fieldName)
+	}
+
+	foreignIndex, _ := c.Args["index"].(string)
+	if fi := f.foreignIndex(); fi != "" {
+		if foreignIndex == "" {
+			foreignIndex = fi
+		} else if foreignIndex != fi {
+			return "", errors.Errorf("Join(): field %q references index %q", fieldName, fi)
+		}
+	}
+	if foreignIndex == "" {
+		return "", errors.New("Join(): index required")
+	} else if e.Holder.Index(foreignIndex) == nil {
+		return "", ErrIndexNotFound
+	}
+	return foreignIndex, nil
+}
+
+// executeJoinShard executes a Join() call for a single shard. It returns the
+// columns whose field value is one of the columns of the executed input.
+func (e *executor) executeJoinShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
+	span, _ := tracing.StartSpanFromContext(ctx, "Executor.executeJoinShard")
+	defer span.Finish()
+
+	if len(c.Children) > 0 {
+		return nil, errors.New("Join() input must be executed against the foreign index")
+	}
+
+	fieldName, _ := c.Args["field"].(string)
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, ErrFieldNotFound
+	}
+	bsig := f.bsiGroup(fieldName)
+	if bsig == nil {
+		return nil, ErrBSIGroupNotFound
+	}
+
+	encoded, _ := c.Args["columns"].(string)
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding join input")
+	}
+	columns := roaring.NewBitmap()
+	if len(data) > 0 {
+		if err := columns.UnmarshalBinary(data); err != nil {
+			return nil, errors.Wrap(err, "unmarshaling join input")
+		}
+	}
+
+	// Ignore column IDs which cannot be stored in the field.
+	var predicates []int64
+	itr := columns.Iterator()
+	for id, eof := itr.Next(); !eof; id, eof = itr.Next() {
+		if id > math.MaxInt64 {
+			break
+		}
+		if v, outOfRange := bsig.baseValue(pql.EQ, int64(id)); !outOfRange {
+			predicates = append(predicates, v)
+		}
+	}
+
+	frag := e.Holder.fragment(index, fieldName, viewBSIGroupPrefix+fieldName, shard)
+	if frag == nil || len(predicates) == 0 {
+		return NewRow(), nil
+	}
+	return frag.rangeIn(bsig.BitDepth, predicates)
+}
+
+// executeCount executes a count() call.
+func (e *executor) executeCount(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeCount")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return 0, errors.New("Count() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return 0, errors.New("Count() only accepts a single bitmap input")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return 0, err
+		}
+		return row.Count(), nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(uint64)
+		return other + v.(uint64)
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := result.(uint64)
+
+	return n, nil
+}
+
+// executeCountApprox executes a CountApprox() call, which estimates the
+// count of the input bitmap. Shards hold disjoint columns, so each shard's
+// count is summed like Count(), and only unions of cached rows are estimated
+// from HyperLogLog sketches, since they are cheaper to merge than the rows.
+func (e *executor) executeCountApprox(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeCountApprox")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return 0, errors.New("CountApprox() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return 0, errors.New("CountApprox() only accepts a single bitmap input")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		return e.executeCountApproxShard(ctx, index, c.Children[0], shard)
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(uint64)
+		return other + v.(uint64)
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := result.(uint64)
+
+	return n, nil
+}
+
+// executeCountApproxShard estimates the count of a bitmap call for a single
+// shard. A Union() call is estimated from the sketches of its rows if every
+// row can be sketched, and anything else is counted exactly.
+func (e *executor) executeCountApproxShard(ctx context.Context, index string, c *pql.Call, shard uint64) (uint64, error) {
+	if c.Name == "Union" {
+		hll := newHyperLogLog()
+		if ok, err := e.mergeSketchShard(index, c, shard, hll); err != nil {
+			return 0, err
+		} else if ok {
+			return hll.count(), nil
+		}
+	}
+
+	row, err := e.executeBitmapCallShard(ctx, index, c, shard)
+	if err != nil {
+		return 0, err
+	}
+	return row.Count(), nil
+}
+
+// mergeSketchShard merges the HyperLogLog sketch of a Union() or Row() call
+// for a single shard into hll. It returns false if any row is not in the
+// count cache of its fragment, or if the call contains any other call.
+func (e *executor) mergeSketchShard(index string, c *pql.Call, shard uint64, hll hyperLogLog) (bool, error) {
+	switch c.Name {
+	case "Union":
+		for _, child := range c.Children {
+			if ok, err := e.mergeSketchShard(index, child, shard, hll); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case "Row":
+		views, ok, err := e.sketchViews(index, c)
+		if err != nil || !ok {
+			return false, err
+		}
+		fieldName, _ := c.FieldArg()
+		rowID, _, _ := c.UintArg(fieldName)
+
+		for _, view := range views {
+			if frag := e.Holder.fragment(index, fieldName, view, shard); frag != nil && !frag.mergeRowSketch(rowID, hll) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// sketchViews returns the views of a Row() call which can be sketched by
+// their fragments. It returns false if the call must be computed as a row.
+func (e *executor) sketchViews(index string, c *pql.Call) ([]string, bool, error) {
+	if c.HasConditionArg() {
+		return nil, false, nil
+	}
+	fieldName, err := c.FieldArg()
+	if err != nil {
+		return nil, false, nil
+	}
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return nil, false, ErrFieldNotFound
+	} else if _, ok, err := c.UintArg(fieldName); err != nil || !ok {
+		return nil, false, nil
+	}
+
+	_, hasFrom := c.Args["from"]
+	_, hasTo := c.Args["to"]
+	if !hasFrom && !hasTo {
+		return []string{viewStandard}, true, nil
+	} else if f.Type() != FieldTypeTime {
+		return nil, false, nil
+	}
+	views, err := rowsViews(f, c)
+	return views, err == nil, err
+}
+
+// executeSample executes a Sample() call. The input bitmap is first counted
+// on each shard, and then the coordinating node assigns each shard a number
+// of columns to sample in proportion to its count. The call is sent again
+// with the "shards" and "quotas" args so that each shard samples its quota.
+// Remote nodes return the shard counts as pairs in the first pass.
+func (e *executor) executeSample(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (interface{}, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSample")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return nil, errors.New("Sample() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("Sample() only accepts a single bitmap input")
+	}
+	n, ok, err := c.UintArg("n")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading n")
+	} else if !ok || n == 0 {
+		return nil, errors.New("Sample() requires n greater than zero")
+	}
+	seed, ok, err := c.IntArg("seed")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading seed")
+	} else if !ok {
+		seed = rand.Int63()
+	}
+
+	if _, ok := c.Args["quotas"]; ok {
+		return e.executeSampleShards(ctx, index, c, shards, opt)
+	}
+
+	// Count the input bitmap on each shard.
+	mapFn := func(shard uint64) (interface{}, error) {
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		return []Pair{{ID: shard, Count: row.Count()}}, nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.([]Pair)
+		return Pairs(other).Add(v.([]Pair))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, errors.Wrap(err, "counting shards")
+	}
+	counts, _ := result.([]Pair)
+
+	if opt.Remote {
+		return counts, nil
+	}
+
+	// Sample only the shards with a non-zero quota.
+	quotas := sampleQuotas(counts, n, rand.New(rand.NewSource(seed)))
+	other := c.Clone()
+	other.Args["seed"] = seed
+	var sampleShards, sampleQuotas []uint64
+	for _, shard := range shards {
+		if q := quotas[shard]; q > 0 {
+			sampleShards = append(sampleShards, shard)
+			sampleQuotas = append(sampleQuotas, q)
+		}
+	}
+	if len(sampleShards) == 0 {
+		return NewRow(), nil
+	}
+	other.Args["shards"] = sampleShards
+	other.Args["quotas"] = sampleQuotas
+
+	return e.executeSampleShards(ctx, index, other, sampleShards, opt)
+}
+
+// executeSampleShards samples the number of columns given by the "quotas"
+// arg from each shard in the "shards" arg of a Sample() call.
+func (e *executor) executeSampleShards(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (*Row, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSampleShards")
+	defer span.Finish()
+
+	seed, _, err := c.IntArg("seed")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading seed")
+	}
+	quotaShards, _, err := c.UintSliceArg("shards")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading shards")
+	}
+	quotaN, _, err := c.UintSliceArg("quotas")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading quotas")
+	} else if len(quotaN) != len(quotaShards) {
+		return nil, errors.New("Sample() shards and quotas must have the same length")
+	}
+	quotas := make(map[uint64]uint64, len(quotaShards))
+	for i, shard := range quotaShards {
+		quotas[shard] = quotaN[i]
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		if quotas[shard] == 0 {
+			return NewRow(), nil
+		}
+		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+
+		// Seed each shard differently, but the same way on every node.
+		rng := rand.New(rand.NewSource(seed ^ int64(sketchHash(shard))))
+		return sampleRow(row, quotas[shard], rng), nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(*Row)
+		if other == nil {
+			other = NewRow()
+		}
+		other.Merge(v.(*Row))
+		return other
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, errors.Wrap(err, "sampling shards")
+	}
+	row, _ := result.(*Row)
+	if row == nil {
+		row = NewRow()
+	}
+	return row, nil
+}
+
+// sampleQuotas returns the number of columns to sample from each shard,
+// given the count of each shard, so that n columns are sampled in total.
+// Each shard receives its proportional share rounded down, and the remaining
+// columns are assigned by systematic sampling over the fractional parts so
+// that every column has the same probability of being sampled. If n is not
+// less than the total count then every column is sampled.
+func sampleQuotas(counts []Pair, n uint64, rng *rand.Rand) map[uint64]uint64 {
+	sort.Slice(counts, func(i, j int) bool { return counts[i].ID < counts[j].ID })
+
+	var total uint64
+	for _, p := range counts {
+		total += p.Count
+	}
+
+	quotas := make(map[uint64]uint64, len(counts))
+	if n >= total {
+		for _, p := range counts {
+			quotas[p.ID] = p.Count
+		}
+		return quotas
+	}
+
+	// Fractional parts are in units of 1/total, and the systematic sample
+	// selects every total units starting at a random offset.
+	offset := uint64(rng.Int63n(int64(total)))
+	var cum uint64
+	for _, p := range counts {
+		hi, lo := bits.Mul64(n, p.Count)
+		q, rem := bits.Div64(hi, lo, total)
+
+		// Add one if a point offset+k*total lies in [cum, cum+rem).
+		start := cum
+		cum += rem
+		if (cum+total-offset-1)/total > (start+total-offset-1)/total {
+			q++
+		}
+		quotas[p.ID] = q
+	}
+	return quotas
+}
+
+// sampleRow returns k columns of row chosen uniformly at random, using
+// Floyd's algorithm to pick their positions.
+func sampleRow(row *Row, k uint64, rng *rand.Rand) *Row {
+	columns := row.Columns()
+	m := uint64(len(columns))
+	if k >= m {
+		return row
+	}
+
+	selected := make(map[uint64]struct{}, k)
+	for j := m - k; j < m; j++ {
+		t := uint64(rng.Int63n(int64(j + 1)))
+		if _, ok := selected[t]; ok {
+			t = j
+		}
+		selected[t] = struct{}{}
+	}
+
+	positions := make([]uint64, 0, k)
+	for pos := range selected {
+		positions = append(positions, pos)
+	}
+	sort.Sort(uint64Slice(positions))
+
+	other := NewRow()
+	for _, pos := range positions {
+		other.SetBit(columns[pos])
+	}
+	return other
+}
+
+// executeCountSeries executes a CountSeries() call. The input bitmap is
+// counted once for each interval between from and to, with every Row() call
+// on a time field restricted to that interval.
+func (e *executor) executeCountSeries(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (CountSeries, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeCountSeries")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return nil, errors.New("CountSeries() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return nil, errors.New("CountSeries() only accepts a single bitmap input")
+	}
+
+	buckets, calls, err := e.countSeriesBuckets(index, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "CountSeries()")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		series := make(CountSeries, len(buckets))
+		for i := range buckets {
+			row, err := e.executeBitmapCallShard(ctx, index, calls[i], shard)
+			if err != nil {
+				return nil, err
+			}
+			series[i] = SeriesCount{Time: buckets[i], Count: row.Count()}
+		}
+		return series, nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(CountSeries)
+		return other.add(v.(CountSeries))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	series, _ := result.(CountSeries)
+
+	// Return a zero count for every bucket if there were no shards.
+	if series == nil {
+		series = make(CountSeries, len(buckets))
+		for i := range buckets {
+			series[i].Time = buckets[i]
+		}
+	}
+	return series, nil
+}
+
+// countSeriesBuckets returns the start time of each interval of a
+// CountSeries() call, along with a copy of the input bitmap call for each
+// interval in which the time range of each Row() call on a time field is
+// set to the interval.
+func (e *executor) countSeriesBuckets(index string, c *pql.Call) ([]time.Time, []*pql.Call, error) {
+	interval, ok := c.Args["interval"].(string)
+	if !ok || len(interval) != 1 || !strings.Contains("YMWDH", interval) {
+		return nil, nil, errors.New("interval must be one of Y, M, W, D or H")
+	}
+	unit := rune(interval[0])
+
+	// Both ends of the range are required so that every node computes
+	// the same buckets.
+	var from, to time.Time
+	if v, ok := c.Args["from"]; !ok {
+		return nil, nil, errors.New("from required")
+	} else if t, err := parseTime(v); err != nil {
+		return nil, nil, errors.Wrap(err, "parsing from time")
+	} else {
+		from = t
+	}
+	if v, ok := c.Args["to"]; !ok {
+		return nil, nil, errors.New("to required")
+	} else if t, err := parseTime(v); err != nil {
+		return nil, nil, errors.Wrap(err, "parsing to time")
+	} else {
+		to = t
+	}
+
+	if err := e.validateIntervalCall(index, c.Children[0], unit); err != nil {
+		return nil, nil, errors.Wrap(err, "input bitmap")
+	}
+
+	var buckets []time.Time
+	var calls []*pql.Call
+	for t := truncateTime(from, unit); t.Before(to); t = addTimeUnit(t, unit) {
+		if len(buckets) == maxCountSeriesBuckets {
+			return nil, nil, fmt.Errorf("too many intervals, maximum is %d", maxCountSeriesBuckets)
+		}
+		call, err := e.intervalCall(index, c.Children[0], t, addTimeUnit(t, unit))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "input bitmap")
+		}
+		buckets = append(buckets, t)
+		calls = append(calls, call)
+	}
+	return buckets, calls, nil
+}
+
+// validateIntervalCall returns an error if c does not contain a Row() call on
+// a time field, or if unit is finer than the time quantum of any such field.
+// Weeks are made up of days, so a unit of 'W' requires 'D' in the quantum.
+func (e *executor) validateIntervalCall(index string, c *pql.Call, unit rune) error {
+	rows := e.timeRowCalls(index, c)
+	if len(rows) == 0 {
+		return errors.New("must contain a Row() call on a time field")
+	}
+	quantumUnit := unit
+	if unit == 'W' {
+		quantumUnit = 'D'
+	}
+	for _, row := range rows {
+		fieldName, _ := row.FieldArg()
+		q := e.Holder.Field(index, fieldName).TimeQuantum()
+		if q == "" || strings.IndexByte("YMDH", q[len(q)-1]) < strings.IndexRune("YMDH", quantumUnit) {
+			return fmt.Errorf("interval %c is finer than the time quantum of field %q", unit, fieldName)
+		}
+	}
+	return nil
+}
+
+// intervalCall returns a copy of c in which the time range of every Row()
+// call on a time field is set to the interval from start to end. An error is
+// returned if a time field has been rolled up to a coarser unit than the
+// interval for that period, as its views would be counted in every interval
+// within the coarser view's period.
+func (e *executor) intervalCall(index string, c *pql.Call, start, end time.Time) (*pql.Call, error) {
+	other := c.Clone()
+	now := time.Now()
+	for _, row := range e.timeRowCalls(index, other) {
+		fieldName, _ := row.FieldArg()
+		f := e.Holder.Field(index, fieldName)
+		if err := f.validateTimeRange(start, end, f.TimeQuantum(), now); err != nil {
+			return nil, err
+		}
+		row.Args["from"] = start.Format(TimeFormat)
+		row.Args["to"] = end.Format(TimeFormat)
+	}
+	return other, nil
+}
+
+// executeRetention executes a Retention() call. The cohort of each period is
+// the start bitmap within that period, and is intersected with the return
+// bitmap within that period and each following period.
+func (e *executor) executeRetention(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (RetentionMatrix, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeRetention")
+	defer span.Finish()
+
+	periods, startCalls, returnCalls, err := e.retentionPeriods(index, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "Retention()")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		starts := make([]*Row, len(periods))
+		returns := make([]*Row, len(periods))
+		for i := range periods {
+			row, err := e.executeBitmapCallShard(ctx, index, startCalls[i], shard)
+			if err != nil {
+				return nil, err
+			}
+			starts[i] = row
+
+			if row, err = e.executeBitmapCallShard(ctx, index, returnCalls[i], shard); err != nil {
+				return nil, err
+			}
+			returns[i] = row
+		}
+
+		m := make(RetentionMatrix, len(periods))
+		for i := range m {
+			m[i] = RetentionCohort{
+				Time:   periods[i],
+				Size:   starts[i].Count(),
+				Counts: make([]uint64, len(periods)-i),
+			}
+			if m[i].Size == 0 {
+				continue
+			}
+			for j := range m[i].Counts {
+				m[i].Counts[j] = starts[i].intersectionCount(returns[i+j])
+			}
+		}
+		return m, nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(RetentionMatrix)
+		return other.add(v.(RetentionMatrix))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	m, _ := result.(RetentionMatrix)
+
+	// Return empty cohorts if there were no shards.
+	if m == nil {
+		m = make(RetentionMatrix, len(periods))
+		for i := range periods {
+			m[i] = RetentionCohort{Time: periods[i], Counts: make([]uint64, len(periods)-i)}
+		}
+	}
+	return m, nil
+}
+
+// retentionPeriods returns the start time of each period of a Retention()
+// call, along with copies of the start and return bitmap calls restricted
+// to each period.
+func (e *executor) retentionPeriods(index string, c *pql.Call) ([]time.Time, []*pql.Call, []*pql.Call, error) {
+	startCall, ok, err := c.CallArg("start")
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
+		return nil, nil, nil, errors.New("start required")
+	}
+	returnCall, ok, err := c.CallArg("return")
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
+		return nil, nil, nil, errors.New("return required")
+	}
+
+	interval, ok := c.Args["interval"].(string)
+	if !ok || len(interval) != 1 || !strings.Contains("YMWDH", interval) {
+		return nil, nil, nil, errors.New("interval must be one of Y, M, W, D or H")
+	}
+	unit := rune(interval[0])
+
+	n, ok, err := c.UintArg("periods")
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok || n == 0 {
+		return nil, nil, nil, errors.New("periods required")
+	} else if n > maxRetentionPeriods {
+		return nil, nil, nil, fmt.Errorf("too many periods, maximum is %d", maxRetentionPeriods)
+	}
+
+	v, ok := c.Args["from"]
+	if !ok {
+		return nil, nil, nil, errors.New("from required")
+	}
+	from, err := parseTime(v)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "parsing from time")
+	}
+
+	if err := e.validateIntervalCall(index, startCall, unit); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "start")
+	} else if err := e.validateIntervalCall(index, returnCall, unit); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "return")
+	}
+
+	periods := make([]time.Time, n)
+	startCalls := make([]*pql.Call, n)
+	returnCalls := make([]*pql.Call, n)
+	t := truncateTime(from, unit)
+	for i := range periods {
+		end := addTimeUnit(t, unit)
+		periods[i] = t
+		if startCalls[i], err = e.intervalCall(index, startCall, t, end); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "start")
+		} else if returnCalls[i], err = e.intervalCall(index, returnCall, t, end); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "return")
+		}
+		t = end
+	}
+	return periods, startCalls, returnCalls, nil
+}
+
+// executeFunnel executes a Funnel() call. Each child call is a step, and a
+// column completes a step once it has completed the previous step and then
+// matches the step within the window which began when it matched the first.
+func (e *executor) executeFunnel(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (FunnelCounts, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeFunnel")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return nil, errors.New("Funnel() requires at least one step")
+	}
+	buckets, windows, calls, err := e.funnelBuckets(index, c)
+	if err != nil {
+		return nil, errors.Wrap(err, "Funnel()")
+	}
+
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		// Retrieve the columns matching each step within each bucket.
+		rows := make([][]*Row, len(calls))
+		for i := range calls {
+			rows[i] = make([]*Row, len(buckets))
+			for j := range buckets {
+				row, err := e.executeBitmapCallShard(ctx, index, calls[i][j], shard)
+				if err != nil {
+					return nil, err
+				}
+				rows[i][j] = row
+			}
+		}
+
+		// Follow the columns which start the funnel in each bucket through
+		// the buckets of their window, in order. Ordering is only enforced
+		// between buckets: within a bucket each step is reached from the
+		// previous one regardless of when in the bucket either was set.
+		completed := make([]*Row, len(calls))
+		for i := range completed {
+			completed[i] = NewRow()
+		}
+		for start := range buckets {
+			if !rows[0][start].Any() {
+				continue
+			}
+			reached := make([]*Row, len(calls))
+			reached[0] = rows[0][start]
+			for i := 1; i < len(reached); i++ {
+				reached[i] = NewRow()
+			}
+			for j := start; j < windows[start]; j++ {
+				for i := 1; i < len(calls); i++ {
+					reached[i] = reached[i].Union(reached[i-1].Intersect(rows[i][j]))
+				}
+			}
+			for i := range completed {
+				completed[i] = completed[i].Union(reached[i])
+			}
+		}
+
+		counts := make(FunnelCounts, len(completed))
+		for i := range completed {
+			counts[i] = completed[i].Count()
+		}
+		return counts, nil
+	}
+
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(FunnelCounts)
+		return other.add(v.(FunnelCounts))
+	}
+
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return nil, err
+	}
+	counts, _ := result.(FunnelCounts)
+	if counts == nil {
+		counts = make(FunnelCounts, len(c.Children))
+	}
+	return counts, nil
+}
+
+// funnelBuckets returns the start time of each bucket between the from and
+// to times of a Funnel() call, the index of the bucket which ends the window
+// beginning at each bucket, and a copy of each step call for each bucket.
+// Buckets are the finest unit of time which every step can be evaluated at.
+func (e *executor) funnelBuckets(index string, c *pql.Call) ([]time.Time, []int, [][]*pql.Call, error) {
+	window, ok := c.Args["window"].(string)
+	if !ok || len(window) != 1 || !strings.Contains("YMWDH", window) {
+		return nil, nil, nil, errors.New("window must be one of Y, M, W, D or H")
+	}
+	windowUnit := rune(window[0])
+
+	// Both ends of the range are required so that every node computes
+	// the same buckets.
+	var from, to time.Time
+	if v, ok := c.Args["from"]; !ok {
+		return nil, nil, nil, errors.New("from required")
+	} else if t, err := parseTime(v); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "parsing from time")
+	} else {
+		from = t
+	}
+	if v, ok := c.Args["to"]; !ok {
+		return nil, nil, nil, errors.New("to required")
+	} else if t, err := parseTime(v); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "parsing to time")
+	} else {
+		to = t
+	}
+
+	// Use the coarsest of the finest units of the time fields of each step.
+	unit := 'H'
+	for i, step := range c.Children {
+		rows := e.timeRowCalls(index, step)
+		if len(rows) == 0 {
+			return nil, nil, nil, fmt.Errorf("step %d must contain a Row() call on a time field", i+1)
+		}
+		for _, row := range rows {
+			fieldName, _ := row.FieldArg()
+			q := e.Holder.Field(index, fieldName).TimeQuantum()
+			if q == "" {
+				return nil, nil, nil, fmt.Errorf("field %q has no time quantum", fieldName)
+			} else if finest := rune(q[len(q)-1]); strings.IndexRune("YMDH", finest) < strings.IndexRune("YMDH", unit) {
+				unit = finest
+			}
+		}
+	}
+	if strings.IndexRune("YMWDH", windowUnit) >= strings.IndexRune("YMWDH", unit) {
+		return nil, nil, nil, fmt.Errorf("window %c must be coarser than the time quantum unit %c", windowUnit, unit)
+	}
+
+	var buckets []time.Time
+	for t := truncateTime(from, unit); t.Before(to); t = addTimeUnit(t, unit) {
+		if len(buckets) == maxFunnelBuckets {
+			return nil, nil, nil, fmt.Errorf("too many %c units between from and to, maximum is %d", unit, maxFunnelBuckets)
+		}
+		buckets = append(buckets, t)
+	}
+
+	// Find the end of the window beginning at each bucket.
+	windows := make([]int, len(buckets))
+	for i := range buckets {
+		end := addTimeUnit(buckets[i], windowUnit)
+		j := i
+		for j < len(buckets) && buckets[j].Before(end) {
+			j++
+		}
+		windows[i] = j
+	}
+
+	calls := make([][]*pql.Call, len(c.Children))
+	for i, step := range c.Children {
+		calls[i] = make([]*pql.Call, len(buckets))
+		for j, t := range buckets {
+			call, err := e.intervalCall(index, step, t, addTimeUnit(t, unit))
+			if err != nil {
+				return nil, nil, nil, errors.Wrapf(err, "step %d", i+1)
+			}
+			calls[i][j] = call
+		}
+	}
+	return buckets, windows, calls, nil
+}
+
+// timeRowCalls returns the Row() calls within c which select a row of a
+// time field.
+func (e *executor) timeRowCalls(index string, c *pql.Call) []*pql.Call {
+	var calls []*pql.Call
+	if c.Name == "Row" && !c.HasConditionArg() {
+		if fieldName, err := c.FieldArg(); err == nil {
+			if f := e.Holder.Field(index, fieldName); f != nil && f.Type() == FieldTypeTime {
+				calls = append(calls, c)
+			}
+		}
+	}
+	for _, child := range c.Children {
+		calls = append(calls, e.timeRowCalls(index, child)...)
+	}
+	return calls
+}
+
+// executeSimilar executes a Similar() call, which scores rows of a field by
+// their similarity to the input bitmap. In the first pass, each shard
+// returns the count of the input bitmap and of its intersection with each row
+// which intersects it. The call is then sent again with the candidate rows
+// as an "ids" arg so that each shard returns their counts, and rows are
+// scored using their exact totals. Remote nodes return the unscored counts.
+func (e *executor) executeSimilar(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (SimilarRows, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeSimilar")
+	defer span.Finish()
+
+	if len(c.Children) == 0 {
+		return SimilarRows{}, errors.New("Similar() requires an input bitmap")
+	} else if len(c.Children) > 1 {
+		return SimilarRows{}, errors.New("Similar() only accepts a single bitmap input")
+	}
+	n, _, err := c.UintArg("n")
+	if err != nil {
+		return SimilarRows{}, errors.Wrap(err, "reading n")
+	}
+	metric, err := similarityMetric(c)
+	if err != nil {
+		return SimilarRows{}, err
+	}
+
+	rows, err := e.executeSimilarShards(ctx, index, c, shards, opt)
 	if err != nil {
-		return nil, err
+		return SimilarRows{}, err
+	} else if _, ok := c.Args["ids"]; ok || opt.Remote {
+		return rows, nil
 	}
 
-	return existenceRow.Difference(row), nil
+	// Only the original caller should fetch the full counts.
+	if len(rows.Rows) > 0 {
+		other := c.Clone()
+		ids := make([]uint64, len(rows.Rows))
+		for i, r := range rows.Rows {
+			ids[i] = r.ID
+		}
+		other.Args["ids"] = ids
+
+		counts, err := e.executeSimilarShards(ctx, index, other, shards, opt)
+		if err != nil {
+			return SimilarRows{}, errors.Wrap(err, "retrieving full counts")
+		}
+		rows = rows.add(counts)
+	}
+	return rows.top(metric, int(n)), nil
 }
 
-// executeShiftShard executes a shift() call for a local shard.
-func (e *executor) executeShiftShard(ctx context.Context, index string, c *pql.Call, shard uint64) (*Row, error) {
-	n, _, err := c.IntArg("n")
+// executeSimilarShards executes a pass of a Similar() call on each shard.
+// Without an "ids" arg, each shard returns the rows which intersect the
+// input bitmap, without their counts. With one, each shard returns the
+// counts of those rows, without reading the input bitmap.
+func (e *executor) executeSimilarShards(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (SimilarRows, error) {
+	fieldName, _ := c.Args["field"].(string)
+	if fieldName == "" {
+		return SimilarRows{}, errors.New("Similar() field required")
+	}
+	f := e.Holder.Field(index, fieldName)
+	if f == nil {
+		return SimilarRows{}, ErrFieldNotFound
+	}
+	switch f.Type() {
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
+		return SimilarRows{}, fmt.Errorf("cannot compute Similar() on integer field: %q", fieldName)
+	}
+	ids, hasIDs, err := c.UintSliceArg("ids")
 	if err != nil {
-		return nil, fmt.Errorf("executeShiftShard: %v", err)
+		return SimilarRows{}, errors.Wrap(err, "reading ids")
+	}
+	views, err := rowsViews(f, c)
+	if err != nil {
+		return SimilarRows{}, errors.Wrap(err, "getting views")
 	}
 
-	if len(c.Children) == 0 {
-		return nil, errors.New("Shift() requires an input row")
-	} else if len(c.Children) > 1 {
-		return nil, errors.New("Shift() only accepts a single row input")
+	// Execute calls in bulk on each remote node and merge.
+	mapFn := func(shard uint64) (interface{}, error) {
+		var frags []*fragment
+		for _, view := range views {
+			if frag := e.Holder.fragment(index, fieldName, view, shard); frag != nil {
+				frags = append(frags, frag)
+			}
+		}
+		if hasIDs {
+			return SimilarRows{Rows: countFragments(frags, ids)}, nil
+		}
+
+		src, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		if err != nil {
+			return nil, err
+		}
+		return SimilarRows{Count: src.Count(), Rows: similarFragments(frags, src)}, nil
 	}
 
-	row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
-	if err != nil {
-		return nil, err
+	// Merge returned results at coordinating node.
+	reduceFn := func(prev, v interface{}) interface{} {
+		other, _ := prev.(SimilarRows)
+		return other.add(v.(SimilarRows))
 	}
 
-	return row.Shift(n)
+	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
+	if err != nil {
+		return SimilarRows{}, err
+	}
+	rows, _ := result.(SimilarRows)
+	return rows, nil
 }
 
-// executeCount executes a count() call.
-func (e *executor) executeCount(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (uint64, error) {
-	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeCount")
+// executeOverlap executes an Overlap() call, which counts two bitmaps along
+// with their intersection and union in a single pass.
+func (e *executor) executeOverlap(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (Overlap, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeOverlap")
 	defer span.Finish()
 
-	if len(c.Children) == 0 {
-		return 0, errors.New("Count() requires an input bitmap")
-	} else if len(c.Children) > 1 {
-		return 0, errors.New("Count() only accepts a single bitmap input")
+	if len(c.Children) != 2 {
+		return Overlap{}, errors.New("Overlap() requires two input bitmaps")
+	}
+	metric, err := similarityMetric(c)
+	if err != nil {
+		return Overlap{}, err
 	}
 
 	// Execute calls in bulk on each remote node and merge.
 	mapFn := func(shard uint64) (interface{}, error) {
-		row, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
+		a, err := e.executeBitmapCallShard(ctx, index, c.Children[0], shard)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		return row.Count(), nil
+		b, err := e.executeBitmapCallShard(ctx, index, c.Children[1], shard)
+		if err != nil {
+			return nil, err
+		}
+		na, nb, n := a.Count(), b.Count(), a.intersectionCount(b)
+		return Overlap{Counts: [2]uint64{na, nb}, Intersection: n, Union: na + nb - n}, nil
 	}
 
 	// Merge returned results at coordinating node.
 	reduceFn := func(prev, v interface{}) interface{} {
-		other, _ := prev.(uint64)
-		return other + v.(uint64)
+		other, _ := prev.(Overlap)
+		return other.add(v.(Overlap))
 	}
 
 	result, err := e.mapReduce(ctx, index, shards, c, opt, mapFn, reduceFn)
 	if err != nil {
-		return 0, err
+		return Overlap{}, err
 	}
-	n, _ := result.(uint64)
+	overlap, _ := result.(Overlap)
+	overlap.Similarity = similarity(metric, overlap.Counts[0], overlap.Counts[1], overlap.Intersection)
 
-	return n, nil
+	return overlap, nil
 }
 
 // executeClearBit executes a Clear() call.
@@ -1962,6 +4264,8 @@ func (e *executor) executeClearRowShard(ctx context.Context, index string, c *pq
 	}
 
 	// Remove the row from all views.
+	field.rollupMu.RLock()
+	defer field.rollupMu.RUnlock()
 	changed := false
 	for _, view := range field.views() {
 		fragment := e.Holder.fragment(index, fieldName, view.name, shard)
@@ -1978,6 +4282,113 @@ func (e *executor) executeClearRowShard(ctx context.Context, index string, c *pq
 	return changed, nil
 }
 
+// executeDelete executes a Delete() call. The coordinating node determines
+// the columns to delete and then sends them to every node as an "ids" arg,
+// since replicas and column attributes may be stored on any node.
+func (e *executor) executeDelete(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "Executor.executeDelete")
+	defer span.Finish()
+
+	idx := e.Holder.Index(index)
+	if idx == nil {
+		return 0, ErrIndexNotFound
+	}
+
+	ids, ok, err := c.UintSliceArg("ids")
+	if err != nil {
+		return 0, errors.Wrap(err, "reading ids")
+	} else if !ok {
+		if len(c.Children) == 0 {
+			return 0, errors.New("Delete() requires an input row")
+		} else if len(c.Children) > 1 {
+			return 0, errors.New("Delete() only accepts a single row input")
+		}
+
+		row, err := e.executeBitmapCall(ctx, index, c.Children[0], shards, opt)
+		if err != nil {
+			return 0, errors.Wrap(err, "finding columns")
+		}
+		ids = row.Columns()
+		c = &pql.Call{Name: "Delete", Args: map[string]interface{}{"ids": ids}}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := e.deleteColumns(idx, ids); err != nil {
+		return 0, errors.Wrap(err, "deleting columns")
+	}
+
+	// Do not forward call if this is already being forwarded.
+	if opt.Remote {
+		return uint64(len(ids)), nil
+	}
+
+	// Execute on remote nodes in parallel.
+	nodes := Nodes(e.Cluster.nodes).FilterID(e.Node.ID)
+	resp := make(chan error, len(nodes))
+	for _, node := range nodes {
+		go func(node *Node) {
+			_, err := e.remoteExec(ctx, node, index, &pql.Query{Calls: []*pql.Call{c}}, nil)
+			resp <- err
+		}(node)
+	}
+
+	// Return first error.
+	for range nodes {
+		if err := <-resp; err != nil {
+			return 0, err
+		}
+	}
+
+	return uint64(len(ids)), nil
+}
+
+// deleteColumns clears columns from every local fragment of an index,
+// including the existence field, and removes their column attributes.
+func (e *executor) deleteColumns(idx *Index, ids []uint64) error {
+	columns := NewRow(ids...)
+
+	for _, field := range idx.Fields() {
+		field.rollupMu.RLock()
+		for _, view := range field.views() {
+			for _, frag := range view.allFragments() {
+				if columns.segment(frag.shard) == nil {
+					continue
+				}
+				if _, err := frag.clearColumns(columns); err != nil {
+					field.rollupMu.RUnlock()
+					return errors.Wrapf(err, "clearing columns on field %s view %s shard %d", field.Name(), view.name, frag.shard)
+				}
+			}
+		}
+		field.rollupMu.RUnlock()
+	}
+
+	// Remove column attributes by setting each existing key to nil.
+	store := idx.ColumnAttrStore()
+	for _, id := range ids {
+		attrs, err := store.Attrs(id)
+		if err != nil {
+			return errors.Wrap(err, "reading column attrs")
+		} else if len(attrs) == 0 {
+			continue
+		}
+
+		m := make(map[string]interface{}, len(attrs))
+		for k := range attrs {
+			m[k] = nil
+		}
+		if err := store.SetAttrs(id, m); err != nil {
+			return errors.Wrap(err, "clearing column attrs")
+		} else if err := idx.logColumnAttrs(id, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // executeSetRow executes a Store() call.
 func (e *executor) executeSetRow(ctx context.Context, index string, c *pql.Call, shards []uint64, opt *execOptions) (bool, error) {
 	// Ensure the field type supports Store().
@@ -2115,6 +4526,36 @@ func (e *executor) executeSet(ctx context.Context, index string, c *pql.Call, op
 		return e.executeSetValueField(ctx, index, c, f, colID, rowVal, opt)
 	}
 
+	// Timestamp field.
+	if f.Type() == FieldTypeTimestamp {
+		// Read row value and convert it to the field's unit.
+		v, ok := c.Args[fieldName]
+		if !ok {
+			return false, fmt.Errorf("Set() row argument '%v' required", rowLabel)
+		}
+		rowVal, _, err := timestampArgValue(v, f.Options().TimeUnit, decimalRoundFloor)
+		if err != nil {
+			return false, fmt.Errorf("reading Set() row: %v", err)
+		}
+
+		return e.executeSetValueField(ctx, index, c, f, colID, rowVal, opt)
+	}
+
+	// Decimal field.
+	if f.Type() == FieldTypeDecimal {
+		// Read row value and convert it to its scaled representation.
+		v, ok := c.Args[fieldName]
+		if !ok {
+			return false, fmt.Errorf("Set() row argument '%v' required", rowLabel)
+		}
+		rowVal, err := decimalValue(v, f.Options().Scale, decimalRoundNearest)
+		if err != nil {
+			return false, fmt.Errorf("reading Set() row: %v", err)
+		}
+
+		return e.executeSetValueField(ctx, index, c, f, colID, rowVal, opt)
+	}
+
 	// Read row ID.
 	rowID, ok, err := c.UintArg(fieldName)
 	if err != nil {
@@ -2238,6 +4679,8 @@ func (e *executor) executeSetRowAttrs(ctx context.Context, index string, c *pql.
 	// Set attributes.
 	if err := field.RowAttrStore().SetAttrs(rowID, attrs); err != nil {
 		return err
+	} else if err := field.logRowAttrs(map[uint64]map[string]interface{}{rowID: attrs}); err != nil {
+		return err
 	}
 	field.Stats.Count("SetRowAttrs", 1, 1.0)
 
@@ -2332,6 +4775,8 @@ func (e *executor) executeBulkSetRowAttrs(ctx context.Context, index string, cal
 		// Set attributes.
 		if err := field.RowAttrStore().SetBulkAttrs(fieldMap); err != nil {
 			return nil, err
+		} else if err := field.logRowAttrs(fieldMap); err != nil {
+			return nil, err
 		}
 		field.Stats.Count("SetRowAttrs", 1, 1.0)
 	}
@@ -2386,6 +4831,8 @@ func (e *executor) executeSetColumnAttrs(ctx context.Context, index string, c *p
 	// Set attributes.
 	if err := idx.ColumnAttrStore().SetAttrs(col, attrs); err != nil {
 		return err
+	} else if err := idx.logColumnAttrs(col, attrs); err != nil {
+		return err
 	}
 	idx.Stats.Count("SetProfileAttrs", 1, 1.0)
 	// Do not forward call if this is already being forwarded.
@@ -2638,8 +5085,55 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 		fieldName = callArgString(c, "_field")
 		rowKey = "previous"
 		colKey = "column"
+	case "Options":
+		// The page cursor is a column.
+		colKey = "after"
 	case "GroupBy":
 		return errors.Wrap(e.translateGroupByCall(index, idx, c), "translating GroupBy")
+	case "Join":
+		// The input row is translated against the foreign index.
+		foreignIndex, err := e.joinIndex(index, c)
+		if err != nil {
+			return err
+		} else if len(c.Children) == 0 {
+			return errors.New("Join() requires an input row")
+		} else if len(c.Children) > 1 {
+			return errors.New("Join() only accepts a single row input")
+		}
+		fidx := e.Holder.Index(foreignIndex)
+		for _, child := range c.Children {
+			if err := e.translateCall(foreignIndex, fidx, child); err != nil {
+				return errors.Wrap(err, "translating join input")
+			}
+		}
+		return nil
+	case "Percentile":
+		if filter, ok, err := c.CallArg("filter"); err != nil {
+			return errors.Wrap(err, "getting filter call")
+		} else if ok {
+			return errors.Wrap(e.translateCall(index, idx, filter), "translating filter call")
+		}
+		return nil
+	case "Similar":
+		// The field arg names the rows to score rather than a row, so
+		// only the input bitmap is translated.
+		for _, child := range c.Children {
+			if err := e.translateCall(index, idx, child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "Retention":
+		for _, key := range []string{"start", "return"} {
+			if call, ok, err := c.CallArg(key); err != nil {
+				return errors.Wrapf(err, "getting %s call", key)
+			} else if ok {
+				if err := e.translateCall(index, idx, call); err != nil {
+					return errors.Wrapf(err, "translating %s call", key)
+				}
+			}
+		}
+		return nil
 	default:
 		colKey = "col"
 		fieldName = callArgString(c, "field")
@@ -2676,8 +5170,13 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 
 		// Bool field keys do not use the translator because there
 		// are only two possible values. Instead, they are handled
-		// directly.
-		if field.Type() == FieldTypeBool {
+		// directly. Values of foreign index fields are column keys
+		// in another index.
+		if foreignIndex := field.foreignIndex(); foreignIndex != "" {
+			if err := e.translateForeignCall(foreignIndex, c, rowKey); err != nil {
+				return errors.Wrap(err, "translating foreign key")
+			}
+		} else if field.Type() == FieldTypeBool {
 			boolVal, err := callArgBool(c, rowKey)
 			if err != nil {
 				return errors.Wrap(err, "getting bool key")
@@ -2698,7 +5197,8 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 				}
 				c.Args[rowKey] = ids[0]
 			}
-		} else {
+		} else if field.Type() != FieldTypeTimestamp {
+			// Timestamp fields parse string values when the call is executed.
 			if isString(c.Args[rowKey]) {
 				return errors.New("string 'row' value not allowed unless field 'keys' option enabled")
 			}
@@ -2715,6 +5215,51 @@ func (e *executor) translateCall(index string, idx *Index, c *pql.Call) error {
 	return nil
 }
 
+// translateForeignCall translates the value of a foreign index field into the
+// column ID of the corresponding key in the foreign index. Equality in Row()
+// calls is expressed as a condition so that it is executed against the bsiGroup.
+func (e *executor) translateForeignCall(foreignIndex string, c *pql.Call, fieldName string) error {
+	translate := func(v interface{}) (interface{}, error) {
+		key, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		ids, err := e.TranslateStore.TranslateColumnsToUint64(foreignIndex, []string{key})
+		if err != nil {
+			return nil, err
+		}
+		return int64(ids[0]), nil
+	}
+
+	switch v := c.Args[fieldName].(type) {
+	case nil:
+		return nil
+	case *pql.Condition:
+		if v.Op != pql.EQ && v.Op != pql.NEQ {
+			if _, ok := v.Value.(string); ok {
+				return errors.New("foreign keys only support == and != conditions")
+			}
+			return nil
+		}
+		value, err := translate(v.Value)
+		if err != nil {
+			return err
+		}
+		c.Args[fieldName] = &pql.Condition{Op: v.Op, Value: value}
+	default:
+		value, err := translate(v)
+		if err != nil {
+			return err
+		}
+		if c.Name == "Row" {
+			c.Args[fieldName] = &pql.Condition{Op: pql.EQ, Value: value}
+		} else {
+			c.Args[fieldName] = value
+		}
+	}
+	return nil
+}
+
 func (e *executor) translateGroupByCall(index string, idx *Index, c *pql.Call) error {
 	if c.Name != "GroupBy" {
 		panic("translateGroupByCall called with '" + c.Name + "'")
@@ -2807,6 +5352,13 @@ func (e *executor) translateResult(index string, idx *Index, call *pql.Call, res
 					other.Keys = append(other.Keys, key)
 				}
 			}
+			if result.Next != nil {
+				key, err := e.TranslateStore.TranslateColumnToString(index, result.Next.Column)
+				if err != nil {
+					return nil, err
+				}
+				other.Next = &RowCursor{Column: result.Next.Column, Key: key}
+			}
 			return other, nil
 		}
 
@@ -2873,10 +5425,80 @@ func (e *executor) translateResult(index string, idx *Index, call *pql.Call, res
 			other = append(other, GroupCount{
 				Group: group,
 				Count: gl.Count,
+				Sum:   gl.Sum,
 			})
 		}
 		return other, nil
 
+	case SimilarRows:
+		fieldName := callArgString(call, "field")
+		if field := idx.Field(fieldName); field != nil && field.keys() {
+			other := SimilarRows{Count: result.Count, Rows: make([]RowSimilarity, len(result.Rows))}
+			for i, r := range result.Rows {
+				key, err := e.TranslateStore.TranslateRowToString(index, fieldName, r.ID)
+				if err != nil {
+					return nil, errors.Wrap(err, "translating row ID")
+				}
+				r.Key = key
+				other.Rows[i] = r
+			}
+			return other, nil
+		}
+
+	case DistinctValues:
+		fieldName := callArgString(call, "field")
+		if field := idx.Field(fieldName); field != nil && field.foreignIndex() != "" {
+			other := make(DistinctKeys, len(result))
+			for i, id := range result {
+				key, err := e.TranslateStore.TranslateColumnToString(field.foreignIndex(), uint64(id))
+				if err != nil {
+					return nil, errors.Wrap(err, "translating foreign column ID")
+				}
+				other[i] = key
+			}
+			return other, nil
+		}
+
+	case []ColumnValue:
+		var foreignIndex string
+		if field := idx.Field(callArgString(call, "field")); field != nil {
+			foreignIndex = field.foreignIndex()
+		}
+		if idx.Keys() || foreignIndex != "" {
+			other := make([]ColumnValue, len(result))
+			for i, cv := range result {
+				other[i] = cv
+				if idx.Keys() {
+					key, err := e.TranslateStore.TranslateColumnToString(index, cv.ID)
+					if err != nil {
+						return nil, errors.Wrap(err, "translating column ID")
+					}
+					other[i].Key = key
+				}
+				if foreignIndex != "" {
+					key, err := e.TranslateStore.TranslateColumnToString(foreignIndex, uint64(cv.Value))
+					if err != nil {
+						return nil, errors.Wrap(err, "translating foreign column ID")
+					}
+					other[i].ValueKey = key
+				}
+			}
+			return other, nil
+		}
+
+	case ValCount:
+		if call.Name == "Min" || call.Name == "Max" {
+			fieldName := callArgString(call, "field")
+			if field := idx.Field(fieldName); field != nil && field.foreignIndex() != "" && result.Count > 0 {
+				key, err := e.TranslateStore.TranslateColumnToString(field.foreignIndex(), uint64(result.Val))
+				if err != nil {
+					return nil, errors.Wrap(err, "translating foreign column ID")
+				}
+				result.Key = key
+				return result, nil
+			}
+		}
+
 	case RowIDs:
 		other := RowIdentifiers{}
 
@@ -2994,9 +5616,58 @@ func needsShards(calls []*pql.Call) bool {
 }
 
 // ValCount represents a grouping of sum & count for Sum() and Average() calls.
+// For decimal fields, Val holds the value multiplied by 10^Scale. For
+// timestamp fields, Val holds the number of TimeUnit units since the epoch.
+// For the Min() and Max() of a field with a foreign index, Key holds the
+// foreign key of Val.
 type ValCount struct {
-	Val   int64 `json:"value"`
-	Count int64 `json:"count"`
+	Val      int64  `json:"value"`
+	Count    int64  `json:"count"`
+	Scale    int64  `json:"-"`
+	TimeUnit string `json:"-"`
+	Key      string `json:"-"`
+}
+
+// MarshalJSON marshals ValCount to JSON such that the
+// value of a decimal field is written with its fractional digits,
+// the value of a timestamp field is written in RFC3339 format,
+// and a foreign key is written in place of the value.
+func (vc ValCount) MarshalJSON() ([]byte, error) {
+	if vc.Key != "" {
+		return json.Marshal(struct {
+			Key   string `json:"value"`
+			Count int64  `json:"count"`
+		}{
+			Key:   vc.Key,
+			Count: vc.Count,
+		})
+	}
+	if vc.TimeUnit != "" {
+		return json.Marshal(struct {
+			Val   string `json:"value"`
+			Count int64  `json:"count"`
+		}{
+			Val:   vc.Timestamp().Format(time.RFC3339Nano),
+			Count: vc.Count,
+		})
+	}
+	return json.Marshal(struct {
+		Val   json.Number `json:"value"`
+		Count int64       `json:"count"`
+	}{
+		Val:   json.Number(formatDecimal(vc.Val, vc.Scale)),
+		Count: vc.Count,
+	})
+}
+
+// DecimalVal returns the value with its scale applied.
+func (vc ValCount) DecimalVal() float64 {
+	return unscaleDecimal(vc.Val, vc.Scale)
+}
+
+// Timestamp returns the value as a time in its time unit.
+func (vc ValCount) Timestamp() time.Time {
+	return valueTimestamp(vc.Val, vc.TimeUnit)
 }
 
 func (vc *ValCount) add(other ValCount) ValCount {
@@ -3079,6 +5750,11 @@ type groupByIterator struct {
 
 	// Optional filter row to intersect against first level of values.
 	filter *Row
+
+	// Optional BSI fragment and group used to compute the sum of an integer
+	// field for each group.
+	aggFragment *fragment
+	aggBSIGroup *bsiGroup
 }
 
 // newGroupByIterator initializes a new groupByIterator.
@@ -3100,20 +5776,30 @@ func newGroupByIterator(rowIDs []RowIDs, children []*pql.Call, filter *Row, inde
 		if fieldName, ok = call.Args["_field"].(string); !ok {
 			return nil, errors.Errorf("%s call must have field with valid (string) field name. Got %v of type %[2]T", call.Name, call.Args["_field"])
 		}
-		if holder.Field(index, fieldName) == nil {
+		field := holder.Field(index, fieldName)
+		if field == nil {
 			return nil, ErrFieldNotFound
 		}
 		gbi.fields[i].Field = fieldName
-		// Fetch fragment.
-		frag := holder.fragment(index, fieldName, viewStandard, shard)
-		if frag == nil { // this means this whole shard doesn't have all it needs to continue
+		// Fetch fragments of the views in the call's time range, if any.
+		views, err := rowsViews(field, call)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting views")
+		}
+		var frags []*fragment
+		for _, view := range views {
+			if frag := holder.fragment(index, fieldName, view, shard); frag != nil {
+				frags = append(frags, frag)
+			}
+		}
+		if len(frags) == 0 { // this means this whole shard doesn't have all it needs to continue
 			return nil, nil
 		}
 		filters := []rowFilter{}
 		if len(rowIDs[i]) > 0 {
 			filters = append(filters, filterWithRows(rowIDs[i]))
 		}
-		gbi.rowIters[i] = frag.rowIterator(i != 0, filters...)
+		gbi.rowIters[i] = newRowIterator(frags, i != 0, filters...)
 
 		prev, hasPrev, err := call.UintArg("previous")
 		if err != nil {
@@ -3222,6 +5908,20 @@ func (gbi *groupByIterator) Next() (ret GroupCount, done bool) {
 		ret.Group[i].RowID = r.id
 	}
 
+	if gbi.aggBSIGroup != nil {
+		var sum int64
+		if gbi.aggFragment != nil {
+			row := gbi.rows[len(gbi.rows)-1].row
+			if len(gbi.rows) > 1 {
+				row = row.Intersect(gbi.rows[len(gbi.rows)-2].row)
+			}
+			// sum does not currently return a non-nil error.
+			vsum, vcount, _ := gbi.aggFragment.sum(row, gbi.aggBSIGroup.BitDepth)
+			sum = vsum + int64(vcount)*gbi.aggBSIGroup.Base
+		}
+		ret.Sum = &sum
+	}
+
 	// set up for next call
 
 	gbi.nextAtIdx(len(gbi.rows) - 1)
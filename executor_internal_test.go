@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -236,3 +238,39 @@ func TestFieldRowMarshalJSON(t *testing.T) {
 		t.Fatalf("unexpected json: %s", b)
 	}
 }
+
+func TestSampleQuotas(t *testing.T) {
+	counts := []Pair{{ID: 4, Count: 7}, {ID: 0, Count: 3}, {ID: 2, Count: 5}}
+
+	// Quotas add up to n, and are the proportional share rounded up or down.
+	sums := make(map[uint64]uint64)
+	const seeds = 3000
+	for seed := int64(0); seed < seeds; seed++ {
+		quotas := sampleQuotas(counts, 4, rand.New(rand.NewSource(seed)))
+		var total uint64
+		for _, p := range counts {
+			exp := float64(4*p.Count) / 15
+			if q := quotas[p.ID]; float64(q) < math.Floor(exp) || float64(q) > math.Ceil(exp) {
+				t.Fatalf("unexpected quota for shard %d: %d", p.ID, q)
+			}
+			total += quotas[p.ID]
+			sums[p.ID] += quotas[p.ID]
+		}
+		if total != 4 {
+			t.Fatalf("unexpected total: %d", total)
+		}
+	}
+
+	// On average, each shard receives its proportional share.
+	for _, p := range counts {
+		exp := float64(4*p.Count) / 15
+		if avg := float64(sums[p.ID]) / seeds; math.Abs(avg-exp) > 0.05 {
+			t.Fatalf("unexpected average quota for shard %d: %v, expected %v", p.ID, avg, exp)
+		}
+	}
+
+	// Every column is sampled if n is at least the total count.
+	if quotas := sampleQuotas(counts, 15, rand.New(rand.NewSource(0))); quotas[4] != 7 || quotas[0] != 3 || quotas[2] != 5 {
+		t.Fatalf("unexpected quotas: %v", quotas)
+	}
+}
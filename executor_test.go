@@ -15,6 +15,7 @@
 package pilosa_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"flag"
@@ -1258,6 +1259,70 @@ func TestExecutor_Execute_TopN_Attr_Src(t *testing.T) {
 	}
 }
 
+// Ensure TopN() ranks rows across the time views within a from/to range.
+func TestExecutor_Execute_TopN_Time(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "cached", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")), pilosa.OptFieldCache(pilosa.CacheTypeRanked, 100))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "uncached", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	for _, field := range []string{"cached", "uncached"} {
+		c.Query(t, "i", fmt.Sprintf(`
+			Set(1, %[1]s=1, 2019-01-01T00:00)
+			Set(2, %[1]s=1, 2019-01-01T00:00)
+			Set(3, %[1]s=1, 2019-01-01T00:00)
+			Set(4, %[1]s=1, 2019-01-01T00:00)
+			Set(1, %[1]s=2, 2019-01-08T00:00)
+			Set(2, %[1]s=2, 2019-01-09T00:00)
+			Set(%[2]d, %[1]s=2, 2019-01-10T00:00)
+			Set(1, %[1]s=3, 2019-01-09T00:00)
+		`, field, ShardWidth+1))
+	}
+	if err := c[0].RecalculateCaches(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		query    string
+		expected []pilosa.Pair
+	}{
+		{
+			query:    `TopN(%s, from=2019-01-07T00:00, to=2019-01-14T00:00)`,
+			expected: []pilosa.Pair{{ID: 2, Count: 3}, {ID: 3, Count: 1}},
+		},
+		{
+			query:    `TopN(%s, n=1, from=2019-01-01T00:00, to=2019-01-14T00:00)`,
+			expected: []pilosa.Pair{{ID: 1, Count: 4}},
+		},
+		{
+			query:    `TopN(%s, Row(%[1]s=1), from=2019-01-07T00:00, to=2019-01-14T00:00)`,
+			expected: []pilosa.Pair{{ID: 2, Count: 2}, {ID: 3, Count: 1}},
+		},
+		{
+			// The threshold applies per shard to the counts combined across views.
+			query:    `TopN(%s, from=2019-01-07T00:00, to=2019-01-14T00:00, threshold=2)`,
+			expected: []pilosa.Pair{{ID: 2, Count: 2}},
+		},
+		{
+			query:    `TopN(%s, from=2019-02-01T00:00, to=2019-03-01T00:00)`,
+			expected: []pilosa.Pair{},
+		},
+	} {
+		query := fmt.Sprintf(tt.query, "cached")
+		t.Run(query, func(t *testing.T) {
+			results := c.Query(t, "i", query).Results
+			if !reflect.DeepEqual(results[0], tt.expected) {
+				t.Fatalf("unexpected result:\n%+v\nexpected:\n%+v", results[0], tt.expected)
+			}
+		})
+	}
+
+	// Time fields have no cache by default and cannot rank rows within a
+	// range without one.
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `TopN(uncached, from=2019-01-07T00:00, to=2019-01-14T00:00)`}); err == nil || !strings.Contains(err.Error(), "field has no cache") {
+		t.Fatalf("expected no cache error, got: %v", err)
+	}
+}
+
 // Ensure Min()  and Max() queries can be executed.
 func TestExecutor_Execute_MinMax(t *testing.T) {
 	t.Run("ColumnID", func(t *testing.T) {
@@ -1631,6 +1696,550 @@ func TestExecutor_Execute_Sum(t *testing.T) {
 	})
 }
 
+// Ensure a Distinct() query can be executed.
+func TestExecutor_Execute_Distinct(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "foo", pilosa.OptFieldTypeInt(-1000, 1000))
+	c.Query(t, "i", `
+		Set(0, x=0)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, x=0)
+
+		Set(0, foo=20)
+		Set(1, foo=-30)
+		Set(`+strconv.Itoa(ShardWidth)+`, foo=20)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, foo=40)
+		Set(`+strconv.Itoa((5*ShardWidth)+100)+`, foo=-30)
+		Set(`+strconv.Itoa((5*ShardWidth)+101)+`, foo=0)
+	`)
+
+	t.Run("NoFilter", func(t *testing.T) {
+		result := c.Query(t, "i", `Distinct(field=foo)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.DistinctValues{-30, 0, 20, 40}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		result := c.Query(t, "i", `Distinct(Row(x=0), field=foo)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.DistinctValues{20, 40}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		result := c.Query(t, "i", `Distinct(field=foo, limit=2)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.DistinctValues{-30, 0}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("ErrFieldRequired", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Distinct()`}); err == nil || !strings.Contains(err.Error(), "field required") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure a Sort() query can be executed.
+func TestExecutor_Execute_Sort(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "foo", pilosa.OptFieldTypeInt(-1000, 1000))
+	c.Query(t, "i", `
+		Set(0, x=0)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, x=0)
+		Set(`+strconv.Itoa((5*ShardWidth)+101)+`, x=0)
+
+		Set(0, foo=20)
+		Set(1, foo=-30)
+		Set(`+strconv.Itoa(ShardWidth)+`, foo=20)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, foo=40)
+		Set(`+strconv.Itoa((5*ShardWidth)+100)+`, foo=-30)
+		Set(`+strconv.Itoa((5*ShardWidth)+101)+`, foo=0)
+	`)
+
+	t.Run("Ascending", func(t *testing.T) {
+		result := c.Query(t, "i", `Sort(field=foo, limit=3)`).Results[0]
+		if !reflect.DeepEqual(result, []pilosa.ColumnValue{
+			{ID: 1, Value: -30},
+			{ID: (5 * ShardWidth) + 100, Value: -30},
+			{ID: (5 * ShardWidth) + 101, Value: 0},
+		}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Descending", func(t *testing.T) {
+		result := c.Query(t, "i", `Sort(field=foo, limit=3, desc=true)`).Results[0]
+		if !reflect.DeepEqual(result, []pilosa.ColumnValue{
+			{ID: ShardWidth + 1, Value: 40},
+			{ID: 0, Value: 20},
+			{ID: ShardWidth, Value: 20},
+		}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		result := c.Query(t, "i", `Sort(Row(x=0), field=foo, desc=true)`).Results[0]
+		if !reflect.DeepEqual(result, []pilosa.ColumnValue{
+			{ID: ShardWidth + 1, Value: 40},
+			{ID: 0, Value: 20},
+			{ID: (5 * ShardWidth) + 101, Value: 0},
+		}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		result := c.Query(t, "i", `Sort(Row(x=1), field=foo)`).Results[0]
+		if !reflect.DeepEqual(result, []pilosa.ColumnValue{}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "score", pilosa.OptFieldTypeInt(0, 100))
+		c.Query(t, "k", `
+			Set("alice", score=10)
+			Set("bob", score=30)
+			Set("carol", score=20)
+		`)
+		result := c.Query(t, "k", `Sort(field=score, limit=2, desc=true)`).Results[0]
+		if values, ok := result.([]pilosa.ColumnValue); !ok || len(values) != 2 {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		} else if values[0].Key != "bob" || values[0].Value != 30 || values[1].Key != "carol" || values[1].Value != 20 {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		} else if buf, err := json.Marshal(values); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `[{"key":"bob","value":30},{"key":"carol","value":20}]` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("Decimal", func(t *testing.T) {
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "price", pilosa.OptFieldTypeDecimal(2, 0, 100))
+		c.Query(t, "i", `
+			Set(0, price=1.5)
+			Set(`+strconv.Itoa(ShardWidth+1)+`, price=0.25)
+		`)
+		result := c.Query(t, "i", `Sort(field=price, desc=true)`).Results[0]
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `[{"id":0,"value":1.50},{"id":`+strconv.Itoa(ShardWidth+1)+`,"value":0.25}]` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "login", pilosa.OptFieldTypeTimestamp(pilosa.TimeUnitSeconds))
+		c.Query(t, "i", `
+			Set(0, login="2019-01-02T00:00")
+			Set(`+strconv.Itoa(ShardWidth+1)+`, login="2019-01-01T00:00")
+		`)
+		result := c.Query(t, "i", `Sort(field=login)`).Results[0]
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `[{"id":`+strconv.Itoa(ShardWidth+1)+`,"value":"2019-01-01T00:00:00Z"},{"id":0,"value":"2019-01-02T00:00:00Z"}]` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			query string
+			err   string
+		}{
+			{`Sort()`, "field required"},
+			{`Sort(field=x)`, "is not an int, decimal or timestamp field"},
+			{`Sort(field=foo, desc=1)`, "desc must be a bool"},
+		} {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("%s: expected error %q, got: %v", tt.query, tt.err, err)
+			}
+		}
+	})
+}
+
+// Ensure a Percentile() query can be executed.
+func TestExecutor_Execute_Percentile(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "foo", pilosa.OptFieldTypeInt(-1000, 1000))
+
+	// Set values -40, -30, ..., 50 spread across several shards.
+	var buf bytes.Buffer
+	for i := 0; i < 10; i++ {
+		col := i * ShardWidth / 2
+		fmt.Fprintf(&buf, "Set(%d, foo=%d)\n", col, (i-4)*10)
+		if i%2 == 0 {
+			fmt.Fprintf(&buf, "Set(%d, x=0)\n", col)
+		}
+	}
+	c.Query(t, "i", buf.String())
+
+	for _, tt := range []struct {
+		query string
+		exp   pilosa.ValCount
+	}{
+		{query: `Percentile(field=foo, nth=0)`, exp: pilosa.ValCount{Val: -40, Count: 1}},
+		{query: `Percentile(field=foo, nth=50)`, exp: pilosa.ValCount{Val: 0, Count: 1}},
+		{query: `Percentile(field=foo, nth=51)`, exp: pilosa.ValCount{Val: 10, Count: 1}},
+		{query: `Percentile(field=foo, nth=95)`, exp: pilosa.ValCount{Val: 50, Count: 1}},
+		{query: `Percentile(field=foo, nth=100)`, exp: pilosa.ValCount{Val: 50, Count: 1}},
+		{query: `Percentile(field=foo, nth=99.9)`, exp: pilosa.ValCount{Val: 50, Count: 1}},
+		{query: `Percentile(field=foo, nth=50, filter=Row(x=0))`, exp: pilosa.ValCount{Val: 0, Count: 1}},
+		{query: `Percentile(field=foo, nth=90, filter=Row(x=0))`, exp: pilosa.ValCount{Val: 40, Count: 1}},
+		{query: `Percentile(field=foo, nth=50, filter=Row(x=1))`, exp: pilosa.ValCount{}},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if result := c.Query(t, "i", tt.query).Results[0]; !reflect.DeepEqual(result, tt.exp) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "bar", pilosa.OptFieldTypeInt(-1000, 1000))
+		c.Query(t, "i", fmt.Sprintf(`Set(1, bar=5) Set(%d, bar=5) Set(%d, bar=5) Set(%d, bar=7)`, ShardWidth+1, 2*ShardWidth+1, 3*ShardWidth+1))
+		if result := c.Query(t, "i", `Percentile(field=bar, nth=50)`).Results[0]; !reflect.DeepEqual(result, pilosa.ValCount{Val: 5, Count: 3}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		if result := c.Query(t, "i", `Percentile(field=bar, nth=80)`).Results[0]; !reflect.DeepEqual(result, pilosa.ValCount{Val: 7, Count: 1}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("ErrNth", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Percentile(field=foo, nth=101)`}); err == nil || !strings.Contains(err.Error(), "between 0 and 100") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure a decimal field can be set and queried.
+func TestExecutor_Execute_Decimal(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "price", pilosa.OptFieldTypeDecimal(2, -100, 1000))
+	c.Query(t, "i", `
+		Set(0, x=0)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, x=0)
+
+		Set(0, price=12.34)
+		Set(1, price=-0.5)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, price=7)
+		Set(`+strconv.Itoa((5*ShardWidth)+100)+`, price=0.29)
+	`)
+
+	t.Run("Sum", func(t *testing.T) {
+		result := c.Query(t, "i", `Sum(field=price)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 1913, Count: 4, Scale: 2}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `{"value":19.13,"count":4}` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("SumFilter", func(t *testing.T) {
+		result := c.Query(t, "i", `Sum(Row(x=0), field=price)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 1934, Count: 2, Scale: 2}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("MinMax", func(t *testing.T) {
+		result := c.Query(t, "i", `Min(field=price)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: -50, Count: 1, Scale: 2}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		result = c.Query(t, "i", `Max(field=price)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 1234, Count: 1, Scale: 2}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Row", func(t *testing.T) {
+		for _, tt := range []struct {
+			query string
+			exp   []uint64
+		}{
+			{query: `Row(price > 7)`, exp: []uint64{0}},
+			{query: `Row(price >= 7)`, exp: []uint64{0, ShardWidth + 1}},
+			{query: `Row(price < 0.29)`, exp: []uint64{1}},
+			{query: `Row(price == 0.29)`, exp: []uint64{(5 * ShardWidth) + 100}},
+			{query: `Row(price > 0.285)`, exp: []uint64{0, ShardWidth + 1, (5 * ShardWidth) + 100}},
+			{query: `Row(price >< [-0.5, 0.29])`, exp: []uint64{1, (5 * ShardWidth) + 100}},
+		} {
+			if cols := c.Query(t, "i", tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("%s: unexpected columns: %v", tt.query, cols)
+			}
+		}
+	})
+
+	t.Run("ImportValue", func(t *testing.T) {
+		nodes, err := c[0].API.ShardNodes(context.Background(), "i", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, node := range nodes {
+			for _, m := range c {
+				if m.API.Node().ID != node.ID {
+					continue
+				}
+				if err := m.API.ImportValue(context.Background(), &pilosa.ImportValueRequest{
+					Index:       "i",
+					Field:       "price",
+					ColumnIDs:   []uint64{2, 3},
+					FloatValues: []float64{1.25, 100.01},
+				}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		result := c.Query(t, "i", `Max(field=price)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 10001, Count: 1, Scale: 2}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("ErrOutOfRange", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Set(4, price=1000.01)`}); err == nil || !strings.Contains(err.Error(), pilosa.ErrBSIGroupValueTooHigh.Error()) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure timestamp fields can be set and queried with RFC3339 values.
+func TestExecutor_Execute_Timestamp(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "login", pilosa.OptFieldTypeTimestamp(pilosa.TimeUnitMilliseconds))
+	c.Query(t, "i", `
+		Set(0, x=0)
+		Set(`+strconv.Itoa(ShardWidth+1)+`, x=0)
+
+		Set(0, login="2019-01-01T00:00")
+		Set(1, login="2018-12-31T23:59:59.5Z")
+		Set(`+strconv.Itoa(ShardWidth+1)+`, login="2019-03-01T12:00:00+02:00")
+		Set(`+strconv.Itoa((5*ShardWidth)+100)+`, login=1546300800001)
+	`)
+
+	t.Run("MinMax", func(t *testing.T) {
+		result := c.Query(t, "i", `Min(field=login)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 1546300799500, Count: 1, TimeUnit: pilosa.TimeUnitMilliseconds}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `{"value":"2018-12-31T23:59:59.5Z","count":1}` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+
+		result = c.Query(t, "i", `Max(Row(x=0), field=login)`).Results[0]
+		if vc := result.(pilosa.ValCount); !vc.Timestamp().Equal(time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("Row", func(t *testing.T) {
+		for _, tt := range []struct {
+			query string
+			exp   []uint64
+		}{
+			{query: `Row(login > "2019-01-01T00:00")`, exp: []uint64{ShardWidth + 1, (5 * ShardWidth) + 100}},
+			{query: `Row(login >= "2019-01-01T00:00:00Z")`, exp: []uint64{0, ShardWidth + 1, (5 * ShardWidth) + 100}},
+			{query: `Row(login < "2019-01-01T00:00")`, exp: []uint64{1}},
+			{query: `Row(login == "2019-01-01T00:00")`, exp: []uint64{0}},
+			{query: `Row(login >< ["2019-01-01T00:00", "2019-02-01T00:00"])`, exp: []uint64{0, (5 * ShardWidth) + 100}},
+			{query: `Row(login > 1546300800000)`, exp: []uint64{ShardWidth + 1, (5 * ShardWidth) + 100}},
+			{query: `Row(login > "2018-12-31T23:59:59.4999Z")`, exp: []uint64{0, 1, ShardWidth + 1, (5 * ShardWidth) + 100}},
+			{query: `Row(login <= "2018-12-31T23:59:59.5001Z")`, exp: []uint64{1}},
+			{query: `Row(login == "2018-12-31T23:59:59.5001Z")`, exp: []uint64{}},
+			{query: `Row(login != "2018-12-31T23:59:59.5001Z")`, exp: []uint64{0, 1, ShardWidth + 1, (5 * ShardWidth) + 100}},
+		} {
+			if cols := c.Query(t, "i", tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("%s: unexpected columns: %v", tt.query, cols)
+			}
+		}
+	})
+
+	t.Run("ErrInvalidTimestamp", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Set(4, login="yesterday")`}); err == nil || !strings.Contains(err.Error(), "cannot parse timestamp") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure int fields with a foreign index translate values through its column keys.
+func TestExecutor_Execute_ForeignIndex(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, "users", pilosa.IndexOptions{Keys: true}, "name", pilosa.OptFieldKeys())
+	c.CreateField(t, "events", pilosa.IndexOptions{}, "user_id", pilosa.OptFieldTypeInt(0, 1<<32), pilosa.OptFieldForeignIndex("users"))
+	c.Query(t, "users", `Set("bob", name="Bob")`)
+	c.Query(t, "events", `
+		Set(1, user_id="alice")
+		Set(2, user_id="bob")
+		Set(3, user_id="alice")
+	`)
+
+	t.Run("Row", func(t *testing.T) {
+		for _, tt := range []struct {
+			query string
+			exp   []uint64
+		}{
+			{query: `Row(user_id="alice")`, exp: []uint64{1, 3}},
+			{query: `Row(user_id=="bob")`, exp: []uint64{2}},
+			{query: `Row(user_id!="bob")`, exp: []uint64{1, 3}},
+			{query: `Row(user_id="carol")`, exp: []uint64{}},
+		} {
+			if cols := c.Query(t, "events", tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("%s: unexpected columns: %v", tt.query, cols)
+			}
+		}
+	})
+
+	t.Run("Distinct", func(t *testing.T) {
+		result := c.Query(t, "events", `Distinct(field=user_id)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.DistinctKeys{"bob", "alice"}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	t.Run("MinMax", func(t *testing.T) {
+		if result := c.Query(t, "events", `Min(field=user_id)`).Results[0]; !reflect.DeepEqual(result, pilosa.ValCount{Val: 1, Count: 1, Key: "bob"}) {
+			t.Fatalf("unexpected min: %s", spew.Sdump(result))
+		}
+		result := c.Query(t, "events", `Max(field=user_id)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.ValCount{Val: 2, Count: 2, Key: "alice"}) {
+			t.Fatalf("unexpected max: %s", spew.Sdump(result))
+		}
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `{"value":"alice","count":2}` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		result := c.Query(t, "events", `Sort(field=user_id, desc=true, limit=2)`).Results[0]
+		if !reflect.DeepEqual(result, []pilosa.ColumnValue{{ID: 1, Value: 2, ValueKey: "alice"}, {ID: 3, Value: 2, ValueKey: "alice"}}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+		if buf, err := json.Marshal(result); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `[{"id":1,"value":"alice"},{"id":3,"value":"alice"}]` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+	})
+
+	t.Run("ErrRangeCondition", func(t *testing.T) {
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "events", Query: `Row(user_id > "alice")`}); err == nil || !strings.Contains(err.Error(), "foreign keys only support == and != conditions") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("ErrForeignIndexKeys", func(t *testing.T) {
+		if _, err := c[0].API.CreateField(context.Background(), "users", "friend_id", pilosa.OptFieldTypeInt(0, 100), pilosa.OptFieldForeignIndex("events")); err == nil || !strings.Contains(err.Error(), "foreign index must have the 'keys' option enabled") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+// Ensure a row from another index can be joined through an int field.
+func TestExecutor_Execute_Join(t *testing.T) {
+	t.Run("Cluster", func(t *testing.T) {
+		c := test.MustRunCluster(t, 3)
+		defer c.Close()
+		c.CreateField(t, "users", pilosa.IndexOptions{}, "country", pilosa.OptFieldKeys())
+		c.CreateField(t, "events", pilosa.IndexOptions{}, "type", pilosa.OptFieldKeys())
+		c.CreateField(t, "events", pilosa.IndexOptions{}, "user_id", pilosa.OptFieldTypeInt(0, 10*ShardWidth))
+		c.Query(t, "users", `
+			Set(1, country="DE")
+			Set(2, country="US")
+			Set(`+strconv.Itoa(ShardWidth+3)+`, country="DE")
+		`)
+		c.Query(t, "events", `
+			Set(10, user_id=1)
+			Set(11, user_id=2)
+			Set(`+strconv.Itoa(ShardWidth+12)+`, user_id=`+strconv.Itoa(ShardWidth+3)+`)
+			Set(`+strconv.Itoa(2*ShardWidth+13)+`, user_id=1)
+			Set(10, type="click")
+			Set(11, type="click")
+			Set(`+strconv.Itoa(2*ShardWidth+13)+`, type="click")
+		`)
+
+		for _, tt := range []struct {
+			query string
+			exp   []uint64
+		}{
+			{query: `Join(Row(country="DE"), index=users, field=user_id)`, exp: []uint64{10, ShardWidth + 12, 2*ShardWidth + 13}},
+			{query: `Intersect(Row(type="click"), Join(Row(country="DE"), index=users, field=user_id))`, exp: []uint64{10, 2*ShardWidth + 13}},
+			{query: `Join(Row(country="FR"), index=users, field=user_id)`, exp: []uint64{}},
+		} {
+			if cols := c.Query(t, "events", tt.query).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, tt.exp) {
+				t.Fatalf("%s: unexpected columns: %v", tt.query, cols)
+			}
+		}
+
+		if n := c.Query(t, "events", `Count(Join(Row(country="US"), index=users, field=user_id))`).Results[0]; n != uint64(1) {
+			t.Fatalf("unexpected count: %v", n)
+		}
+	})
+
+	t.Run("ForeignIndex", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, "users", pilosa.IndexOptions{Keys: true}, "country", pilosa.OptFieldKeys())
+		c.CreateField(t, "events", pilosa.IndexOptions{}, "user_id", pilosa.OptFieldTypeInt(0, 1<<32), pilosa.OptFieldForeignIndex("users"))
+		c.Query(t, "users", `
+			Set("alice", country="DE")
+			Set("bob", country="US")
+		`)
+		c.Query(t, "events", `
+			Set(1, user_id="alice")
+			Set(2, user_id="bob")
+			Set(3, user_id="alice")
+		`)
+
+		if cols := c.Query(t, "events", `Join(Row(country="DE"), field=user_id)`).Results[0].(*pilosa.Row).Columns(); !reflect.DeepEqual(cols, []uint64{1, 3}) {
+			t.Fatalf("unexpected columns: %v", cols)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, "users", pilosa.IndexOptions{}, "country")
+		c.CreateField(t, "events", pilosa.IndexOptions{}, "user_id", pilosa.OptFieldTypeInt(0, 100))
+		c.CreateField(t, "events", pilosa.IndexOptions{}, "type")
+
+		for _, tt := range []struct {
+			query string
+			err   string
+		}{
+			{query: `Join(Row(country=1), field=user_id)`, err: "Join(): index required"},
+			{query: `Join(Row(country=1), index=users, field=type)`, err: `Join(): field "type" must be an int field`},
+			{query: `Join(index=users, field=user_id)`, err: "Join() requires an input row"},
+			{query: `Join(Row(country=1), index=foo, field=user_id)`, err: pilosa.ErrIndexNotFound.Error()},
+		} {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "events", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("%s: unexpected error: %v", tt.query, err)
+			}
+		}
+	})
+}
+
 // Ensure a range query can be executed.
 func TestExecutor_Execute_Row_Range(t *testing.T) {
 	t.Run("RowIDColumnID", func(t *testing.T) {
@@ -2707,22 +3316,104 @@ func TestExecutor_ExecuteOptions(t *testing.T) {
 			t.Fatalf("unexpected attrs: %s", spew.Sdump(attrs))
 		}
 	})
-}
 
-// Ensure an existence field is maintained.
-func TestExecutor_Execute_Existence(t *testing.T) {
-	t.Run("Row", func(t *testing.T) {
-		c := test.MustRunCluster(t, 1)
-		defer c.Close()
-		hldr := test.Holder{Holder: c[0].Server.Holder()}
-		index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{TrackExistence: true})
-		_, err := index.CreateField("f", pilosa.OptFieldTypeDefault())
-		if err != nil {
-			t.Fatal(err)
+	t.Run("paginate", func(t *testing.T) {
+		writeQuery := fmt.Sprintf(`
+			Set(1, f=10)
+			Set(2, f=10)
+			Set(%d, f=10)
+			Set(%d, f=10)`, ShardWidth+1, ShardWidth*2+5)
+		readQueries := []string{
+			`Options(Row(f=10), limit=2)`,
+			`Options(Row(f=10), limit=2, after=2)`,
+			fmt.Sprintf(`Options(Row(f=10), limit=2, after=%d)`, ShardWidth*2+5),
+			`Options(Row(f=10), after=1)`,
 		}
-
-		// Set bits.
-		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `` +
+		responses := runCallTest(t, writeQuery, readQueries, nil)
+		for i, tt := range []struct {
+			columns []uint64
+			next    *pilosa.RowCursor
+		}{
+			{[]uint64{1, 2}, &pilosa.RowCursor{Column: 2}},
+			{[]uint64{ShardWidth + 1, ShardWidth*2 + 5}, nil},
+			{[]uint64{}, nil},
+			{[]uint64{2, ShardWidth + 1, ShardWidth*2 + 5}, nil},
+		} {
+			row := responses[i].Results[0].(*pilosa.Row)
+			if bits := row.Columns(); !reflect.DeepEqual(bits, tt.columns) {
+				t.Fatalf("%d. unexpected columns: %+v", i, bits)
+			} else if !reflect.DeepEqual(row.Next, tt.next) {
+				t.Fatalf("%d. unexpected cursor: %+v", i, row.Next)
+			}
+		}
+	})
+
+	t.Run("paginateWithKeys", func(t *testing.T) {
+		writeQuery := `
+			Set("a", f="ten")
+			Set("b", f="ten")
+			Set("c", f="ten")`
+		readQueries := []string{
+			`Options(Row(f="ten"), limit=2)`,
+			`Options(Row(f="ten"), limit=2, after="b")`,
+		}
+		responses := runCallTest(t, writeQuery, readQueries,
+			&pilosa.IndexOptions{Keys: true},
+			pilosa.OptFieldKeys())
+
+		row := responses[0].Results[0].(*pilosa.Row)
+		if !reflect.DeepEqual(row.Keys, []string{"a", "b"}) {
+			t.Fatalf("unexpected keys: %+v", row.Keys)
+		} else if row.Next == nil || row.Next.Key != "b" {
+			t.Fatalf("unexpected cursor: %+v", row.Next)
+		} else if buf, err := json.Marshal(row); err != nil {
+			t.Fatal(err)
+		} else if string(buf) != `{"attrs":{},"columns":[],"keys":["a","b"],"next":"b"}` {
+			t.Fatalf("unexpected json: %s", buf)
+		}
+
+		row = responses[1].Results[0].(*pilosa.Row)
+		if !reflect.DeepEqual(row.Keys, []string{"c"}) {
+			t.Fatalf("unexpected keys: %+v", row.Keys)
+		} else if row.Next != nil {
+			t.Fatalf("unexpected cursor: %+v", row.Next)
+		}
+	})
+
+	t.Run("paginateErrors", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+		for _, tt := range []struct {
+			query string
+			err   string
+		}{
+			{`Options(Row(f=10), limit=0)`, "limit must be greater than zero"},
+			{`Options(Row(f=10), limit=-1)`, "limit must be an unsigned integer"},
+			{`Options(Count(Row(f=10)), limit=1)`, "only apply to calls which return a row"},
+		} {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("%s: expected error %q, got: %v", tt.query, tt.err, err)
+			}
+		}
+	})
+}
+
+// Ensure an existence field is maintained.
+func TestExecutor_Execute_Existence(t *testing.T) {
+	t.Run("Row", func(t *testing.T) {
+		c := test.MustRunCluster(t, 1)
+		defer c.Close()
+		hldr := test.Holder{Holder: c[0].Server.Holder()}
+		index := hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{TrackExistence: true})
+		_, err := index.CreateField("f", pilosa.OptFieldTypeDefault())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Set bits.
+		if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `` +
 			fmt.Sprintf("Set(%d, f=%d)\n", 3, 10) +
 			fmt.Sprintf("Set(%d, f=%d)\n", ShardWidth+1, 10) +
 			fmt.Sprintf("Set(%d, f=%d)\n", ShardWidth+2, 20),
@@ -3241,6 +3932,48 @@ func benchmarkExistence(nn bool, b *testing.B) {
 func BenchmarkExecutor_Existence_True(b *testing.B)  { benchmarkExistence(true, b) }
 func BenchmarkExecutor_Existence_False(b *testing.B) { benchmarkExistence(false, b) }
 
+// BenchmarkExecutor_CountApprox compares estimating the count of a union of
+// rows with counting it exactly.
+func BenchmarkExecutor_CountApprox(b *testing.B) {
+	c := test.MustRunCluster(b, 1)
+	defer c.Close()
+	c.CreateField(b, "i", pilosa.IndexOptions{}, "f")
+
+	const rows, shards, bitsPerRow = 20, 4, 200000
+	var q strings.Builder
+	for shard := uint64(0); shard < shards; shard++ {
+		req := &pilosa.ImportRequest{Index: "i", Field: "f", Shard: shard}
+		for row := uint64(0); row < rows; row++ {
+			for i := 0; i < bitsPerRow; i++ {
+				req.RowIDs = append(req.RowIDs, row)
+				req.ColumnIDs = append(req.ColumnIDs, shard*ShardWidth+uint64(rand.Intn(ShardWidth)))
+			}
+		}
+		if err := c[0].API.Import(context.Background(), req); err != nil {
+			b.Fatal(err)
+		}
+	}
+	c[0].RecalculateCaches()
+
+	q.WriteString("Union(")
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			q.WriteString(", ")
+		}
+		fmt.Fprintf(&q, "Row(f=%d)", row)
+	}
+	q.WriteString(")")
+
+	for _, name := range []string{"Count", "CountApprox"} {
+		query := fmt.Sprintf("%s(%s)", name, q.String())
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.Query(b, "i", query)
+			}
+		})
+	}
+}
+
 func TestExecutor_Execute_Rows(t *testing.T) {
 	c := test.MustRunCluster(t, 3)
 	defer c.Close()
@@ -3334,6 +4067,756 @@ func TestExecutor_Execute_RowsTimeEmpty(t *testing.T) {
 	}
 }
 
+// Ensure GroupBy() restricts child Rows() calls to their time range.
+func TestExecutor_Execute_GroupByTime(t *testing.T) {
+	c := test.MustRunCluster(t, 1)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "campaign", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "country")
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, campaign=1, 2019-01-01T00:00)
+		Set(2, campaign=1, 2019-01-08T00:00)
+		Set(%d, campaign=2, 2019-01-09T00:00)
+		Set(1, country=10)
+		Set(2, country=10)
+		Set(%[1]d, country=20)
+	`, ShardWidth+3))
+
+	for _, tt := range []struct {
+		query    string
+		expected []pilosa.GroupCount
+	}{
+		{
+			query: `GroupBy(Rows(campaign), Rows(country))`,
+			expected: []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "campaign", RowID: 1}, {Field: "country", RowID: 10}}, Count: 2},
+				{Group: []pilosa.FieldRow{{Field: "campaign", RowID: 2}, {Field: "country", RowID: 20}}, Count: 1},
+			},
+		},
+		{
+			query: `GroupBy(Rows(campaign, from=2019-01-07T00:00, to=2019-01-14T00:00), Rows(country))`,
+			expected: []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "campaign", RowID: 1}, {Field: "country", RowID: 10}}, Count: 1},
+				{Group: []pilosa.FieldRow{{Field: "campaign", RowID: 2}, {Field: "country", RowID: 20}}, Count: 1},
+			},
+		},
+		{
+			query: `GroupBy(Rows(campaign, from=2019-01-07T00:00, to=2019-01-14T00:00, limit=1), Rows(country))`,
+			expected: []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "campaign", RowID: 1}, {Field: "country", RowID: 10}}, Count: 1},
+			},
+		},
+		{
+			query:    `GroupBy(Rows(campaign, from=2019-02-01T00:00, to=2019-03-01T00:00), Rows(country))`,
+			expected: []pilosa.GroupCount{},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			results := c.Query(t, "i", tt.query).Results
+			if !reflect.DeepEqual(results[0], tt.expected) {
+				t.Fatalf("unexpected result:\n%+v\nexpected:\n%+v", results[0], tt.expected)
+			}
+		})
+	}
+}
+
+// Ensure a CountSeries() query counts each interval across the cluster.
+func TestExecutor_Execute_CountSeries(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "event", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "country")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "hourly", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMDH")))
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, event=1, 2019-01-01T00:00)
+		Set(2, event=1, 2019-01-01T05:00)
+		Set(%[1]d, event=1, 2019-01-03T00:00)
+		Set(%[2]d, event=1, 2019-02-01T00:00)
+		Set(1, event=2, 2019-01-02T00:00)
+		Set(1, country=10)
+		Set(%[1]d, country=10)
+		Set(1, hourly=1, 2019-01-01T00:00)
+	`, ShardWidth+1, 5*ShardWidth+2))
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2019, m, d, 0, 0, 0, 0, time.UTC) }
+
+	for _, tt := range []struct {
+		query    string
+		expected pilosa.CountSeries
+	}{
+		{
+			query: `CountSeries(Row(event=1), interval=D, from=2019-01-01T00:00, to=2019-01-04T00:00)`,
+			expected: pilosa.CountSeries{
+				{Time: day(1, 1), Count: 2},
+				{Time: day(1, 2), Count: 0},
+				{Time: day(1, 3), Count: 1},
+			},
+		},
+		{
+			query: `CountSeries(Row(event=1), interval=M, from=2019-01-15T00:00, to=2019-03-01T00:00)`,
+			expected: pilosa.CountSeries{
+				{Time: day(1, 1), Count: 3},
+				{Time: day(2, 1), Count: 1},
+			},
+		},
+		{
+			// Weeks start on Monday, so the first week begins before from.
+			query: `CountSeries(Row(event=1), interval=W, from=2019-01-02T00:00, to=2019-01-15T00:00)`,
+			expected: pilosa.CountSeries{
+				{Time: time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC), Count: 3},
+				{Time: day(1, 7), Count: 0},
+				{Time: day(1, 14), Count: 0},
+			},
+		},
+		{
+			query: `CountSeries(Intersect(Row(event=1), Row(country=10)), interval=D, from=2019-01-01T00:00, to=2019-01-04T00:00)`,
+			expected: pilosa.CountSeries{
+				{Time: day(1, 1), Count: 1},
+				{Time: day(1, 2), Count: 0},
+				{Time: day(1, 3), Count: 1},
+			},
+		},
+		{
+			query: `CountSeries(Union(Row(event=1), Row(event=2)), interval=D, from=2019-01-02T00:00, to=2019-01-03T00:00)`,
+			expected: pilosa.CountSeries{
+				{Time: day(1, 2), Count: 1},
+			},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			result := c.Query(t, "i", tt.query).Results[0]
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `CountSeries(Row(event=1), from=2019-01-01T00:00, to=2019-01-04T00:00)`, err: "interval must be one of Y, M, W, D or H"},
+		{query: `CountSeries(Row(event=1), interval=D, to=2019-01-04T00:00)`, err: "from required"},
+		{query: `CountSeries(Row(event=1), interval=H, from=2019-01-01T00:00, to=2019-01-02T00:00)`, err: "interval H is finer than the time quantum"},
+		{query: `CountSeries(Row(country=10), interval=D, from=2019-01-01T00:00, to=2019-01-02T00:00)`, err: "must contain a Row() call on a time field"},
+		{query: `CountSeries(Row(hourly=1), interval=H, from=2018-01-01T00:00, to=2020-01-01T00:00)`, err: "too many intervals"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Ensure a Retention() query computes the retention matrix across the cluster.
+func TestExecutor_Execute_Retention(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "event", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "monthly", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YM")))
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, event=1, 2019-01-07T00:00)
+		Set(2, event=1, 2019-01-09T00:00)
+		Set(%[1]d, event=1, 2019-01-13T00:00)
+		Set(1, event=2, 2019-01-08T00:00)
+		Set(3, event=1, 2019-01-15T00:00)
+		Set(1, event=2, 2019-01-14T00:00)
+		Set(%[1]d, event=2, 2019-01-16T00:00)
+		Set(3, event=2, 2019-01-20T00:00)
+		Set(1, event=2, 2019-01-21T00:00)
+		Set(2, event=2, 2019-01-27T00:00)
+	`, 5*ShardWidth+1))
+
+	week := func(d int) time.Time { return time.Date(2019, 1, d, 0, 0, 0, 0, time.UTC) }
+	expected := pilosa.RetentionMatrix{
+		{Time: week(7), Size: 3, Counts: []uint64{1, 2, 2}},
+		{Time: week(14), Size: 1, Counts: []uint64{1, 0}},
+		{Time: week(21), Size: 0, Counts: []uint64{0}},
+	}
+	result := c.Query(t, "i", `Retention(start=Row(event=1), return=Row(event=2), interval=W, periods=3, from=2019-01-07T00:00)`).Results[0]
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("unexpected result: %s", spew.Sdump(result))
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		c.CreateField(t, "k", pilosa.IndexOptions{}, "event", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")), pilosa.OptFieldKeys())
+		c.Query(t, "k", `
+			Set(1, event="signup", 2019-01-01T00:00)
+			Set(2, event="signup", 2019-01-01T00:00)
+			Set(1, event="visit", 2019-01-02T00:00)
+		`)
+		result := c.Query(t, "k", `Retention(start=Row(event="signup"), return=Row(event="visit"), interval=D, periods=2, from=2019-01-01T00:00)`).Results[0]
+		if !reflect.DeepEqual(result, pilosa.RetentionMatrix{
+			{Time: week(1), Size: 2, Counts: []uint64{0, 1}},
+			{Time: week(2), Size: 0, Counts: []uint64{0}},
+		}) {
+			t.Fatalf("unexpected result: %s", spew.Sdump(result))
+		}
+	})
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `Retention(return=Row(event=2), interval=W, periods=3, from=2019-01-07T00:00)`, err: "start required"},
+		{query: `Retention(start=Row(event=1), return=Row(event=2), interval=Q, periods=3, from=2019-01-07T00:00)`, err: "interval must be one of Y, M, W, D or H"},
+		{query: `Retention(start=Row(event=1), return=Row(event=2), interval=W, from=2019-01-07T00:00)`, err: "periods required"},
+		{query: `Retention(start=Row(event=1), return=Row(event=2), interval=W, periods=501, from=2019-01-07T00:00)`, err: "too many periods"},
+		{query: `Retention(start=Row(monthly=1), return=Row(event=2), interval=W, periods=3, from=2019-01-07T00:00)`, err: "interval W is finer than the time quantum"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Ensure a Funnel() query counts the columns completing each step in order.
+func TestExecutor_Execute_Funnel(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "step", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMDH")))
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, step=1, 2019-01-01T10:00)
+		Set(1, step=2, 2019-01-01T11:00)
+		Set(1, step=3, 2019-01-01T12:00)
+
+		Set(2, step=1, 2019-01-01T10:00)
+		Set(2, step=2, 2019-01-02T09:00)
+		Set(2, step=3, 2019-01-02T11:00)
+
+		Set(3, step=2, 2019-01-01T09:00)
+		Set(3, step=1, 2019-01-01T10:00)
+
+		Set(4, step=1, 2019-01-01T10:00)
+		Set(4, step=3, 2019-01-01T11:00)
+
+		Set(%[1]d, step=1, 2019-01-01T05:00)
+		Set(%[1]d, step=2, 2019-01-01T05:00)
+		Set(%[1]d, step=3, 2019-01-01T06:00)
+	`, 5*ShardWidth+1))
+
+	for _, tt := range []struct {
+		query    string
+		expected pilosa.FunnelCounts
+	}{
+		{
+			query:    `Funnel(Row(step=1), Row(step=2), Row(step=3), from=2019-01-01T00:00, to=2019-01-03T00:00, window=D)`,
+			expected: pilosa.FunnelCounts{5, 3, 2},
+		},
+		{
+			query:    `Funnel(Row(step=1), Row(step=2), Row(step=3), from=2019-01-01T00:00, to=2019-01-03T00:00, window=W)`,
+			expected: pilosa.FunnelCounts{5, 3, 3},
+		},
+		{
+			query:    `Funnel(Row(step=1), Row(step=2), Row(step=3), from=2019-01-01T00:00, to=2019-01-02T00:00, window=D)`,
+			expected: pilosa.FunnelCounts{5, 2, 2},
+		},
+		{
+			query:    `Funnel(Row(step=1), Row(step=2), from=2019-02-01T00:00, to=2019-02-02T00:00, window=D)`,
+			expected: pilosa.FunnelCounts{0, 0},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			result := c.Query(t, "i", tt.query).Results[0]
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	}
+
+	// Steps within the same bucket count as ordered even when reversed,
+	// while reversed steps in different buckets do not.
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "daily", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	c.Query(t, "i", `
+		Set(1, daily=2, 2019-01-01T09:00)
+		Set(1, daily=1, 2019-01-01T18:00)
+
+		Set(2, daily=2, 2019-01-01T09:00)
+		Set(2, daily=1, 2019-01-02T09:00)
+	`)
+	if result := c.Query(t, "i", `Funnel(Row(daily=1), Row(daily=2), from=2019-01-01T00:00, to=2019-01-03T00:00, window=W)`).Results[0]; !reflect.DeepEqual(result, pilosa.FunnelCounts{2, 1}) {
+		t.Fatalf("unexpected same bucket result: %s", spew.Sdump(result))
+	}
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `Funnel(from=2019-01-01T00:00, to=2019-01-03T00:00, window=D)`, err: "requires at least one step"},
+		{query: `Funnel(Row(step=1), from=2019-01-01T00:00, to=2019-01-03T00:00)`, err: "window must be one of Y, M, W, D or H"},
+		{query: `Funnel(Row(step=1), from=2019-01-01T00:00, window=D)`, err: "to required"},
+		{query: `Funnel(Row(step=1), from=2019-01-01T00:00, to=2019-01-03T00:00, window=H)`, err: "window H must be coarser than the time quantum unit H"},
+		{query: `Funnel(Row(step=1), from=2019-01-01T00:00, to=2020-01-01T00:00, window=D)`, err: "too many H units"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Ensure a Delete() query clears columns from every field and node.
+func TestExecutor_Execute_Delete(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "n", pilosa.OptFieldTypeInt(-100, 100))
+	c.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "t", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+	c.CreateField(t, "i", pilosa.IndexOptions{TrackExistence: true}, "m", pilosa.OptFieldTypeMutex(pilosa.CacheTypeRanked, 100))
+	c.Query(t, "i", fmt.Sprintf(`
+		Set(1, f=10) Set(1, n=5) Set(1, t=1, 2019-01-01T00:00) Set(1, m=3) SetColumnAttrs(1, x=1)
+		Set(2, f=10) Set(2, n=7) SetColumnAttrs(2, x=2)
+		Set(%[1]d, f=11) Set(%[1]d, n=-3) Set(%[1]d, t=1, 2019-01-02T00:00) SetColumnAttrs(%[1]d, x=3)
+		Set(%[2]d, f=11) Set(%[2]d, m=3)
+	`, ShardWidth+1, 2*ShardWidth+3))
+
+	if res := c.Query(t, "i", `Delete(Union(Row(n<0), Row(m=3)))`).Results[0]; res != uint64(3) {
+		t.Fatalf("unexpected result: %v", res)
+	}
+
+	for _, tt := range []struct {
+		query    string
+		expected interface{}
+	}{
+		{query: `Row(f=10)`, expected: []uint64{2}},
+		{query: `Row(f=11)`, expected: []uint64{}},
+		{query: `Row(m=3)`, expected: []uint64{}},
+		{query: `Not(Row(f=99))`, expected: []uint64{2}},
+		{query: `Sum(field=n)`, expected: pilosa.ValCount{Val: 7, Count: 1}},
+		{query: `Count(Row(t=1, from=2019-01-01T00:00, to=2019-01-03T00:00))`, expected: uint64(0)},
+		{query: `Delete(Row(m=3))`, expected: uint64(0)},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			result := c.Query(t, "i", tt.query).Results[0]
+			if row, ok := result.(*pilosa.Row); ok {
+				result = row.Columns()
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	}
+
+	// Column attributes are removed from every node.
+	for i := range c {
+		store := c[i].Server.Holder().Index("i").ColumnAttrStore()
+		for _, id := range []uint64{1, ShardWidth + 1} {
+			if attrs, err := store.Attrs(id); err != nil {
+				t.Fatal(err)
+			} else if len(attrs) != 0 {
+				t.Fatalf("unexpected attrs on node %d for column %d: %v", i, id, attrs)
+			}
+		}
+		if attrs, err := store.Attrs(2); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(attrs, map[string]interface{}{"x": int64(2)}) {
+			t.Fatalf("unexpected attrs on node %d for column 2: %v", i, attrs)
+		}
+	}
+
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `Delete()`}); err == nil || !strings.Contains(err.Error(), "Delete() requires an input row") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure an approximate TopN() query ranks rows using merged sketches.
+func TestExecutor_Execute_TopN_Approximate(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "n", pilosa.OptFieldTypeInt(0, 100))
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "x", pilosa.OptFieldTypeSet(pilosa.CacheTypeNone, 0))
+
+	// Row 1 is the top row in every shard, while rows 2 and 3 are each only
+	// a candidate in one shard but have more columns overall than row 4.
+	var buf strings.Builder
+	for shard := uint64(0); shard < 6; shard++ {
+		for col := uint64(0); col < 10; col++ {
+			fmt.Fprintf(&buf, "Set(%d, f=1)\n", shard*ShardWidth+col)
+		}
+		for col := uint64(0); col < 3+shard%2; col++ {
+			fmt.Fprintf(&buf, "Set(%d, f=%d)\n", shard*ShardWidth+col, 2+shard%2)
+		}
+	}
+	for col := uint64(0); col < 5; col++ {
+		fmt.Fprintf(&buf, "Set(%d, f=4)\n", 5*ShardWidth+col)
+	}
+	c.Query(t, "i", buf.String())
+	c[0].RecalculateCaches()
+
+	for _, tt := range []struct {
+		query    string
+		expected []pilosa.Pair
+	}{
+		{query: `TopN(f, n=1, approximate=true)`, expected: []pilosa.Pair{{ID: 1, Count: 60}}},
+		{query: `TopN(f, n=3, approximate=true)`, expected: []pilosa.Pair{{ID: 1, Count: 60}, {ID: 3, Count: 12}, {ID: 2, Count: 9}}},
+		{query: `TopN(f, approximate=true)`, expected: []pilosa.Pair{{ID: 1, Count: 60}, {ID: 3, Count: 12}, {ID: 2, Count: 9}, {ID: 4, Count: 5}}},
+		{query: `TopN(f, n=3, approximate=false)`, expected: []pilosa.Pair{{ID: 1, Count: 60}, {ID: 3, Count: 12}, {ID: 2, Count: 9}}},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if result := c.Query(t, "i", tt.query).Results[0]; !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("unexpected result: %s", spew.Sdump(result))
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `TopN(f, Row(f=1), n=2, approximate=true)`, err: "does not support an input bitmap"},
+		{query: `TopN(f, n=2, ids=[1,2], approximate=true)`, err: "does not support ids"},
+		{query: `TopN(f, n=2, threshold=5, approximate=true)`, err: "does not support threshold"},
+		{query: `TopN(n, n=2, approximate=true)`, err: "cannot compute TopN() on integer field"},
+		{query: `TopN(x, n=2, approximate=true)`, err: "field has no cache"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Ensure a CountApprox() query estimates unions of rows from merged sketches
+// and counts everything else exactly.
+func TestExecutor_Execute_CountApprox(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "t", pilosa.OptFieldTypeTime(pilosa.TimeQuantum("YMD")))
+
+	var buf strings.Builder
+	for shard := uint64(0); shard < 4; shard++ {
+		for col := uint64(0); col < 300; col++ {
+			id := shard*ShardWidth + col
+			fmt.Fprintf(&buf, "Set(%d, f=1)\n", id)
+			if col%3 == 0 {
+				fmt.Fprintf(&buf, "Set(%d, f=2)\n", id+1000)
+			}
+			fmt.Fprintf(&buf, "Set(%d, t=1, 2019-01-%02dT00:00)\n", id, 1+col%3)
+			fmt.Fprintf(&buf, "Set(%d, t=1, 2019-01-%02dT00:00)\n", id+col%2, 2+col%3)
+		}
+	}
+	c.Query(t, "i", buf.String())
+	c[0].RecalculateCaches()
+
+	// Estimates should be within four standard errors of the exact count.
+	approx := func(t *testing.T, q string) {
+		t.Helper()
+		exp := c.Query(t, "i", fmt.Sprintf("Count(%s)", q)).Results[0].(uint64)
+		for i := 0; i < 2; i++ {
+			n := c.Query(t, "i", fmt.Sprintf("CountApprox(%s)", q)).Results[0].(uint64)
+			if math.Abs(float64(n)-float64(exp)) > 0.065*float64(exp) {
+				t.Fatalf("unexpected estimate: %d, expected about %d", n, exp)
+			} else if !strings.HasPrefix(q, "Union") && n != exp {
+				t.Fatalf("unexpected count: %d, expected %d", n, exp)
+			}
+		}
+	}
+	for _, q := range []string{
+		`Row(f=1)`,
+		`Union(Row(f=1), Row(f=2))`,
+		`Row(t=1, from=2019-01-01T00:00, to=2019-01-05T00:00)`,
+		`Row(t=1, from=2019-01-02T00:00, to=2019-01-03T00:00)`,
+		`Union(Row(f=2), Row(t=1, from=2019-01-01T00:00, to=2019-01-02T00:00))`,
+		`Intersect(Row(f=1), Row(t=1, from=2019-01-01T00:00, to=2019-01-02T00:00))`,
+		`Row(f=3)`,
+	} {
+		t.Run(q, func(t *testing.T) { approx(t, q) })
+	}
+
+	// Sketches of rows are updated as bits are set, and rebuilt after bits
+	// are cleared.
+	t.Run("Changes", func(t *testing.T) {
+		q := `Union(Row(f=1), Row(f=2))`
+		buf.Reset()
+		for col := uint64(50000); col < 50500; col++ {
+			fmt.Fprintf(&buf, "Set(%d, f=2)\n", col)
+		}
+		c.Query(t, "i", buf.String())
+		approx(t, q)
+
+		c.Query(t, "i", strings.Replace(buf.String(), "Set(", "Clear(", -1))
+		approx(t, q)
+	})
+
+	if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `CountApprox()`}); err == nil || !strings.Contains(err.Error(), "CountApprox() requires an input bitmap") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// Ensure a Sample() query returns a reproducible sample of a row.
+func TestExecutor_Execute_Sample(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	counts := map[uint64]uint64{0: 100, 1: 200, 3: 50, 4: 400}
+	var buf strings.Builder
+	for shard, n := range counts {
+		for col := uint64(0); col < n; col++ {
+			fmt.Fprintf(&buf, "Set(%d, f=1)\n", shard*ShardWidth+col*7)
+		}
+	}
+	fmt.Fprintf(&buf, "Set(%d, f=2)\n", 2*ShardWidth)
+	c.Query(t, "i", buf.String())
+	all := c.Query(t, "i", `Row(f=1)`).Results[0].(*pilosa.Row)
+
+	sample := func(node int, q string) []uint64 {
+		t.Helper()
+		res, err := c[node].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Results[0].(*pilosa.Row).Columns()
+	}
+
+	cols := sample(0, `Sample(Row(f=1), n=100, seed=42)`)
+	if len(cols) != 100 {
+		t.Fatalf("unexpected sample size: %d", len(cols))
+	} else if n := pilosa.NewRow(cols...).Intersect(all).Count(); n != 100 {
+		t.Fatalf("sample is not a subset of the row: %d", n)
+	}
+
+	// Each shard is sampled in proportion to its count.
+	perShard := make(map[uint64]uint64)
+	for _, col := range cols {
+		perShard[col/ShardWidth]++
+	}
+	for shard, n := range counts {
+		if exp := float64(100*n) / 750; math.Abs(float64(perShard[shard])-exp) >= 1 {
+			t.Fatalf("unexpected sample size for shard %d: %d, expected about %v", shard, perShard[shard], exp)
+		}
+	}
+
+	// Samples are reproducible from any node, and differ between seeds.
+	if other := sample(1, `Sample(Row(f=1), n=100, seed=42)`); !reflect.DeepEqual(other, cols) {
+		t.Fatalf("unexpected sample from another node: %v", other)
+	} else if other := sample(2, `Sample(Row(f=1), n=100, seed=43)`); reflect.DeepEqual(other, cols) {
+		t.Fatal("expected a different sample for a different seed")
+	} else if other := sample(0, `Sample(Row(f=1), n=1000)`); !reflect.DeepEqual(other, all.Columns()) {
+		t.Fatalf("expected whole row, got %d columns", len(other))
+	} else if other := sample(0, `Sample(Row(f=3), n=10)`); len(other) != 0 {
+		t.Fatalf("unexpected sample of empty row: %v", other)
+	}
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `Sample(n=10)`, err: "Sample() requires an input bitmap"},
+		{query: `Sample(Row(f=1))`, err: "Sample() requires n greater than zero"},
+		{query: `Sample(Row(f=1), n=0)`, err: "Sample() requires n greater than zero"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "f")
+		c.Query(t, "k", `Set("a", f=1) Set("b", f=1) Set("c", f=1) Set("d", f=1) Set("e", f=2)`)
+
+		res := c.Query(t, "k", `Sample(Row(f=1), n=2, seed=7)`).Results[0].(*pilosa.Row)
+		if len(res.Keys) != 2 {
+			t.Fatalf("unexpected keys: %v", res.Keys)
+		}
+		for _, key := range res.Keys {
+			if key == "e" {
+				t.Fatalf("unexpected key in sample: %v", res.Keys)
+			}
+		}
+		if other := c.Query(t, "k", `Sample(Row(f=1), n=2, seed=7)`).Results[0].(*pilosa.Row); !reflect.DeepEqual(other.Keys, res.Keys) {
+			t.Fatalf("unexpected keys on repeat: %v != %v", other.Keys, res.Keys)
+		}
+	})
+}
+
+// Ensure a Similar() query scores every row of a field exactly.
+func TestExecutor_Execute_Similar(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(0, 100))
+
+	// Row 1 has ten columns in each of four shards. Row 3 only intersects
+	// row 1 in shard 0, but most of its columns are in shard 3.
+	var buf strings.Builder
+	for shard := uint64(0); shard < 4; shard++ {
+		for col := uint64(0); col < 10; col++ {
+			id := shard*ShardWidth + col
+			fmt.Fprintf(&buf, "Set(%d, f=1) Set(%d, f=4)\n", id, id)
+			if shard < 2 {
+				fmt.Fprintf(&buf, "Set(%d, f=2)\n", id)
+			}
+			if shard == 0 && col < 5 {
+				fmt.Fprintf(&buf, "Set(%d, f=3)\n", id)
+			}
+		}
+	}
+	for col := uint64(0); col < 100; col++ {
+		fmt.Fprintf(&buf, "Set(%d, f=3)\n", 3*ShardWidth+100+col)
+	}
+	for col := uint64(0); col < 10; col++ {
+		fmt.Fprintf(&buf, "Set(%d, f=4) Set(%d, f=5)\n", 2*ShardWidth+50+col, ShardWidth+500+col)
+	}
+	c.Query(t, "i", buf.String())
+
+	check := func(t *testing.T, res pilosa.SimilarRows, exp []pilosa.RowSimilarity) {
+		t.Helper()
+		if res.Count != 40 {
+			t.Fatalf("unexpected count: %d", res.Count)
+		} else if len(res.Rows) != len(exp) {
+			t.Fatalf("unexpected rows: %+v", res.Rows)
+		}
+		for i, r := range res.Rows {
+			if r.ID != exp[i].ID || r.Count != exp[i].Count || r.Intersection != exp[i].Intersection || math.Abs(r.Score-exp[i].Score) > 1e-9 {
+				t.Fatalf("unexpected row %d: %+v, expected %+v", i, r, exp[i])
+			}
+		}
+	}
+
+	t.Run("Jaccard", func(t *testing.T) {
+		res := c.Query(t, "i", `Similar(Row(f=1), field=f)`).Results[0].(pilosa.SimilarRows)
+		check(t, res, []pilosa.RowSimilarity{
+			{ID: 1, Count: 40, Intersection: 40, Score: 1},
+			{ID: 4, Count: 50, Intersection: 40, Score: 0.8},
+			{ID: 2, Count: 20, Intersection: 20, Score: 0.5},
+			{ID: 3, Count: 105, Intersection: 5, Score: 5.0 / 140},
+		})
+	})
+
+	t.Run("Cosine", func(t *testing.T) {
+		res := c.Query(t, "i", `Similar(Row(f=1), field=f, n=3, metric=cosine)`).Results[0].(pilosa.SimilarRows)
+		check(t, res, []pilosa.RowSimilarity{
+			{ID: 1, Count: 40, Intersection: 40, Score: 1},
+			{ID: 4, Count: 50, Intersection: 40, Score: 40 / math.Sqrt(40*50)},
+			{ID: 2, Count: 20, Intersection: 20, Score: 20 / math.Sqrt(40*20)},
+		})
+	})
+
+	t.Run("Overlap", func(t *testing.T) {
+		res := c.Query(t, "i", `Similar(Row(f=1), field=f, metric=overlap)`).Results[0].(pilosa.SimilarRows)
+		check(t, res, []pilosa.RowSimilarity{
+			{ID: 1, Count: 40, Intersection: 40, Score: 1},
+			{ID: 2, Count: 20, Intersection: 20, Score: 1},
+			{ID: 4, Count: 50, Intersection: 40, Score: 1},
+			{ID: 3, Count: 105, Intersection: 5, Score: 0.125},
+		})
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		res := c.Query(t, "i", `Similar(Row(f=9), field=f)`).Results[0].(pilosa.SimilarRows)
+		if res.Count != 0 || len(res.Rows) != 0 {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		c.CreateField(t, "k", pilosa.IndexOptions{Keys: true}, "f", pilosa.OptFieldKeys())
+		c.Query(t, "k", `Set("a", f="x") Set("b", f="x") Set("a", f="y") Set("c", f="y") Set("c", f="z")`)
+
+		res := c.Query(t, "k", `Similar(Row(f="x"), field=f)`).Results[0].(pilosa.SimilarRows)
+		if len(res.Rows) != 2 || res.Rows[0].Key != "x" || res.Rows[1].Key != "y" || res.Rows[1].Score != 1.0/3 {
+			t.Fatalf("unexpected rows: %+v", res.Rows)
+		}
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		c.CreateField(t, "b", pilosa.IndexOptions{}, "b", pilosa.OptFieldTypeBool())
+		c.Query(t, "b", `Set(1, b=true) Set(2, b=true) Set(3, b=false)`)
+
+		res := c.Query(t, "b", `Similar(Row(b=true), field=b)`).Results[0].(pilosa.SimilarRows)
+		if res.Count != 2 || len(res.Rows) != 1 || res.Rows[0].ID != 1 || res.Rows[0].Count != 2 || res.Rows[0].Score != 1 {
+			t.Fatalf("unexpected result: %+v", res)
+		}
+	})
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `Similar(field=f)`, err: "Similar() requires an input bitmap"},
+		{query: `Similar(Row(f=1))`, err: "Similar() field required"},
+		{query: `Similar(Row(f=1), field=x)`, err: "field not found"},
+		{query: `Similar(Row(f=1), field=v)`, err: "cannot compute Similar() on integer field"},
+		{query: `Similar(Row(f=1), field=f, metric=dice)`, err: "invalid similarity metric: dice"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// Ensure an Overlap() query counts the intersection and union of two rows.
+func TestExecutor_Execute_Overlap(t *testing.T) {
+	c := test.MustRunCluster(t, 3)
+	defer c.Close()
+	c.CreateField(t, "i", pilosa.IndexOptions{}, "f")
+
+	var buf strings.Builder
+	for shard := uint64(0); shard < 4; shard++ {
+		for col := uint64(0); col < 10; col++ {
+			fmt.Fprintf(&buf, "Set(%d, f=1)\n", shard*ShardWidth+col)
+		}
+	}
+	for col := uint64(0); col < 20; col++ {
+		fmt.Fprintf(&buf, "Set(%d, f=2)\n", 3*ShardWidth+col)
+	}
+	c.Query(t, "i", buf.String())
+
+	for _, tt := range []struct {
+		query string
+		exp   pilosa.Overlap
+	}{
+		{
+			query: `Overlap(Row(f=1), Row(f=2))`,
+			exp:   pilosa.Overlap{Counts: [2]uint64{40, 20}, Intersection: 10, Union: 50, Similarity: 0.2},
+		},
+		{
+			query: `Overlap(Row(f=1), Row(f=2), metric=overlap)`,
+			exp:   pilosa.Overlap{Counts: [2]uint64{40, 20}, Intersection: 10, Union: 50, Similarity: 0.5},
+		},
+		{
+			query: `Overlap(Row(f=2), Row(f=1), metric=cosine)`,
+			exp:   pilosa.Overlap{Counts: [2]uint64{20, 40}, Intersection: 10, Union: 50, Similarity: 10 / math.Sqrt(20*40)},
+		},
+		{
+			query: `Overlap(Row(f=1), Row(f=3))`,
+			exp:   pilosa.Overlap{Counts: [2]uint64{40, 0}, Union: 40},
+		},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if res := c.Query(t, "i", tt.query).Results[0].(pilosa.Overlap); !reflect.DeepEqual(res, tt.exp) {
+				t.Fatalf("unexpected result: %+v, expected %+v", res, tt.exp)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		query string
+		err   string
+	}{
+		{query: `Overlap(Row(f=1))`, err: "Overlap() requires two input bitmaps"},
+		{query: `Overlap(Row(f=1), Row(f=2), metric=dice)`, err: "invalid similarity metric: dice"},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: tt.query}); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error %q, got: %v", tt.err, err)
+			}
+		})
+	}
+}
+
 func TestExecutor_Execute_Query_Error(t *testing.T) {
 	c := test.MustRunCluster(t, 1)
 	defer c.Close()
@@ -3642,6 +5125,53 @@ func TestExecutor_Execute_GroupBy(t *testing.T) {
 			test.CheckGroupBy(t, expected, results)
 		})
 
+		t.Run("AggregateSum", func(t *testing.T) {
+			c.CreateField(t, "i", pilosa.IndexOptions{}, "v", pilosa.OptFieldTypeInt(-1000, 1000))
+			c.Query(t, "i", `
+				Set(0, v=10)
+				Set(1, v=-3)
+				Set(2, v=7)
+				Set(`+strconv.Itoa(ShardWidth+1)+`, v=100)
+				Set(`+strconv.Itoa(ShardWidth+2)+`, v=5)
+			`)
+
+			sum := func(v int64) *int64 { return &v }
+			expected := []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}, {Field: "sub", RowID: 100}}, Count: 3, Sum: sum(107)},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}, {Field: "sub", RowID: 110}}, Count: 1, Sum: sum(10)},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 11}, {Field: "sub", RowID: 110}}, Count: 1, Sum: sum(7)},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 12}, {Field: "sub", RowID: 110}}, Count: 1, Sum: sum(7)},
+			}
+
+			results := c.Query(t, "i", `GroupBy(Rows(general), Rows(sub), aggregate=Sum(field=v))`).Results[0].([]pilosa.GroupCount)
+			test.CheckGroupBy(t, expected, results)
+
+			expected = []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 10}}, Count: 3, Sum: sum(107)},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 11}}, Count: 2, Sum: sum(12)},
+				{Group: []pilosa.FieldRow{{Field: "general", RowID: 12}}, Count: 2, Sum: sum(12)},
+			}
+
+			results = c.Query(t, "i", `GroupBy(Rows(general), aggregate=Sum(field=v))`).Results[0].([]pilosa.GroupCount)
+			test.CheckGroupBy(t, expected, results)
+
+			// Sums which cancel to zero are still returned.
+			c.Query(t, "i", `Set(3, v=-107)`)
+			expected = []pilosa.GroupCount{
+				{Group: []pilosa.FieldRow{{Field: "sub", RowID: 100}}, Count: 4, Sum: sum(0)},
+				{Group: []pilosa.FieldRow{{Field: "sub", RowID: 110}}, Count: 2, Sum: sum(17)},
+			}
+			results = c.Query(t, "i", `GroupBy(Rows(sub), aggregate=Sum(field=v))`).Results[0].([]pilosa.GroupCount)
+			test.CheckGroupBy(t, expected, results)
+
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `GroupBy(Rows(general), aggregate=Sum(field=sub))`}); err == nil || !strings.Contains(err.Error(), "not an int field") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := c[0].API.Query(context.Background(), &pilosa.QueryRequest{Index: "i", Query: `GroupBy(Rows(general), aggregate=Count(Row(general=10)))`}); err == nil || !strings.Contains(err.Error(), "not a valid aggregate") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+
 		t.Run("check field offset no limit", func(t *testing.T) {
 			expected := []pilosa.GroupCount{
 				{Group: []pilosa.FieldRow{{Field: "general", RowID: 11}}, Count: 2},
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -54,11 +55,13 @@ const (
 
 // Field types.
 const (
-	FieldTypeSet   = "set"
-	FieldTypeInt   = "int"
-	FieldTypeTime  = "time"
-	FieldTypeMutex = "mutex"
-	FieldTypeBool  = "bool"
+	FieldTypeSet       = "set"
+	FieldTypeInt       = "int"
+	FieldTypeTime      = "time"
+	FieldTypeMutex     = "mutex"
+	FieldTypeBool      = "bool"
+	FieldTypeDecimal   = "decimal"
+	FieldTypeTimestamp = "timestamp"
 )
 
 // Field represents a container for views.
@@ -87,6 +90,13 @@ type Field struct {
 	logger logger.Logger
 
 	snapshotQueue chan *fragment
+	durability    string
+	changeLog     *ChangeLog
+
+	// rollupMu is held for writing while a time view is rolled up, and for
+	// reading by writes which may touch time views, so no write lands in a
+	// view between its merge into the coarser view and its deletion.
+	rollupMu sync.RWMutex
 }
 
 // FieldOption is a functional option type for pilosa.fieldOptions.
@@ -101,6 +111,57 @@ func OptFieldKeys() FieldOption {
 	}
 }
 
+// OptFieldForeignIndex is a functional option on FieldOptions
+// used to specify that the values of an int field are column IDs
+// in the given index. Values are translated through the column
+// keys of that index.
+func OptFieldForeignIndex(index string) FieldOption {
+	return func(fo *FieldOptions) error {
+		fo.ForeignIndex = index
+		return nil
+	}
+}
+
+// OptFieldTTL is a functional option on FieldOptions used to
+// specify how long the time views of a time field are retained.
+// Views whose time range ended more than ttl ago are deleted.
+func OptFieldTTL(ttl time.Duration) FieldOption {
+	return func(fo *FieldOptions) error {
+		if ttl < 0 {
+			return errors.New("ttl cannot be negative")
+		}
+		fo.TTL = ttl
+		return nil
+	}
+}
+
+// OptFieldRollup is a functional option on FieldOptions used to
+// specify the rollup policy of a time field. Time views which
+// outlive the policy are merged into coarser views and deleted.
+func OptFieldRollup(policy RollupPolicy) FieldOption {
+	return func(fo *FieldOptions) error {
+		if _, err := policy.retentions(); err != nil {
+			return err
+		}
+		fo.Rollup = policy
+		return nil
+	}
+}
+
+// OptFieldCache is a functional option on FieldOptions used to
+// specify the cache of a time field. Each time view keeps its own
+// cache so that TopN() can rank rows within a time range.
+func OptFieldCache(cacheType string, cacheSize uint32) FieldOption {
+	return func(fo *FieldOptions) error {
+		if !isValidCacheType(cacheType) {
+			return ErrInvalidCacheType
+		}
+		fo.CacheType = cacheType
+		fo.CacheSize = cacheSize
+		return nil
+	}
+}
+
 // OptFieldTypeDefault is a functional option on FieldOptions
 // used to set the field type and cache setting to the default values.
 func OptFieldTypeDefault() FieldOption {
@@ -149,6 +210,66 @@ func OptFieldTypeInt(min, max int64) FieldOption {
 	}
 }
 
+// OptFieldTypeDecimal is a functional option on FieldOptions
+// used to specify the field as being type `decimal` and to
+// provide any respective configuration values. Values are
+// stored as integers multiplied by 10^scale. The min and max
+// are clamped to the range which can be represented at scale.
+func OptFieldTypeDecimal(scale int64, min, max float64) FieldOption {
+	return func(fo *FieldOptions) error {
+		if fo.Type != "" {
+			return errors.Errorf("field type is already set to: %s", fo.Type)
+		}
+		if scale < 0 || scale > maxDecimalScale {
+			return ErrInvalidDecimalScale
+		}
+		if min > max {
+			return errors.New("decimal field min cannot be greater than max")
+		}
+		smin, err := scaleDecimal(min, scale, decimalRoundCeil)
+		if err != nil {
+			if math.IsNaN(min) {
+				return errors.Wrap(err, "scaling min")
+			}
+			smin = math.MinInt64
+		}
+		smax, err := scaleDecimal(max, scale, decimalRoundFloor)
+		if err != nil {
+			if math.IsNaN(max) {
+				return errors.Wrap(err, "scaling max")
+			}
+			smax = math.MaxInt64
+		}
+		fo.Type = FieldTypeDecimal
+		fo.Scale = scale
+		fo.Min = smin
+		fo.Max = smax
+		fo.Base = bsiBase(smin, smax)
+		return nil
+	}
+}
+
+// OptFieldTypeTimestamp is a functional option on FieldOptions
+// used to specify the field as being type `timestamp` and to
+// provide any respective configuration values. Values are
+// stored as the number of timeUnit units since the Unix epoch.
+func OptFieldTypeTimestamp(timeUnit string) FieldOption {
+	return func(fo *FieldOptions) error {
+		if fo.Type != "" {
+			return errors.Errorf("field type is already set to: %s", fo.Type)
+		}
+		if !isValidTimeUnit(timeUnit) {
+			return ErrInvalidTimeUnit
+		}
+		fo.Type = FieldTypeTimestamp
+		fo.TimeUnit = timeUnit
+		fo.Min = math.MinInt64
+		fo.Max = math.MaxInt64
+		fo.Base = bsiBase(fo.Min, fo.Max)
+		return nil
+	}
+}
+
 // OptFieldTypeTime is a functional option on FieldOptions
 // used to specify the field as being type `time` and to
 // provide any respective configuration values.
@@ -537,6 +658,11 @@ func (f *Field) loadMeta() error {
 	f.options.TimeQuantum = TimeQuantum(pb.TimeQuantum)
 	f.options.Keys = pb.Keys
 	f.options.NoStandardView = pb.NoStandardView
+	f.options.Scale = pb.Scale
+	f.options.TimeUnit = pb.TimeUnit
+	f.options.ForeignIndex = pb.ForeignIndex
+	f.options.TTL = time.Duration(pb.TTL)
+	f.options.Rollup = RollupPolicy(pb.Rollup)
 
 	return nil
 }
@@ -592,7 +718,7 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.BitDepth = 0
 		f.options.TimeQuantum = ""
 		f.options.Keys = opt.Keys
-	case FieldTypeInt:
+	case FieldTypeInt, FieldTypeDecimal, FieldTypeTimestamp:
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
 		f.options.CacheSize = 0
@@ -602,6 +728,9 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.BitDepth = opt.BitDepth
 		f.options.TimeQuantum = ""
 		f.options.Keys = opt.Keys
+		f.options.Scale = opt.Scale
+		f.options.TimeUnit = opt.TimeUnit
+		f.options.ForeignIndex = opt.ForeignIndex
 
 		// Create new bsiGroup.
 		bsig := &bsiGroup{
@@ -623,12 +752,18 @@ func (f *Field) applyOptions(opt FieldOptions) error {
 		f.options.Type = opt.Type
 		f.options.CacheType = CacheTypeNone
 		f.options.CacheSize = 0
+		if opt.CacheType != "" && opt.CacheType != CacheTypeNone {
+			f.options.CacheType = opt.CacheType
+			f.options.CacheSize = opt.CacheSize
+		}
 		f.options.Min = 0
 		f.options.Max = 0
 		f.options.Base = 0
 		f.options.BitDepth = 0
 		f.options.Keys = opt.Keys
 		f.options.NoStandardView = opt.NoStandardView
+		f.options.TTL = opt.TTL
+		f.options.Rollup = opt.Rollup
 		// Set the time quantum.
 		if err := f.setTimeQuantum(opt.TimeQuantum); err != nil {
 			f.Close()
@@ -679,6 +814,14 @@ func (f *Field) keys() bool {
 	return f.options.Keys
 }
 
+// foreignIndex returns the name of the index whose column keys
+// are used to translate the field's values.
+func (f *Field) foreignIndex() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.options.ForeignIndex
+}
+
 // bsiGroup returns a bsiGroup by name.
 func (f *Field) bsiGroup(name string) *bsiGroup {
 	f.mu.RLock()
@@ -763,6 +906,83 @@ func (f *Field) setTimeQuantum(q TimeQuantum) error {
 	return nil
 }
 
+// viewsByTimeRange returns the views to traverse to query a time range.
+// Views whose period has outlived the retention of their unit in the
+// field's rollup policy have been merged into the next coarser view, so
+// that view is traversed as well and results include its whole period.
+func (f *Field) viewsByTimeRange(start, end time.Time, q TimeQuantum, now time.Time) []string {
+	views := viewsByTimeRange(viewStandard, start, end, q)
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.options.Rollup == "" {
+		return views
+	}
+	retentions, err := f.options.Rollup.retentions()
+	if err != nil {
+		return views
+	}
+
+	other := make([]string, 0, len(views))
+	seen := make(map[string]struct{}, len(views))
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			other = append(other, name)
+		}
+	}
+	for _, name := range views {
+		v := name
+		for viewRolledUp(v, retentions, now) && parentTimeView(v) != "" {
+			// Bits written after the rollup stay in the finer view
+			// until the next rollup, so keep it while it exists.
+			if f.viewMap[v] != nil {
+				add(v)
+			}
+			v = parentTimeView(v)
+		}
+		add(v)
+	}
+	return other
+}
+
+// validateTimeRange returns an error if querying the time range from start
+// to end traverses a view whose period extends beyond the range. This is the
+// case once the views of the range have been rolled up into a coarser view,
+// so that the range can no longer be counted on its own, such as an hour of
+// a day whose hourly views have been merged into the daily view.
+func (f *Field) validateTimeRange(start, end time.Time, q TimeQuantum, now time.Time) error {
+	for _, name := range f.viewsByTimeRange(start, end, q, now) {
+		vstart, err := timeOfView(name, false)
+		if err != nil {
+			return errors.Wrapf(err, "getting start time of view: %s", name)
+		}
+		vend, err := timeOfView(name, true)
+		if err != nil {
+			return errors.Wrapf(err, "getting end time of view: %s", name)
+		}
+		if vstart.Before(start) || vend.After(end) {
+			return fmt.Errorf("time range from %s to %s of field %q has been rolled up into view %s", start.Format(TimeFormat), end.Format(TimeFormat), f.name, name)
+		}
+	}
+	return nil
+}
+
+// viewRolledUp returns true if the period of the named time view ended more
+// than the retention of its unit before now.
+func viewRolledUp(name string, retentions map[rune]time.Duration, now time.Time) bool {
+	units := map[int]rune{10: 'H', 8: 'D', 6: 'M', 4: 'Y'}
+	d, ok := retentions[units[len(viewTimePart(name))]]
+	if !ok {
+		return false
+	}
+	end, err := timeOfView(name, true)
+	if err != nil {
+		return false
+	}
+	return !end.After(now.Add(-d))
+}
+
 // RowTime gets the row at the particular time with the granularity specified by
 // the quantum.
 func (f *Field) RowTime(rowID uint64, time time.Time, quantum string) (*Row, error) {
@@ -861,11 +1081,16 @@ func (f *Field) newView(path, name string) *view {
 	view.stats = f.Stats
 	view.broadcaster = f.broadcaster
 	view.snapshotQueue = f.snapshotQueue
+	view.durability = f.durability
+	view.changeLog = f.changeLog
 	return view
 }
 
 // deleteView removes the view from the field.
 func (f *Field) deleteView(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	view := f.viewMap[name]
 	if view == nil {
 		return ErrInvalidView
@@ -886,6 +1111,98 @@ func (f *Field) deleteView(name string) error {
 	return nil
 }
 
+// rollupCandidates returns the names of the field's time views which have
+// outlived the retention of their unit in the field's rollup policy.
+func (f *Field) rollupCandidates(now time.Time) []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.options.Type != FieldTypeTime || f.options.Rollup == "" {
+		return nil
+	}
+	retentions, err := f.options.Rollup.retentions()
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for name := range f.viewMap {
+		if strings.HasPrefix(name, viewStandard+"_") && viewRolledUp(name, retentions, now) {
+			names = append(names, name)
+		}
+	}
+	// Roll up finer views first so their data reaches the coarsest view.
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// rollupView merges every fragment of a time view into the view of the next
+// coarser unit, then deletes the view. Writes to the field are blocked until
+// the view is deleted.
+func (f *Field) rollupView(name string) error {
+	f.rollupMu.Lock()
+	defer f.rollupMu.Unlock()
+
+	view := f.view(name)
+	if view == nil {
+		return nil
+	}
+	parentName := parentTimeView(name)
+	if parentName == "" {
+		return errors.Errorf("no coarser view to roll up into: %s", name)
+	}
+	parent, err := f.createViewIfNotExists(parentName)
+	if err != nil {
+		return errors.Wrap(err, "creating parent view")
+	}
+	for _, frag := range view.allFragments() {
+		other, err := parent.CreateFragmentIfNotExists(frag.shard)
+		if err != nil {
+			return errors.Wrap(err, "creating parent fragment")
+		}
+		if err := other.unionFragment(frag); err != nil {
+			return errors.Wrapf(err, "merging fragment: shard=%d", frag.shard)
+		}
+	}
+	if err := f.deleteView(name); err != nil && err != ErrInvalidView {
+		return errors.Wrap(err, "deleting view")
+	}
+	return nil
+}
+
+// expiredViews returns the names of the field's time views whose time
+// range ended more than the field's TTL before now.
+func (f *Field) expiredViews(now time.Time) []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.options.Type != FieldTypeTime || f.options.TTL <= 0 {
+		return nil
+	}
+	cutoff := now.Add(-f.options.TTL)
+
+	var names []string
+	for name := range f.viewMap {
+		if !strings.HasPrefix(name, viewStandard+"_") {
+			continue
+		}
+		end, err := timeOfView(name, true)
+		if err != nil {
+			continue
+		}
+		if !end.After(cutoff) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Row returns a row of the standard view.
 // It seems this method is only being used by the test
 // package, and the fact that it's only allowed on
@@ -925,6 +1242,9 @@ func (f *Field) SetBit(rowID, colID uint64, t *time.Time) (changed bool, err err
 		return changed, nil
 	}
 
+	f.rollupMu.RLock()
+	defer f.rollupMu.RUnlock()
+
 	// If a timestamp is specified then set bits across all views for the quantum.
 	for _, subname := range viewsByTime(viewName, *t, f.TimeQuantum()) {
 		view, err := f.createViewIfNotExists(subname)
@@ -946,6 +1266,9 @@ func (f *Field) SetBit(rowID, colID uint64, t *time.Time) (changed bool, err err
 func (f *Field) ClearBit(rowID, colID uint64) (changed bool, err error) {
 	viewName := viewStandard
 
+	f.rollupMu.RLock()
+	defer f.rollupMu.RUnlock()
+
 	// Retrieve view. Exit if it doesn't exist.
 	view, present := f.viewMap[viewName]
 	if !present {
@@ -1092,7 +1415,55 @@ func (f *Field) SetValue(columnID uint64, value int64) (changed bool, err error)
 		return false, errors.Wrap(err, "creating view")
 	}
 
-	return view.setValue(columnID, bsig.BitDepth, baseValue)
+	// The change is logged by the fragment so that events are logged in
+	// the order values are applied.
+	return view.setValue(columnID, bsig.BitDepth, baseValue, f.valueEvents(ChangeEventSetValue, view.name, []uint64{columnID}, []int64{value})...)
+}
+
+// logRowAttrs logs an event for each set of row attributes in m, if change
+// data capture is enabled.
+func (f *Field) logRowAttrs(m map[uint64]map[string]interface{}) error {
+	if f.changeLog == nil {
+		return nil
+	}
+	rowIDs := make([]uint64, 0, len(m))
+	for rowID := range m {
+		rowIDs = append(rowIDs, rowID)
+	}
+	sort.Sort(uint64Slice(rowIDs))
+
+	events := make([]ChangeEvent, len(rowIDs))
+	for i, rowID := range rowIDs {
+		events[i] = ChangeEvent{
+			Type:  ChangeEventSetRowAttrs,
+			Index: f.index,
+			Field: f.name,
+			Row:   rowID,
+			Attrs: m[rowID],
+		}
+	}
+	return errors.Wrap(f.changeLog.append(events...), "logging changes")
+}
+
+// valueEvents returns an event of the given type for each column value, if
+// change data capture is enabled.
+func (f *Field) valueEvents(typ, viewName string, columnIDs []uint64, values []int64) []ChangeEvent {
+	if f.changeLog == nil {
+		return nil
+	}
+	events := make([]ChangeEvent, len(columnIDs))
+	for i := range columnIDs {
+		events[i] = ChangeEvent{
+			Type:   typ,
+			Index:  f.index,
+			Field:  f.name,
+			View:   viewName,
+			Shard:  columnIDs[i] / ShardWidth,
+			Column: columnIDs[i],
+			Value:  values[i],
+		}
+	}
+	return events
 }
 
 // Sum returns the sum and count for a field.
@@ -1240,6 +1611,9 @@ func (f *Field) Import(rowIDs, columnIDs []uint64, timestamps []*time.Time, opts
 		}
 	}
 
+	f.rollupMu.RLock()
+	defer f.rollupMu.RUnlock()
+
 	// Import into each fragment.
 	for key, data := range dataByFragment {
 		view, err := f.createViewIfNotExists(key.View)
@@ -1338,7 +1712,11 @@ func (f *Field) importValue(columnIDs []uint64, values []int64, options *ImportO
 			baseValues[i] = value - bsig.Base
 		}
 
-		if err := frag.importValue(data.ColumnIDs, baseValues, requiredDepth, options.Clear); err != nil {
+		typ := ChangeEventSetValue
+		if options.Clear {
+			typ = ChangeEventClearValue
+		}
+		if err := frag.importValue(data.ColumnIDs, baseValues, requiredDepth, options.Clear, f.valueEvents(typ, key.View, data.ColumnIDs, data.Values)...); err != nil {
 			return err
 		}
 	}
@@ -1354,6 +1732,10 @@ func (f *Field) importRoaring(ctx context.Context, data []byte, shard uint64, vi
 		viewName = viewStandard
 	}
 	span.LogKV("view", viewName, "bytes", len(data), "shard", shard)
+
+	f.rollupMu.RLock()
+	defer f.rollupMu.RUnlock()
+
 	view, err := f.createViewIfNotExists(viewName)
 	if err != nil {
 		return errors.Wrap(err, "creating view")
@@ -1392,16 +1774,21 @@ func (p fieldInfoSlice) Less(i, j int) bool { return p[i].Name < p[j].Name }
 
 // FieldOptions represents options to set when initializing a field.
 type FieldOptions struct {
-	Base           int64       `json:"base,omitempty"`
-	BitDepth       uint        `json:"bitDepth,omitempty"`
-	Min            int64       `json:"min,omitempty"`
-	Max            int64       `json:"max,omitempty"`
-	Keys           bool        `json:"keys"`
-	NoStandardView bool        `json:"noStandardView,omitempty"`
-	CacheSize      uint32      `json:"cacheSize,omitempty"`
-	CacheType      string      `json:"cacheType,omitempty"`
-	Type           string      `json:"type,omitempty"`
-	TimeQuantum    TimeQuantum `json:"timeQuantum,omitempty"`
+	Base           int64         `json:"base,omitempty"`
+	BitDepth       uint          `json:"bitDepth,omitempty"`
+	Min            int64         `json:"min,omitempty"`
+	Max            int64         `json:"max,omitempty"`
+	Keys           bool          `json:"keys"`
+	NoStandardView bool          `json:"noStandardView,omitempty"`
+	CacheSize      uint32        `json:"cacheSize,omitempty"`
+	CacheType      string        `json:"cacheType,omitempty"`
+	Type           string        `json:"type,omitempty"`
+	TimeQuantum    TimeQuantum   `json:"timeQuantum,omitempty"`
+	Scale          int64         `json:"scale,omitempty"`
+	TimeUnit       string        `json:"timeUnit,omitempty"`
+	ForeignIndex   string        `json:"foreignIndex,omitempty"`
+	TTL            time.Duration `json:"ttl,omitempty"`
+	Rollup         RollupPolicy  `json:"rollup,omitempty"`
 }
 
 // applyDefaultOptions returns a new FieldOptions object
@@ -1417,6 +1804,18 @@ func applyDefaultOptions(o FieldOptions) FieldOptions {
 	return o
 }
 
+// DecimalMin returns the minimum value of a decimal field
+// with its fractional digits.
+func (o *FieldOptions) DecimalMin() string {
+	return formatDecimal(o.Min, o.Scale)
+}
+
+// DecimalMax returns the maximum value of a decimal field
+// with its fractional digits.
+func (o *FieldOptions) DecimalMax() string {
+	return formatDecimal(o.Max, o.Scale)
+}
+
 // encode converts o into its internal representation.
 func (o *FieldOptions) encode() *internal.FieldOptions {
 	return encodeFieldOptions(o)
@@ -1437,6 +1836,11 @@ func encodeFieldOptions(o *FieldOptions) *internal.FieldOptions {
 		TimeQuantum:    string(o.TimeQuantum),
 		Keys:           o.Keys,
 		NoStandardView: o.NoStandardView,
+		Scale:          o.Scale,
+		TimeUnit:       o.TimeUnit,
+		ForeignIndex:   o.ForeignIndex,
+		TTL:            int64(o.TTL),
+		Rollup:         string(o.Rollup),
 	}
 }
 
@@ -1459,12 +1863,13 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 		})
 	case FieldTypeInt:
 		return json.Marshal(struct {
-			Type     string `json:"type"`
-			Base     int64  `json:"base"`
-			BitDepth uint   `json:"bitDepth"`
-			Min      int64  `json:"min"`
-			Max      int64  `json:"max"`
-			Keys     bool   `json:"keys"`
+			Type         string `json:"type"`
+			Base         int64  `json:"base"`
+			BitDepth     uint   `json:"bitDepth"`
+			Min          int64  `json:"min"`
+			Max          int64  `json:"max"`
+			Keys         bool   `json:"keys"`
+			ForeignIndex string `json:"foreignIndex,omitempty"`
 		}{
 			o.Type,
 			o.Base,
@@ -1472,18 +1877,62 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 			o.Min,
 			o.Max,
 			o.Keys,
+			o.ForeignIndex,
+		})
+	case FieldTypeDecimal:
+		return json.Marshal(struct {
+			Type     string      `json:"type"`
+			Base     json.Number `json:"base"`
+			BitDepth uint        `json:"bitDepth"`
+			Scale    int64       `json:"scale"`
+			Min      json.Number `json:"min"`
+			Max      json.Number `json:"max"`
+			Keys     bool        `json:"keys"`
+		}{
+			o.Type,
+			json.Number(formatDecimal(o.Base, o.Scale)),
+			o.BitDepth,
+			o.Scale,
+			json.Number(formatDecimal(o.Min, o.Scale)),
+			json.Number(formatDecimal(o.Max, o.Scale)),
+			o.Keys,
+		})
+	case FieldTypeTimestamp:
+		return json.Marshal(struct {
+			Type     string `json:"type"`
+			TimeUnit string `json:"timeUnit"`
+		}{
+			o.Type,
+			o.TimeUnit,
 		})
 	case FieldTypeTime:
+		var ttl string
+		if o.TTL != 0 {
+			ttl = o.TTL.String()
+		}
+		var cacheType string
+		var cacheSize uint32
+		if o.CacheType != CacheTypeNone {
+			cacheType, cacheSize = o.CacheType, o.CacheSize
+		}
 		return json.Marshal(struct {
-			Type           string      `json:"type"`
-			TimeQuantum    TimeQuantum `json:"timeQuantum"`
-			Keys           bool        `json:"keys"`
-			NoStandardView bool        `json:"noStandardView"`
+			Type           string       `json:"type"`
+			TimeQuantum    TimeQuantum  `json:"timeQuantum"`
+			Keys           bool         `json:"keys"`
+			NoStandardView bool         `json:"noStandardView"`
+			TTL            string       `json:"ttl,omitempty"`
+			Rollup         RollupPolicy `json:"rollup,omitempty"`
+			CacheType      string       `json:"cacheType,omitempty"`
+			CacheSize      uint32       `json:"cacheSize,omitempty"`
 		}{
 			o.Type,
 			o.TimeQuantum,
 			o.Keys,
 			o.NoStandardView,
+			ttl,
+			o.Rollup,
+			cacheType,
+			cacheSize,
 		})
 	case FieldTypeMutex:
 		return json.Marshal(struct {
@@ -1507,6 +1956,51 @@ func (o *FieldOptions) MarshalJSON() ([]byte, error) {
 	return nil, errors.New("invalid field type")
 }
 
+// UnmarshalJSON unmarshals field options from the type-specific
+// representation written by MarshalJSON.
+func (o *FieldOptions) UnmarshalJSON(data []byte) error {
+	type fieldOptions FieldOptions
+	var aux struct {
+		fieldOptions
+		Base json.Number `json:"base,omitempty"`
+		Min  json.Number `json:"min,omitempty"`
+		Max  json.Number `json:"max,omitempty"`
+		TTL  string      `json:"ttl,omitempty"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*o = FieldOptions(aux.fieldOptions)
+
+	// Decimal bounds are written as fractional values of the field's scale.
+	for _, v := range []struct {
+		s json.Number
+		p *int64
+	}{{aux.Base, &o.Base}, {aux.Min, &o.Min}, {aux.Max, &o.Max}} {
+		if v.s == "" {
+			continue
+		}
+		var err error
+		if o.Type == FieldTypeDecimal {
+			*v.p, err = parseDecimal(string(v.s), o.Scale, decimalRoundNearest)
+		} else {
+			*v.p, err = v.s.Int64()
+		}
+		if err != nil {
+			return errors.Wrap(err, "parsing field options")
+		}
+	}
+
+	if aux.TTL != "" {
+		ttl, err := time.ParseDuration(aux.TTL)
+		if err != nil {
+			return errors.Wrap(err, "parsing ttl")
+		}
+		o.TTL = ttl
+	}
+	return nil
+}
+
 // List of bsiGroup types.
 const (
 	bsiGroupTypeInt = "int"
@@ -15,11 +15,13 @@
 package pilosa
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -189,6 +191,119 @@ func TestField_DeleteView(t *testing.T) {
 	}
 }
 
+// Ensure time views are expired once their time range is older than the TTL.
+func TestField_ExpiredViews(t *testing.T) {
+	f := MustOpenField(func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("YMD"))(fo); err != nil {
+			return err
+		}
+		return OptFieldTTL(48 * time.Hour)(fo)
+	})
+	defer f.Close()
+
+	f.MustSetBit(1, 1, time.Date(2018, time.December, 31, 12, 0, 0, 0, time.UTC))
+	f.MustSetBit(1, 2, time.Date(2019, time.January, 3, 12, 0, 0, 0, time.UTC))
+
+	now := time.Date(2019, time.January, 5, 0, 0, 0, 0, time.UTC)
+	if names := f.expiredViews(now); !reflect.DeepEqual(names, []string{"standard_2018", "standard_201812", "standard_20181231"}) {
+		t.Fatalf("unexpected expired views: %v", names)
+	}
+
+	// A view expires when its whole range is older than the TTL.
+	now = now.Add(24 * time.Hour)
+	if names := f.expiredViews(now); !reflect.DeepEqual(names, []string{"standard_2018", "standard_201812", "standard_20181231", "standard_20190103"}) {
+		t.Fatalf("unexpected expired views: %v", names)
+	}
+
+	// Reload field and verify that the TTL is persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if ttl := f.Options().TTL; ttl != 48*time.Hour {
+		t.Fatalf("unexpected ttl (reopen): %s", ttl)
+	}
+}
+
+// Ensure old time views are merged into coarser views and queries fall back
+// to the coarser views.
+func TestField_RollupViews(t *testing.T) {
+	f := MustOpenField(func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("DH"))(fo); err != nil {
+			return err
+		}
+		return OptFieldRollup("H:48h")(fo)
+	})
+	defer f.Close()
+
+	// Write timestamped bits, which set both the hourly and daily views.
+	for col, ts := range map[uint64]time.Time{
+		1:              time.Date(2019, time.January, 2, 3, 0, 0, 0, time.UTC),
+		ShardWidth + 2: time.Date(2019, time.January, 2, 4, 0, 0, 0, time.UTC),
+		3:              time.Date(2019, time.January, 3, 5, 0, 0, 0, time.UTC),
+	} {
+		ts := ts
+		if _, err := f.SetBit(1, col, &ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Write a bit to an hourly view only so the rollup has to merge it.
+	if view, err := f.createViewIfNotExists("standard_2019010202"); err != nil {
+		t.Fatal(err)
+	} else if _, err := view.setBit(1, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2019, time.January, 4, 5, 0, 0, 0, time.UTC)
+	names := f.rollupCandidates(now)
+	if !reflect.DeepEqual(names, []string{"standard_2019010202", "standard_2019010203", "standard_2019010204"}) {
+		t.Fatalf("unexpected rollup candidates: %v", names)
+	}
+	for _, name := range names {
+		if err := f.rollupView(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if f.view("standard_2019010203") != nil || f.view("standard_2019010204") != nil || f.view("standard_2019010202") != nil {
+		t.Fatal("expected hourly views to be deleted")
+	} else if r := f.view("standard_20190102").row(1); !reflect.DeepEqual(r.Columns(), []uint64{1, 4, ShardWidth + 2}) {
+		t.Fatalf("unexpected columns: %v", r.Columns())
+	}
+
+	// Rolled up hours are queried through their daily view, while hours
+	// within the retention are not, even if they have no view.
+	views := f.viewsByTimeRange(time.Date(2019, time.January, 2, 3, 0, 0, 0, time.UTC), time.Date(2019, time.January, 3, 6, 0, 0, 0, time.UTC), "DH", now)
+	exp := []string{"standard_20190102"}
+	for ts := time.Date(2019, time.January, 2, 5, 0, 0, 0, time.UTC); ts.Hour() != 6 || ts.Day() != 3; ts = ts.Add(time.Hour) {
+		exp = append(exp, "standard_"+ts.Format("2006010215"))
+	}
+	if !reflect.DeepEqual(views, exp) {
+		t.Fatalf("unexpected views: %v", views)
+	}
+
+	// A bit written to a rolled up hour is read from its hourly view as
+	// well until the next rollup.
+	ts := time.Date(2019, time.January, 2, 1, 0, 0, 0, time.UTC)
+	if _, err := f.SetBit(1, 5, &ts); err != nil {
+		t.Fatal(err)
+	}
+	views = f.viewsByTimeRange(time.Date(2019, time.January, 2, 1, 0, 0, 0, time.UTC), time.Date(2019, time.January, 2, 2, 0, 0, 0, time.UTC), "DH", now)
+	if exp := []string{"standard_2019010201", "standard_20190102"}; !reflect.DeepEqual(views, exp) {
+		t.Fatalf("unexpected views: %v", views)
+	}
+
+	// Rolled up hours can no longer be counted on their own, while their
+	// days and the hours within the retention can.
+	hour := time.Date(2019, time.January, 2, 3, 0, 0, 0, time.UTC)
+	if err := f.validateTimeRange(hour, hour.Add(time.Hour), "DH", now); err == nil || !strings.Contains(err.Error(), "rolled up into view standard_20190102") {
+		t.Fatalf("expected rolled up error, got: %v", err)
+	} else if err := f.validateTimeRange(hour.Truncate(24*time.Hour), hour.Truncate(24*time.Hour).AddDate(0, 0, 1), "DH", now); err != nil {
+		t.Fatal(err)
+	} else if err := f.validateTimeRange(hour.Add(26*time.Hour), hour.Add(27*time.Hour), "DH", now); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestField represents a test wrapper for Field.
 type TestField struct {
 	*Field
@@ -438,3 +553,62 @@ func TestBSIGroup_BaseDefaultValue(t *testing.T) {
 		}
 	}
 }
+
+func TestField_Decimal(t *testing.T) {
+	f := MustOpenField(OptFieldTypeDecimal(2, -10.5, math.Inf(1)))
+	defer f.Close()
+
+	if opt := f.Options(); opt.Scale != 2 || opt.Min != -1050 || opt.Max != math.MaxInt64 {
+		t.Fatalf("unexpected options: %+v", opt)
+	}
+
+	// Reload field and verify that the scale is persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if opt := f.Options(); opt.Scale != 2 || opt.Min != -1050 {
+		t.Fatalf("unexpected options (reopen): %+v", opt)
+	}
+
+	opt := f.Options()
+	if buf, err := json.Marshal(&opt); err != nil {
+		t.Fatal(err)
+	} else if string(buf) != `{"type":"decimal","base":0.00,"bitDepth":63,"scale":2,"min":-10.50,"max":92233720368547758.07,"keys":false}` {
+		t.Fatalf("unexpected json: %s", buf)
+	}
+
+	var other FieldOptions
+	if buf, err := json.Marshal(&opt); err != nil {
+		t.Fatal(err)
+	} else if err := json.Unmarshal(buf, &other); err != nil {
+		t.Fatal(err)
+	} else if other.Type != FieldTypeDecimal || other.Scale != 2 || other.Min != -1050 || other.Max != math.MaxInt64 {
+		t.Fatalf("unexpected options (unmarshal): %+v", other)
+	}
+
+	if err := OptFieldTypeDecimal(19, 0, 1)(&FieldOptions{}); err != ErrInvalidDecimalScale {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestField_Timestamp(t *testing.T) {
+	f := MustOpenField(OptFieldTypeTimestamp(TimeUnitMicroseconds))
+	defer f.Close()
+
+	// Reload field and verify that the unit is persisted.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if opt := f.Options(); opt.TimeUnit != TimeUnitMicroseconds || opt.Min != math.MinInt64 || opt.Max != math.MaxInt64 {
+		t.Fatalf("unexpected options (reopen): %+v", opt)
+	}
+
+	opt := f.Options()
+	if buf, err := json.Marshal(&opt); err != nil {
+		t.Fatal(err)
+	} else if string(buf) != `{"type":"timestamp","timeUnit":"us"}` {
+		t.Fatalf("unexpected json: %s", buf)
+	}
+
+	if err := OptFieldTypeTimestamp("h")(&FieldOptions{}); err != ErrInvalidTimeUnit {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
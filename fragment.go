@@ -27,6 +27,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -38,6 +39,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/pilosa/pilosa/internal"
 	"github.com/pilosa/pilosa/logger"
+	"github.com/pilosa/pilosa/lru"
 	"github.com/pilosa/pilosa/pql"
 	"github.com/pilosa/pilosa/roaring"
 	"github.com/pilosa/pilosa/shardwidth"
@@ -83,6 +85,10 @@ const (
 	// defaultFragmentMaxOpN is the default value for Fragment.MaxOpN.
 	defaultFragmentMaxOpN = 10000
 
+	// importRoaringBatchSize is the number of positions decoded at a time
+	// when a roaring import is recorded in the change log.
+	importRoaringBatchSize = 1 << 16
+
 	// Row ids used for boolean fields.
 	falseRowID = uint64(0)
 	trueRowID  = uint64(1)
@@ -96,6 +102,18 @@ const (
 	roaringFlagBSIv2 = 0x01 // indicates version using low bit for existence
 )
 
+// Durability modes determine when fragment mutations are synced to disk.
+const (
+	// DurabilityNone leaves syncing to the operating system.
+	DurabilityNone = "none"
+
+	// DurabilityInterval syncs mutations to disk periodically.
+	DurabilityInterval = "interval"
+
+	// DurabilityAlways syncs mutations to disk before they are acknowledged.
+	DurabilityAlways = "always"
+)
+
 // fragment represents the intersection of a field and shard in an index.
 type fragment struct {
 	mu sync.RWMutex
@@ -122,6 +140,14 @@ type fragment struct {
 	snapshotCond       sync.Cond
 	snapshotDelays     int
 	snapshotDelayTime  time.Duration
+	dirty              bool // set when ops have been written since the last sync
+	unlogged           bool // set when mutations which bypass the op log have not been snapshotted
+
+	// Durability mode which determines when mutations are synced to disk.
+	durability string
+
+	// Records mutations for change data capture, if enabled.
+	changeLog *ChangeLog
 
 	// Cache for row counts.
 	CacheType string // passed in by field
@@ -134,6 +160,9 @@ type fragment struct {
 	// Cache containing full rows (not just counts).
 	rowCache bitmapCache
 
+	// HyperLogLog sketches of rows in the count cache, by row ID.
+	sketches *lru.Cache
+
 	// Cached checksums for each block.
 	checksums map[int][]byte
 
@@ -365,6 +394,7 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 		// there's nothing here, we're not going to try to unmarshal it.
 		unmarshalData = false
 		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+		f.sketches = nil
 	} else {
 		// Mmap the underlying file so it can be zero copied.
 		data, err = syswrap.Mmap(int(f.file.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
@@ -409,9 +439,13 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 			if e2 != nil {
 				return fmt.Errorf("unmarshal storage: file=%s, err=%s, clearing old mapping also failed: %v", f.file.Name(), err, e2)
 			}
+			if opErr, ok := errors.Cause(err).(*roaring.OpLogError); ok {
+				return f.recoverOpLog(opErr)
+			}
 			return fmt.Errorf("unmarshal storage: file=%s, err=%s", f.file.Name(), err)
 		}
 		f.rowCache = &simpleCache{make(map[uint64]*Row)}
+		f.sketches = nil
 		f.ops, f.opN = f.storage.Ops()
 	} else {
 		// we're moving to new storage, so instead of using the OpN
@@ -457,6 +491,32 @@ func (f *fragment) openStorage(unmarshalData bool) error {
 	return lastError
 }
 
+// recoverOpLog truncates the op log at an op which could not be read and
+// reopens the storage. This is only done when the op is the last one in the
+// log, as when the server stopped while the op was being written, in which
+// case the op was never acknowledged. An unreadable op followed by others
+// means the log is corrupt, so an error is returned rather than discarding
+// acknowledged ops.
+func (f *fragment) recoverOpLog(opErr *roaring.OpLogError) error {
+	if !opErr.Torn {
+		return fmt.Errorf("unmarshal storage: file=%s, corrupt op log: %s", f.file.Name(), opErr)
+	}
+	f.Logger.Printf("fragment: truncating op log after unreadable op: path=%s, offset=%d, err=%s", f.path, opErr.Offset, opErr.Err)
+
+	if err := f.file.Truncate(int64(opErr.Offset)); err != nil {
+		return errors.Wrap(err, "truncating op log")
+	} else if err := f.safeClose(); err != nil {
+		return errors.Wrap(err, "closing storage")
+	}
+
+	// Reopen from scratch; the truncated log is only read once.
+	f.storage = nil
+	if err := f.openStorage(true); err != nil {
+		return errors.Wrap(err, "reopening storage")
+	}
+	return nil
+}
+
 // openCache initializes the cache from row ids persisted to disk.
 func (f *fragment) openCache() error {
 	// Determine cache type from field name.
@@ -554,6 +614,7 @@ func (f *fragment) safeClose() error {
 		if err := f.file.Sync(); err != nil {
 			return fmt.Errorf("sync: %s", err)
 		}
+		f.dirty = false
 		if err := syscall.Flock(int(f.file.Fd()), syscall.LOCK_UN); err != nil {
 			return fmt.Errorf("unlock: %s", err)
 		}
@@ -662,7 +723,14 @@ func (f *fragment) setBit(rowID, columnID uint64) (changed bool, err error) {
 		}
 	}
 
-	return f.unprotectedSetBit(rowID, columnID)
+	if changed, err = f.unprotectedSetBit(rowID, columnID); err != nil {
+		return changed, err
+	} else if changed {
+		if err := f.logChanges(f.changeEvent(ChangeEventSet, rowID, columnID)); err != nil {
+			return changed, err
+		}
+	}
+	return changed, f.commit()
 }
 
 // handleMutex will clear an existing row and store the new row
@@ -671,8 +739,10 @@ func (f *fragment) handleMutex(rowID, columnID uint64) error {
 	if existingRowID, found, err := f.mutexVector.Get(columnID); err != nil {
 		return errors.Wrap(err, "getting mutex vector data")
 	} else if found && existingRowID != rowID {
-		if _, err := f.unprotectedClearBit(existingRowID, columnID); err != nil {
+		if changed, err := f.unprotectedClearBit(existingRowID, columnID); err != nil {
 			return errors.Wrap(err, "clearing mutex value")
+		} else if changed {
+			return f.logChanges(f.changeEvent(ChangeEventClear, existingRowID, columnID))
 		}
 	}
 	return nil
@@ -713,6 +783,13 @@ func (f *fragment) unprotectedSetBit(rowID, columnID uint64) (changed bool, err
 	// a new copy if no one's reading it.
 	f.rowCache.Add(rowID, nil)
 
+	// Keep the row's sketch, if any, in step with the new bit.
+	if f.sketches != nil {
+		if v, _ := f.sketches.Get(rowID); v != nil {
+			v.(hyperLogLog).add(columnID)
+		}
+	}
+
 	f.stats.Count("setBit", 1, 0.001)
 
 	// Update row count if they have increased.
@@ -736,7 +813,15 @@ func (f *fragment) clearBit(rowID, columnID uint64) (bool, error) {
 	if mustClose {
 		defer f.safeClose()
 	}
-	return f.unprotectedClearBit(rowID, columnID)
+	changed, err := f.unprotectedClearBit(rowID, columnID)
+	if err != nil {
+		return changed, err
+	} else if changed {
+		if err := f.logChanges(f.changeEvent(ChangeEventClear, rowID, columnID)); err != nil {
+			return changed, err
+		}
+	}
+	return changed, f.commit()
 }
 
 // unprotectedClearBit TODO should be replaced by an invocation of
@@ -774,6 +859,7 @@ func (f *fragment) unprotectedClearBit(rowID, columnID uint64) (changed bool, er
 	// Drop the rowCache entry; it's wrong, and we don't want to force
 	// a new copy if no one's reading it.
 	f.rowCache.Add(rowID, nil)
+	f.dropRowSketch(rowID)
 
 	f.stats.Count("clearBit", 1, 1.0)
 
@@ -792,7 +878,20 @@ func (f *fragment) setRow(row *Row, rowID uint64) (bool, error) {
 	if mustClose {
 		defer f.safeClose()
 	}
-	return f.unprotectedSetRow(row, rowID)
+	changed, err := f.unprotectedSetRow(row, rowID)
+	if err != nil {
+		return changed, err
+	} else if f.changeLog != nil {
+		// Log the row as being cleared and then set to the new columns.
+		events := []ChangeEvent{f.changeEvent(ChangeEventClearRow, rowID, 0)}
+		f.storage.ForEachRange(rowID*ShardWidth, (rowID+1)*ShardWidth, func(pos uint64) {
+			events = append(events, f.changeEvent(ChangeEventSet, rowID, f.shard*ShardWidth+pos%ShardWidth))
+		})
+		if err := f.logChanges(events...); err != nil {
+			return changed, err
+		}
+	}
+	return changed, f.commit()
 }
 
 func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err error) {
@@ -823,6 +922,9 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 		f.storage.Containers.Put(headContainerKey+(k%(1<<shardVsContainerExponent)), c)
 	}
 
+	// Invalidate block checksum.
+	delete(f.checksums, int(rowID/HashBlockSize))
+
 	// Update the row in cache.
 	if f.CacheType != CacheTypeNone {
 		n := f.storage.CountRange(rowID*ShardWidth, (rowID+1)*ShardWidth)
@@ -831,8 +933,10 @@ func (f *fragment) unprotectedSetRow(row *Row, rowID uint64) (changed bool, err
 
 	// invalidate rowCache for this row.
 	f.rowCache.Add(rowID, nil)
+	f.dropRowSketch(rowID)
 
-	// Snapshot storage.
+	// Snapshot storage, as containers are replaced without the op log.
+	f.unlogged = true
 	f.enqueueSnapshot()
 	f.stats.Count("setRow", 1, 1.0)
 
@@ -851,7 +955,15 @@ func (f *fragment) clearRow(rowID uint64) (bool, error) {
 	if mustClose {
 		defer f.safeClose()
 	}
-	return f.unprotectedClearRow(rowID)
+	changed, err := f.unprotectedClearRow(rowID)
+	if err != nil {
+		return changed, err
+	} else if changed {
+		if err := f.logChanges(f.changeEvent(ChangeEventClearRow, rowID, 0)); err != nil {
+			return changed, err
+		}
+	}
+	return changed, f.commit()
 }
 
 func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
@@ -872,11 +984,16 @@ func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 		}
 	}
 
+	// Invalidate block checksum.
+	delete(f.checksums, int(rowID/HashBlockSize))
+
 	// Clear the row in cache.
 	f.cache.Add(rowID, 0)
 	f.rowCache.Add(rowID, nil)
+	f.dropRowSketch(rowID)
 
-	// Snapshot storage.
+	// Snapshot storage, as containers are replaced without the op log.
+	f.unlogged = true
 	f.enqueueSnapshot()
 
 	f.stats.Count("clearRow", 1, 1.0)
@@ -884,6 +1001,41 @@ func (f *fragment) unprotectedClearRow(rowID uint64) (changed bool, err error) {
 	return changed, nil
 }
 
+// clearColumns clears every bit of the given columns within the fragment.
+// Only the positions of the given columns are checked in each row.
+func (f *fragment) clearColumns(columns *Row) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var positions []uint64
+	rowSet := make(map[uint64]struct{})
+	var columnIDs []uint64
+	if seg := columns.segment(f.shard); seg != nil {
+		columnIDs = seg.Columns()
+	}
+	for _, rowID := range f.unprotectedRows(0) {
+		for _, columnID := range columnIDs {
+			pos, err := f.pos(rowID, columnID)
+			if err != nil {
+				return false, errors.Wrap(err, "getting bit pos")
+			}
+			if !f.storage.Contains(pos) {
+				continue
+			}
+			positions = append(positions, pos)
+			rowSet[rowID] = struct{}{}
+		}
+	}
+	if len(positions) == 0 {
+		return false, nil
+	}
+
+	if err := f.importPositions(nil, positions, rowSet, true); err != nil {
+		return false, errors.Wrap(err, "clearing positions")
+	}
+	return true, nil
+}
+
 func (f *fragment) bit(rowID, columnID uint64) (bool, error) {
 	pos, err := f.pos(rowID, columnID)
 	if err != nil {
@@ -924,13 +1076,14 @@ func (f *fragment) value(columnID uint64, bitDepth uint) (value int64, exists bo
 }
 
 // clearValue uses a column of bits to clear a multi-bit value.
-func (f *fragment) clearValue(columnID uint64, bitDepth uint, value int64) (changed bool, err error) {
-	return f.setValueBase(columnID, bitDepth, value, true)
+func (f *fragment) clearValue(columnID uint64, bitDepth uint, value int64, events ...ChangeEvent) (changed bool, err error) {
+	return f.setValueBase(columnID, bitDepth, value, true, events...)
 }
 
-// setValue uses a column of bits to set a multi-bit value.
-func (f *fragment) setValue(columnID uint64, bitDepth uint, value int64) (changed bool, err error) {
-	return f.setValueBase(columnID, bitDepth, value, false)
+// setValue uses a column of bits to set a multi-bit value. If the value
+// changed then events are logged along with it.
+func (f *fragment) setValue(columnID uint64, bitDepth uint, value int64, events ...ChangeEvent) (changed bool, err error) {
+	return f.setValueBase(columnID, bitDepth, value, false, events...)
 }
 
 func (f *fragment) positionsForValue(columnID uint64, bitDepth uint, value int64, clear bool, toSet, toClear []uint64) ([]uint64, []uint64, error) {
@@ -974,7 +1127,7 @@ func (f *fragment) positionsForValue(columnID uint64, bitDepth uint, value int64
 }
 
 // TODO get rid of this and use positionsForValue to generate a single write op, and set that with importPositions.
-func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, clear bool) (changed bool, err error) {
+func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, clear bool, events ...ChangeEvent) (changed bool, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	mustClose, err := f.reopen()
@@ -1037,7 +1190,12 @@ func (f *fragment) setValueBase(columnID uint64, bitDepth uint, value int64, cle
 		}
 	}
 
-	return changed, nil
+	if changed {
+		if err := f.logChanges(events...); err != nil {
+			return changed, err
+		}
+	}
+	return changed, f.commit()
 }
 
 // importSetValue is a more efficient SetValue just for imports.
@@ -1117,7 +1275,7 @@ func (f *fragment) sum(filter *Row, bitDepth uint) (sum int64, count uint64, err
 	count = consider.Count()
 
 	// Determine positive & negative sets.
-	nrow := f.row(bsiSignBit)
+	nrow := consider.Intersect(f.row(bsiSignBit))
 	prow := consider.Difference(nrow)
 
 	// Compute the sum based on the bit count of each row multiplied by the
@@ -1226,6 +1384,194 @@ func (f *fragment) maxUnsigned(filter *Row, bitDepth uint) (max int64, count uin
 	return max, count
 }
 
+// distinct returns the distinct values of a given bsiGroup in ascending order.
+// No more than limit values are returned. A bitmap can be passed in to
+// optionally filter the computed columns.
+func (f *fragment) distinct(filter *Row, bitDepth uint, limit int) ([]int64, error) {
+	consider := f.row(bsiExistsBit)
+	if filter != nil {
+		consider = consider.Intersect(filter)
+	}
+
+	// If there are no columns to consider, return early.
+	if !consider.Any() || limit <= 0 {
+		return nil, nil
+	}
+
+	// Read each bit plane once rather than once per visited value.
+	rows := make([]*Row, bitDepth)
+	for i := range rows {
+		rows[i] = f.row(uint64(bsiOffsetBit + i))
+	}
+
+	// Negative values are stored unsigned, so walking the negative set from
+	// the highest magnitude down produces them in ascending order.
+	values := make([]int64, 0)
+	sign := f.row(bsiSignBit)
+	if neg := consider.Intersect(sign); neg.Any() {
+		for _, v := range distinctUnsigned(rows, neg, int(bitDepth)-1, 0, true, limit, nil) {
+			values = append(values, -int64(v))
+		}
+	}
+
+	// Then walk the positive set from the lowest value up.
+	if pos := consider.Difference(sign); pos.Any() && len(values) < limit {
+		for _, v := range distinctUnsigned(rows, pos, int(bitDepth)-1, 0, false, limit-len(values), nil) {
+			values = append(values, int64(v))
+		}
+	}
+
+	return values, nil
+}
+
+// distinctUnsigned descends the bit planes from bit i down to zero, splitting
+// filter on each plane and appending one value for each non-empty leaf. The
+// sign bit is not considered. Values are appended in ascending order unless
+// descending is set.
+func distinctUnsigned(rows []*Row, filter *Row, i int, prefix uint64, descending bool, limit int, values []uint64) []uint64 {
+	if len(values) >= limit {
+		return values
+	} else if i < 0 {
+		return append(values, prefix)
+	}
+
+	ones := filter.Intersect(rows[i])
+	zeros := filter.Difference(ones)
+
+	first, firstPrefix := zeros, prefix
+	second, secondPrefix := ones, prefix|(1<<uint(i))
+	if descending {
+		first, firstPrefix, second, secondPrefix = second, secondPrefix, first, firstPrefix
+	}
+
+	if first.Any() {
+		values = distinctUnsigned(rows, first, i-1, firstPrefix, descending, limit, values)
+	}
+	if second.Any() {
+		values = distinctUnsigned(rows, second, i-1, secondPrefix, descending, limit, values)
+	}
+	return values
+}
+
+// sortValues returns the columns with the lowest bsiGroup values in ascending
+// order, or the highest values in descending order if descending is set.
+// Columns with equal values are ordered by column ID. No more than limit
+// columns are returned. A bitmap can be passed in to optionally filter the
+// computed columns.
+func (f *fragment) sortValues(filter *Row, bitDepth uint, limit int, descending bool) ([]ColumnValue, error) {
+	consider := f.row(bsiExistsBit)
+	if filter != nil {
+		consider = consider.Intersect(filter)
+	}
+
+	// If there are no columns to consider, return early.
+	if !consider.Any() || limit <= 0 {
+		return nil, nil
+	}
+
+	// Read each bit plane once rather than once per visited value.
+	rows := make([]*Row, bitDepth)
+	for i := range rows {
+		rows[i] = f.row(uint64(bsiOffsetBit + i))
+	}
+
+	// Negative values are stored unsigned, so their magnitudes are walked in
+	// the opposite direction of the positive values.
+	sign := f.row(bsiSignBit)
+	neg, pos := consider.Intersect(sign), consider.Difference(sign)
+	if descending {
+		values := sortUnsigned(rows, pos, int(bitDepth)-1, 0, false, true, limit, nil)
+		return sortUnsigned(rows, neg, int(bitDepth)-1, 0, true, false, limit, values), nil
+	}
+	values := sortUnsigned(rows, neg, int(bitDepth)-1, 0, true, true, limit, nil)
+	return sortUnsigned(rows, pos, int(bitDepth)-1, 0, false, false, limit, values), nil
+}
+
+// sortUnsigned descends the bit planes from bit i down to zero, splitting
+// filter on each plane and appending the columns of each non-empty leaf with
+// its value. Magnitudes are visited in ascending order unless descending is
+// set. Values are negated if negative is set.
+func sortUnsigned(rows []*Row, filter *Row, i int, prefix uint64, negative, descending bool, limit int, values []ColumnValue) []ColumnValue {
+	if len(values) >= limit || !filter.Any() {
+		return values
+	} else if i < 0 {
+		value := int64(prefix)
+		if negative {
+			value = -value
+		}
+		for _, col := range filter.Columns() {
+			if len(values) >= limit {
+				break
+			}
+			values = append(values, ColumnValue{ID: col, Value: value})
+		}
+		return values
+	}
+
+	ones := filter.Intersect(rows[i])
+	zeros := filter.Difference(ones)
+
+	first, firstPrefix := zeros, prefix
+	second, secondPrefix := ones, prefix|(1<<uint(i))
+	if descending {
+		first, firstPrefix, second, secondPrefix = second, secondPrefix, first, firstPrefix
+	}
+
+	values = sortUnsigned(rows, first, i-1, firstPrefix, negative, descending, limit, values)
+	return sortUnsigned(rows, second, i-1, secondPrefix, negative, descending, limit, values)
+}
+
+// rangeIn returns the columns whose bsiGroup value is equal to any of the
+// given predicates. Predicates must be representable in bitDepth bits.
+func (f *fragment) rangeIn(bitDepth uint, predicates []int64) (*Row, error) {
+	consider := f.row(bsiExistsBit)
+
+	// Separate predicates by sign and sort their magnitudes.
+	var pos, neg []uint64
+	for _, p := range predicates {
+		if p < 0 {
+			neg = append(neg, uint64(-p))
+		} else {
+			pos = append(pos, uint64(p))
+		}
+	}
+	sort.Sort(uint64Slice(pos))
+	sort.Sort(uint64Slice(neg))
+
+	// Read each bit plane once rather than once per predicate.
+	rows := make([]*Row, bitDepth)
+	for i := range rows {
+		rows[i] = f.row(uint64(bsiOffsetBit + i))
+	}
+
+	sign := f.row(bsiSignBit)
+	b := NewRow()
+	if len(neg) > 0 {
+		b = b.Union(rangeInUnsigned(rows, consider.Intersect(sign), int(bitDepth)-1, neg))
+	}
+	if len(pos) > 0 {
+		b = b.Union(rangeInUnsigned(rows, consider.Difference(sign), int(bitDepth)-1, pos))
+	}
+	return b, nil
+}
+
+// rangeInUnsigned descends the bit planes from bit i down to zero, splitting
+// filter on each plane and only following the branches which lead to one of
+// the sorted values. All values must share the bits above i.
+func rangeInUnsigned(rows []*Row, filter *Row, i int, values []uint64) *Row {
+	if len(values) == 0 || !filter.Any() {
+		return NewRow()
+	} else if i < 0 {
+		return filter
+	}
+
+	// Sorted values with bit i unset precede those with it set.
+	n := sort.Search(len(values), func(j int) bool { return (values[j]>>uint(i))&1 == 1 })
+	return rangeInUnsigned(rows, filter.Difference(rows[i]), i-1, values[:n]).Union(
+		rangeInUnsigned(rows, filter.Intersect(rows[i]), i-1, values[n:]),
+	)
+}
+
 // minRow returns minRowID of the rows in the filter and its count.
 // if filter is nil, it returns fragment.minRowID, 1
 // if fragment has no rows, it returns 0, 0
@@ -1569,8 +1915,161 @@ func (f *fragment) forEachBit(fn func(rowID, columnID uint64) error) error {
 // If opt.FilterValues exist then the row attribute specified by field is matched.
 func (f *fragment) top(opt topOptions) ([]Pair, error) {
 	// Retrieve pairs. If no row ids specified then return from cache.
-	pairs := f.topBitmapPairs(opt.RowIDs)
+	return topPairs(f.topBitmapPairs(opt.RowIDs), f.row, f.RowAttrStore, opt)
+}
+
+// topSketch returns the top n rows of the count cache, or every row if n
+// is zero, along with a sketch of the counts of the rows still in the cache.
+func (f *fragment) topSketch(n int) TopNSketch {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var pairs []Pair
+	for _, p := range f.cache.Top() {
+		if n > 0 && len(pairs) == n {
+			break
+		} else if p.Count > 0 {
+			pairs = append(pairs, Pair{ID: p.ID, Count: p.Count})
+		}
+	}
+	return TopNSketch{Pairs: pairs, Sketch: f.cache.Sketch()}
+}
+
+// mergeRowSketch merges a HyperLogLog sketch of the columns of a row into
+// hll. Only rows in the count cache are sketched, so it returns false
+// without reading the row if the row is not cached. A row's sketch is built
+// on first use, updated as bits are set and dropped when bits are cleared,
+// since sketches cannot remove columns.
+func (f *fragment) mergeRowSketch(rowID uint64, hll hyperLogLog) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sketches != nil {
+		if v, _ := f.sketches.Get(rowID); v != nil {
+			hll.merge(v.(hyperLogLog))
+			return true
+		}
+	}
+	if f.cache.Get(rowID) == 0 {
+		return false
+	}
+
+	other := newHyperLogLog()
+	other.addRow(f.unprotectedRow(rowID))
+	if f.sketches == nil {
+		f.sketches = lru.New(maxRowSketches)
+	}
+	f.sketches.Add(rowID, other)
+	hll.merge(other)
+	return true
+}
+
+// dropRowSketch discards the sketch of a row whose bits have been cleared
+// or replaced.
+func (f *fragment) dropRowSketch(rowID uint64) {
+	if f.sketches != nil {
+		f.sketches.Add(rowID, nil)
+	}
+}
 
+// topFragments returns the top rows across the union of frags, which must
+// all belong to the same shard of different views of a field. Candidates
+// are taken from each fragment's cache and are ranked by their combined
+// count.
+func topFragments(frags []*fragment, opt topOptions) ([]Pair, error) {
+	if len(frags) == 0 {
+		return nil, nil
+	}
+
+	// Union a row across all of the fragments.
+	row := func(rowID uint64) *Row {
+		r := NewRow()
+		for _, f := range frags {
+			r = r.Union(f.row(rowID))
+		}
+		return r
+	}
+
+	// Determine candidate rows. Specific row ids are always counted exactly.
+	rowIDs := opt.RowIDs
+	if len(rowIDs) == 0 {
+		var ids RowIDs
+		for _, f := range frags {
+			pairs, err := f.top(topOptions{
+				N:            opt.N,
+				Src:          opt.Src,
+				FilterName:   opt.FilterName,
+				FilterValues: opt.FilterValues,
+			})
+			if err != nil {
+				return nil, err
+			}
+			other := RowIDs(Pairs(pairs).Keys())
+			sort.Sort(uint64Slice(other))
+			ids = ids.merge(other, int(^uint(0)>>1))
+		}
+		rowIDs = ids
+	}
+
+	// Count each candidate across all views.
+	pairs := make([]bitmapPair, 0, len(rowIDs))
+	for _, rowID := range rowIDs {
+		if n := row(rowID).Count(); n > 0 {
+			pairs = append(pairs, bitmapPair{ID: rowID, Count: n})
+		}
+	}
+	sort.Sort(bitmapPairs(pairs))
+
+	return topPairs(pairs, row, frags[0].RowAttrStore, opt)
+}
+
+// similarFragments returns the number of columns in src of every row across
+// the union of frags which intersects src. The fragments must all belong to
+// the same shard of different views of a field. Rows are in ascending order
+// of ID, and their counts are not set.
+func similarFragments(frags []*fragment, src *Row) []RowSimilarity {
+	var ids RowIDs
+	for _, f := range frags {
+		ids = ids.merge(f.rows(0), int(^uint(0)>>1))
+	}
+
+	rows := make([]RowSimilarity, 0)
+	for _, rowID := range ids {
+		if n := src.intersectionCount(unionFragmentRows(frags, rowID)); n > 0 {
+			rows = append(rows, RowSimilarity{ID: rowID, Intersection: n})
+		}
+	}
+	return rows
+}
+
+// countFragments returns the count of each row in ids, which must be in
+// ascending order, across the union of frags. Empty rows are omitted.
+func countFragments(frags []*fragment, ids []uint64) []RowSimilarity {
+	rows := make([]RowSimilarity, 0)
+	if len(frags) == 0 {
+		return rows
+	}
+	for _, rowID := range ids {
+		if n := unionFragmentRows(frags, rowID).Count(); n > 0 {
+			rows = append(rows, RowSimilarity{ID: rowID, Count: n})
+		}
+	}
+	return rows
+}
+
+// unionFragmentRows returns the union of a row across frags, which must not
+// be empty.
+func unionFragmentRows(frags []*fragment, rowID uint64) *Row {
+	row := frags[0].row(rowID)
+	for _, f := range frags[1:] {
+		row = row.Union(f.row(rowID))
+	}
+	return row
+}
+
+// topPairs returns the top rows from pairs, which must be sorted by count.
+// Rows are retrieved using row when intersecting with opt.Src.
+func topPairs(pairs []bitmapPair, row func(rowID uint64) *Row, attrs AttrStore, opt topOptions) ([]Pair, error) {
 	// If row ids are provided, we don't want to truncate the result set
 	if len(opt.RowIDs) > 0 {
 		opt.N = 0
@@ -1621,7 +2120,7 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 
 		// Apply filter, if set.
 		if filters != nil {
-			attr, err := f.RowAttrStore.Attrs(rowID)
+			attr, err := attrs.Attrs(rowID)
 			if err != nil {
 				return nil, errors.Wrap(err, "getting attrs")
 			} else if attr == nil {
@@ -1638,7 +2137,7 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 			// Calculate count and append.
 			count := cnt
 			if opt.Src != nil {
-				count = opt.Src.intersectionCount(f.row(rowID))
+				count = opt.Src.intersectionCount(row(rowID))
 			}
 			if count == 0 {
 				continue
@@ -1682,7 +2181,7 @@ func (f *fragment) top(opt topOptions) ([]Pair, error) {
 
 		// Calculate the intersecting column count and skip if it's below our
 		// last row in our current result set.
-		count := opt.Src.intersectionCount(f.row(rowID))
+		count := opt.Src.intersectionCount(row(rowID))
 		if count < threshold {
 			continue
 		}
@@ -1866,6 +2365,40 @@ func (f *fragment) blockData(id int) (rowIDs, columnIDs []uint64) {
 	return rowIDs, columnIDs
 }
 
+// replaceBlock replaces all bits in a block with the given fragment
+// positions. Positions outside of the block are ignored.
+func (f *fragment) replaceBlock(id int, positions []uint64) error {
+	start, end := uint64(id)*HashBlockSize*ShardWidth, (uint64(id)+1)*HashBlockSize*ShardWidth
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rowSet := make(map[uint64]struct{})
+	want := make(map[uint64]struct{}, len(positions))
+	var set, clear []uint64
+	for _, pos := range positions {
+		if pos < start || pos >= end {
+			continue
+		}
+		want[pos] = struct{}{}
+		if !f.storage.Contains(pos) {
+			set = append(set, pos)
+			rowSet[pos/ShardWidth] = struct{}{}
+		}
+	}
+	f.storage.ForEachRange(start, end, func(pos uint64) {
+		if _, ok := want[pos]; !ok {
+			clear = append(clear, pos)
+			rowSet[pos/ShardWidth] = struct{}{}
+		}
+	})
+
+	if len(set) == 0 && len(clear) == 0 {
+		return nil
+	}
+	return errors.Wrap(f.importPositions(set, clear, rowSet, false), "replaceBlock")
+}
+
 // mergeBlock compares the block's bits and computes a diff with another set of block bits.
 // The state of a bit is determined by consensus from all blocks being considered.
 //
@@ -2035,9 +2568,9 @@ func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *Impor
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	if options.Clear {
-		err = f.importPositions(nil, positions, rowSet)
+		err = f.importPositions(nil, positions, rowSet, true)
 	} else {
-		err = f.importPositions(positions, nil, rowSet)
+		err = f.importPositions(positions, nil, rowSet, true)
 	}
 	return errors.Wrap(err, "bulkImportStandard")
 }
@@ -2046,11 +2579,13 @@ func (f *fragment) bulkImportStandard(rowIDs, columnIDs []uint64, options *Impor
 // clear in storage. One must also pass in the set of unique rows which are
 // affected by the set and clear operations. It is unprotected (f.mu must be
 // locked when calling it). No position should appear in both set and clear.
+// If logEvents is true, the positions which changed are recorded in the
+// change log.
 //
 // importPositions tries to intelligently decide whether or not to do a full
 // snapshot of the fragment or just do in-memory updates while appending
 // operations to the op log.
-func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct{}) error {
+func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct{}, logEvents bool) error {
 	mustClose, err := f.reopen()
 	if err != nil {
 		return errors.Wrap(err, "reopening")
@@ -2067,6 +2602,13 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		}
 		f.stats.Count("ImportedN", int64(changedN), 1)
 		f.incrementOpN(changedN)
+
+		// AddN moves the positions which changed to the front of set.
+		if logEvents {
+			if err := f.logPositions(ChangeEventSet, set[:changedN]); err != nil {
+				return err
+			}
+		}
 	}
 
 	if len(clear) > 0 {
@@ -2077,6 +2619,12 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		}
 		f.stats.Count("ClearedN", int64(changedN), 1)
 		f.incrementOpN(changedN)
+
+		if logEvents {
+			if err := f.logPositions(ChangeEventClear, clear[:changedN]); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Update cache counts for all affected rows.
@@ -2090,13 +2638,31 @@ func (f *fragment) importPositions(set, clear []uint64, rowSet map[uint64]struct
 		}
 
 		f.rowCache.Add(rowID, nil)
+		f.dropRowSketch(rowID)
 	}
 
 	if f.CacheType != CacheTypeNone {
 		f.cache.Recalculate()
 	}
 
-	return nil
+	return f.commit()
+}
+
+// unionFragment sets every bit of other in the fragment. Both fragments
+// must belong to the same shard.
+func (f *fragment) unionFragment(other *fragment) error {
+	other.mu.RLock()
+	positions := other.storage.Slice()
+	other.mu.RUnlock()
+
+	rowSet := make(map[uint64]struct{})
+	for _, pos := range positions {
+		rowSet[pos/ShardWidth] = struct{}{}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return errors.Wrap(f.importPositions(positions, nil, rowSet, false), "importing positions")
 }
 
 // bulkImportMutex performs a bulk import on a fragment while ensuring
@@ -2154,7 +2720,7 @@ func (f *fragment) bulkImportMutex(rowIDs, columnIDs []uint64) error {
 	toSet := rowIDs[:i]
 	toClear := columnIDs[:clearIdx]
 
-	return errors.Wrap(f.importPositions(toSet, toClear, rowSet), "importing positions")
+	return errors.Wrap(f.importPositions(toSet, toClear, rowSet, true), "importing positions")
 }
 
 func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bitDepth uint, clear bool) error {
@@ -2190,12 +2756,13 @@ func (f *fragment) importValueSmallWrite(columnIDs []uint64, values []int64, bit
 	for i := uint(0); i < bitDepth+1; i++ {
 		rowSet[uint64(i)] = struct{}{}
 	}
-	err := f.importPositions(toSet, toClear, rowSet)
+	err := f.importPositions(toSet, toClear, rowSet, false)
 	return errors.Wrap(err, "importing positions")
 }
 
-// importValue bulk imports a set of range-encoded values.
-func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint, clear bool) error {
+// importValue bulk imports a set of range-encoded values. Events are logged
+// once the values are imported.
+func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint, clear bool, events ...ChangeEvent) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -2205,12 +2772,16 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 	}
 
 	if len(columnIDs)*int(bitDepth+1)+f.opN < f.MaxOpN {
-		return errors.Wrap(f.importValueSmallWrite(columnIDs, values, bitDepth, clear), "import small write")
+		if err := f.importValueSmallWrite(columnIDs, values, bitDepth, clear); err != nil {
+			return errors.Wrap(err, "import small write")
+		}
+		return f.logChanges(events...)
 	}
 
 	// Process every value.
 	// If an error occurs then reopen the storage.
 	f.storage.OpWriter = nil
+	f.unlogged = true
 	totalChanges := 0
 	if err := func() (err error) {
 		for i := range columnIDs {
@@ -2230,13 +2801,21 @@ func (f *fragment) importValue(columnIDs []uint64, values []int64, bitDepth uint
 	// We don't actually care, except we want our stats to be accurate.
 	f.incrementOpN(totalChanges)
 
+	// Invalidate the block checksums of the BSI rows.
+	for rowID := uint64(0); rowID < bsiOffsetBit+uint64(bitDepth); rowID++ {
+		delete(f.checksums, int(rowID/HashBlockSize))
+	}
+
 	// in theory, this should probably have happened anyway, but if enough
 	// of the bits matched existing bits, we'll be under our opN estimate, and
 	// we want to ensure that the snapshot happens.
 	f.enqueueSnapshot()
 	f.unprotectedAwaitSnapshot()
 
-	return nil
+	if err := f.logChanges(events...); err != nil {
+		return err
+	}
+	return f.commit()
 }
 
 // importRoaring imports from the official roaring data format defined at
@@ -2250,6 +2829,13 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 	f.mu.Lock()
 	defer f.mu.Unlock()
 	span.Finish()
+
+	// The change log only records the bits which changed, which the roaring
+	// import does not report, so import the decoded positions instead.
+	if f.changeLog != nil {
+		return errors.Wrap(f.importRoaringPositions(data, clear), "importing positions")
+	}
+
 	span, ctx = tracing.StartSpanFromContext(ctx, "importRoaring.ImportRoaringBits")
 	changed, rowSet, err := f.storage.ImportRoaringBits(data, clear, true, rowSize)
 	span.Finish()
@@ -2264,7 +2850,9 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 		if changes == 0 {
 			continue
 		}
+		delete(f.checksums, int(rowID/HashBlockSize))
 		f.rowCache.Add(rowID, nil)
+		f.dropRowSketch(rowID)
 		if updateCache {
 			anyChanged = true
 			f.cache.BulkAdd(rowID, f.cache.Get(rowID)+uint64(changes))
@@ -2278,7 +2866,81 @@ func (f *fragment) importRoaring(ctx context.Context, data []byte, clear bool) e
 	span, _ = tracing.StartSpanFromContext(ctx, "importRoaring.incrementOpN")
 	f.incrementOpN(changed)
 	span.Finish()
-	return nil
+	return f.commit()
+}
+
+// importRoaringPositions decodes roaring data and imports its positions in
+// batches of at most importRoaringBatchSize, so that memory use does not grow
+// with the size of the import.
+func (f *fragment) importRoaringPositions(data []byte, clear bool) error {
+	bm := roaring.NewBitmap()
+	if err := bm.UnmarshalBinary(data); err != nil {
+		return errors.Wrap(err, "decoding imported bits")
+	}
+
+	positions := make([]uint64, 0, importRoaringBatchSize)
+	rowSet := make(map[uint64]struct{})
+	flush := func() error {
+		defer func() {
+			positions = positions[:0]
+			rowSet = make(map[uint64]struct{})
+		}()
+		if clear {
+			return f.importPositions(nil, positions, rowSet, true)
+		}
+		return f.importPositions(positions, nil, rowSet, true)
+	}
+
+	itr := bm.Iterator()
+	itr.Seek(0)
+	for pos, eof := itr.Next(); !eof; pos, eof = itr.Next() {
+		positions = append(positions, pos)
+		rowSet[pos/ShardWidth] = struct{}{}
+		if len(positions) == importRoaringBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+	return flush()
+}
+
+// changeEvent returns a change event of the given type for a bit in the
+// fragment.
+func (f *fragment) changeEvent(typ string, rowID, columnID uint64) ChangeEvent {
+	return ChangeEvent{
+		Type:   typ,
+		Index:  f.index,
+		Field:  f.field,
+		View:   f.view,
+		Shard:  f.shard,
+		Row:    rowID,
+		Column: columnID,
+	}
+}
+
+// logChanges appends events to the change log, if enabled. It must be called
+// with f.mu locked so that events are logged in the order they are applied.
+func (f *fragment) logChanges(events ...ChangeEvent) error {
+	if f.changeLog == nil {
+		return nil
+	}
+	return errors.Wrap(f.changeLog.append(events...), "logging changes")
+}
+
+// logPositions logs an event of the given type for each fragment position.
+func (f *fragment) logPositions(typ string, positions []uint64) error {
+	if f.changeLog == nil || len(positions) == 0 {
+		return nil
+	}
+	events := make([]ChangeEvent, len(positions))
+	for i, pos := range positions {
+		events[i] = f.changeEvent(typ, pos/ShardWidth, f.shard*ShardWidth+pos%ShardWidth)
+	}
+	return f.logChanges(events...)
 }
 
 // incrementOpN increase the operation count by one.
@@ -2287,6 +2949,7 @@ func (f *fragment) incrementOpN(changed int) {
 	if changed <= 0 {
 		return
 	}
+	f.dirty = true
 	f.opN += changed
 	f.ops++
 	if f.opN > f.MaxOpN {
@@ -2294,6 +2957,47 @@ func (f *fragment) incrementOpN(changed int) {
 	}
 }
 
+// commit makes the mutations applied to the fragment durable according to
+// its durability mode. It must be called with f.mu locked, before the
+// mutations are acknowledged. Only the op log is synced; snapshots are left
+// to the background queue since the op log is replayed on open.
+func (f *fragment) commit() error {
+	if f.durability != DurabilityAlways {
+		return nil
+	}
+	return f.unprotectedSync()
+}
+
+// Sync flushes any ops written since the last sync to disk, and waits for
+// the snapshot of any mutations which bypassed the op log.
+func (f *fragment) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.unprotectedSync()
+}
+
+func (f *fragment) unprotectedSync() error {
+	// Mutations which bypass the op log are only persisted by a snapshot.
+	if f.unlogged {
+		f.unprotectedAwaitSnapshot()
+
+		// Snapshot now if the queued snapshot failed.
+		if f.unlogged && f.file != nil {
+			if err := f.snapshot(); err != nil {
+				return errors.Wrap(err, "snapshotting")
+			}
+		}
+	}
+	if !f.dirty || f.file == nil {
+		return nil
+	}
+	if err := f.file.Sync(); err != nil {
+		return errors.Wrap(err, "syncing op log")
+	}
+	f.dirty = false
+	return nil
+}
+
 // Snapshot writes the storage bitmap to disk and reopens it. This may
 // coexist with existing background-queue snapshotting; it does not remove
 // things from the queue. You probably don't want to do this; use
@@ -2357,6 +3061,15 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 		return n, fmt.Errorf("flush: %s", err)
 	}
 
+	// The snapshot replaces the op log, so it must be on disk before the
+	// rename if mutations are expected to be durable.
+	durable := f.durability == DurabilityAlways || f.durability == DurabilityInterval
+	if durable {
+		if err := file.Sync(); err != nil {
+			return n, fmt.Errorf("sync snapshot: %s", err)
+		}
+	}
+
 	// Close current storage.
 	if err := f.closeStorage(false); err != nil {
 		return n, fmt.Errorf("close storage: %s", err)
@@ -2366,6 +3079,11 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 	if err := os.Rename(snapshotPath, f.path); err != nil {
 		return n, fmt.Errorf("rename snapshot: %s", err)
 	}
+	if durable {
+		if err := syncDir(filepath.Dir(f.path)); err != nil {
+			return n, fmt.Errorf("sync snapshot dir: %s", err)
+		}
+	}
 
 	// if we reloaded from the file, we'd end up with this bitmap
 	// as our storage. so... let's use this bitmap. as our storage.
@@ -2378,10 +3096,22 @@ func unprotectedWriteToFragment(f *fragment, bm *roaring.Bitmap) (n int64, err e
 
 	// Reset operation count.
 	f.opN = 0
+	f.dirty = false
+	f.unlogged = false
 
 	return n, nil
 }
 
+// syncDir flushes the entries of the directory at path to disk.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
 // RecalculateCache rebuilds the cache regardless of invalidate time delay.
 func (f *fragment) RecalculateCache() {
 	f.mu.Lock()
@@ -2575,6 +3305,9 @@ func (f *fragment) readStorageFromArchive(r io.Reader) error {
 		return errors.Wrap(err, "opening")
 	}
 
+	// Clear checksums.
+	f.checksums = make(map[int][]byte)
+
 	return nil
 }
 
@@ -2759,16 +3492,31 @@ func upgradeRoaringBSIv2(f *fragment, bitDepth uint) (string, error) {
 }
 
 type rowIterator struct {
-	f      *fragment
+	frags  []*fragment
 	rowIDs []uint64
 	cur    int
 	wrap   bool
 }
 
 func (f *fragment) rowIterator(wrap bool, filters ...rowFilter) *rowIterator {
+	return newRowIterator([]*fragment{f}, wrap, filters...)
+}
+
+// newRowIterator returns an iterator over the union of the rows of frags,
+// which must all belong to the same shard.
+func newRowIterator(frags []*fragment, wrap bool, filters ...rowFilter) *rowIterator {
+	var rowIDs RowIDs
+	for i, f := range frags {
+		rows := f.rows(0, filters...) // TODO: this may be memory intensive in high cardinality cases
+		if i == 0 {
+			rowIDs = rows
+		} else {
+			rowIDs = rowIDs.merge(rows, int(^uint(0)>>1))
+		}
+	}
 	return &rowIterator{
-		f:      f,
-		rowIDs: f.rows(0, filters...), // TODO: this may be memory intensive in high cardinality cases
+		frags:  frags,
+		rowIDs: rowIDs,
 		wrap:   wrap,
 	}
 }
@@ -2789,7 +3537,10 @@ func (ri *rowIterator) Next() (r *Row, rowID uint64, wrapped bool) {
 		wrapped = true
 	}
 	rowID = ri.rowIDs[ri.cur]
-	r = ri.f.row(rowID)
+	r = ri.frags[0].row(rowID)
+	for _, f := range ri.frags[1:] {
+		r = r.Union(f.row(rowID))
+	}
 	ri.cur++
 	return r, rowID, wrapped
 }
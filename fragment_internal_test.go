@@ -26,9 +26,11 @@ import (
 	"os"
 	"reflect"
 	"sort"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -170,6 +172,49 @@ func TestFragment_ClearRow(t *testing.T) {
 	}
 }
 
+// Ensure a fragment can clear columns across all rows.
+func TestFragment_ClearColumns(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeRanked)
+	defer f.Clean(t)
+
+	for _, bit := range [][2]uint64{{1, 1}, {1, 2}, {2, 2}, {2, 65536}, {3, 3}} {
+		if _, err := f.setBit(bit[0], bit[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if changed, err := f.clearColumns(NewRow(2, 65536, 70000, ShardWidth+2)); err != nil {
+		t.Fatal(err)
+	} else if !changed {
+		t.Fatal("expected change")
+	} else if changed, err := f.clearColumns(NewRow(4)); err != nil {
+		t.Fatal(err)
+	} else if changed {
+		t.Fatal("unexpected change")
+	}
+
+	verify := func() {
+		t.Helper()
+		for rowID, exp := range map[uint64][]uint64{1: {1}, 2: {}, 3: {3}} {
+			if cols := f.row(rowID).Columns(); !reflect.DeepEqual(cols, exp) {
+				t.Fatalf("unexpected columns in row %d: %v", rowID, cols)
+			}
+		}
+	}
+	verify()
+	if n := f.cache.Get(2); n != 0 {
+		t.Fatalf("unexpected cached count for row 2: %d", n)
+	} else if n := f.cache.Get(1); n != 1 {
+		t.Fatalf("unexpected cached count for row 1: %d", n)
+	}
+
+	// Close and reopen the fragment & verify the data.
+	if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	verify()
+}
+
 // Ensure a fragment can set a row.
 func TestFragment_SetRow(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 7, "")
@@ -420,6 +465,28 @@ func TestFragment_Sum(t *testing.T) {
 			t.Fatalf("unexpected sum: got %d, expecting %d", sum, 3800-382)
 		}
 	})
+
+	// verify that negative values outside the filter are excluded
+	if _, err := f.setValue(6000, bitDepth, -100); err != nil {
+		t.Fatal(err)
+	}
+	t.Run("WithFilterNegative", func(t *testing.T) {
+		if sum, n, err := f.sum(NewRow(2000, 4000), bitDepth); err != nil {
+			t.Fatal(err)
+		} else if n != 2 {
+			t.Fatalf("unexpected count: %d", n)
+		} else if sum != 600 {
+			t.Fatalf("unexpected sum: %d", sum)
+		}
+
+		if sum, n, err := f.sum(NewRow(2000, 6000), bitDepth); err != nil {
+			t.Fatal(err)
+		} else if n != 2 {
+			t.Fatalf("unexpected count: %d", n)
+		} else if sum != 200 {
+			t.Fatalf("unexpected sum: %d", sum)
+		}
+	})
 }
 
 // Ensure a fragment can find the min and max of values.
@@ -498,7 +565,135 @@ func TestFragment_MinMax(t *testing.T) {
 	})
 }
 
+// Ensure a fragment can return the distinct values of a bsiGroup.
+func TestFragment_Distinct(t *testing.T) {
+	const bitDepth = 16
+
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	// Set values.
+	if _, err := f.setValue(1000, bitDepth, 382); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(2000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(3000, bitDepth, -2818); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(4000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(5000, bitDepth, -7); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(6000, bitDepth, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter *Row
+		limit  int
+		exp    []int64
+	}{
+		{filter: nil, limit: 10, exp: []int64{-2818, -7, 0, 300, 382}},
+		{filter: nil, limit: 3, exp: []int64{-2818, -7, 0}},
+		{filter: nil, limit: 1, exp: []int64{-2818}},
+		{filter: NewRow(1000, 2000, 4000), limit: 10, exp: []int64{300, 382}},
+		{filter: NewRow(5000, 6000), limit: 10, exp: []int64{-7, 0}},
+		{filter: NewRow(1), limit: 10, exp: nil},
+	}
+	for i, test := range tests {
+		if values, err := f.distinct(test.filter, bitDepth, test.limit); err != nil {
+			t.Fatal(err)
+		} else if len(values) != len(test.exp) || (len(values) > 0 && !reflect.DeepEqual(values, test.exp)) {
+			t.Errorf("test %d expected: %v, but got: %v", i, test.exp, values)
+		}
+	}
+}
+
+// Ensure a fragment can return columns ordered by value.
+func TestFragment_SortValues(t *testing.T) {
+	const bitDepth = 16
+
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	// Set values.
+	if _, err := f.setValue(1000, bitDepth, 382); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(2000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(3000, bitDepth, -2818); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(4000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(5000, bitDepth, -7); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(6000, bitDepth, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filter     *Row
+		limit      int
+		descending bool
+		exp        []ColumnValue
+	}{
+		{filter: nil, limit: 10, exp: []ColumnValue{{ID: 3000, Value: -2818}, {ID: 5000, Value: -7}, {ID: 6000, Value: 0}, {ID: 2000, Value: 300}, {ID: 4000, Value: 300}, {ID: 1000, Value: 382}}},
+		{filter: nil, limit: 10, descending: true, exp: []ColumnValue{{ID: 1000, Value: 382}, {ID: 2000, Value: 300}, {ID: 4000, Value: 300}, {ID: 6000, Value: 0}, {ID: 5000, Value: -7}, {ID: 3000, Value: -2818}}},
+		{filter: nil, limit: 2, exp: []ColumnValue{{ID: 3000, Value: -2818}, {ID: 5000, Value: -7}}},
+		{filter: nil, limit: 2, descending: true, exp: []ColumnValue{{ID: 1000, Value: 382}, {ID: 2000, Value: 300}}},
+		{filter: NewRow(2000, 4000, 5000), limit: 10, descending: true, exp: []ColumnValue{{ID: 2000, Value: 300}, {ID: 4000, Value: 300}, {ID: 5000, Value: -7}}},
+		{filter: NewRow(1), limit: 10, exp: nil},
+	}
+	for i, test := range tests {
+		if values, err := f.sortValues(test.filter, bitDepth, test.limit, test.descending); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(values, test.exp) {
+			t.Errorf("test %d expected: %v, but got: %v", i, test.exp, values)
+		}
+	}
+}
+
 // Ensure a fragment query for matching values.
+func TestFragment_RangeIn(t *testing.T) {
+	const bitDepth = 16
+
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	// Set values.
+	if _, err := f.setValue(1000, bitDepth, 382); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(2000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(3000, bitDepth, -2818); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(4000, bitDepth, 300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(5000, bitDepth, -300); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setValue(6000, bitDepth, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		predicates []int64
+		exp        []uint64
+	}{
+		{predicates: []int64{300}, exp: []uint64{2000, 4000}},
+		{predicates: []int64{382, 300, 0}, exp: []uint64{1000, 2000, 4000, 6000}},
+		{predicates: []int64{-300, -2818}, exp: []uint64{3000, 5000}},
+		{predicates: []int64{300, 300, -300}, exp: []uint64{2000, 4000, 5000}},
+		{predicates: []int64{1, 301, -7}, exp: []uint64{}},
+		{predicates: nil, exp: []uint64{}},
+	}
+	for i, test := range tests {
+		if row, err := f.rangeIn(bitDepth, test.predicates); err != nil {
+			t.Fatal(err)
+		} else if cols := row.Columns(); !reflect.DeepEqual(cols, test.exp) {
+			t.Errorf("test %d expected: %v, but got: %v", i, test.exp, cols)
+		}
+	}
+}
+
 func TestFragment_Range(t *testing.T) {
 	const bitDepth = 16
 
@@ -926,6 +1121,165 @@ func TestFragment_Snapshot(t *testing.T) {
 	}
 }
 
+// Ensure a fragment syncs mutations according to its durability mode.
+func TestFragment_Durability(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	if _, err := f.setBit(1000, 1); err != nil {
+		t.Fatal(err)
+	} else if !f.dirty {
+		t.Fatal("expected unsynced ops")
+	} else if err := f.Sync(); err != nil {
+		t.Fatal(err)
+	} else if f.dirty {
+		t.Fatal("expected ops to be synced")
+	}
+
+	f.durability = DurabilityAlways
+	if _, err := f.setBit(1000, 2); err != nil {
+		t.Fatal(err)
+	} else if f.dirty {
+		t.Fatal("expected ops to be synced before acknowledgement")
+	} else if err := f.bulkImport([]uint64{1, 2}, []uint64{3, 4}, &ImportOptions{}); err != nil {
+		t.Fatal(err)
+	} else if f.dirty {
+		t.Fatal("expected imported ops to be synced before acknowledgement")
+	}
+
+	// Queue a snapshot which is not taken; mutations in the op log must not
+	// wait for it.
+	f.snapshotQueue = make(chan *fragment, 1)
+	f.mu.Lock()
+	f.enqueueSnapshot()
+	f.mu.Unlock()
+	done := make(chan error)
+	go func() {
+		_, err := f.setBit(1000, 5)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		} else if f.dirty {
+			t.Fatal("expected ops to be synced while a snapshot is queued")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected mutation not to wait for the queued snapshot")
+	}
+	<-f.snapshotQueue
+	if err := f.protectedSnapshot(true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Ensure a fragment discards an op which was partially written to its op log.
+func TestFragment_RecoverOpLog(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	if _, err := f.setBit(1000, 1); err != nil {
+		t.Fatal(err)
+	} else if _, err := f.setBit(1000, 2); err != nil {
+		t.Fatal(err)
+	} else if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cut the last op short, as if the server stopped while writing it.
+	fi, err := os.Stat(f.path)
+	if err != nil {
+		t.Fatal(err)
+	} else if err := os.Truncate(f.path, fi.Size()-3); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Open(); err != nil {
+		t.Fatal(err)
+	} else if cols := f.row(1000).Columns(); !reflect.DeepEqual(cols, []uint64{1}) {
+		t.Fatalf("unexpected columns after recovery: %v", cols)
+	}
+
+	// Ensure the op log is usable after recovery.
+	if _, err := f.setBit(1000, 3); err != nil {
+		t.Fatal(err)
+	} else if err := f.Reopen(); err != nil {
+		t.Fatal(err)
+	} else if cols := f.row(1000).Columns(); !reflect.DeepEqual(cols, []uint64{1, 3}) {
+		t.Fatalf("unexpected columns after reopen: %v", cols)
+	}
+}
+
+// Ensure a fragment refuses to open an op log which is corrupt before its
+// last op, rather than discarding acknowledged ops.
+func TestFragment_RecoverOpLog_Corrupt(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer os.Remove(f.cachePath())
+	defer os.Remove(f.path)
+
+	for _, columnID := range []uint64{1, 2, 3} {
+		if _, err := f.setBit(1000, columnID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the value of the second to last op.
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-2*13+3] ^= 0xFF
+	if err := ioutil.WriteFile(f.path, data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Open(); err == nil || !strings.Contains(err.Error(), "corrupt op log") {
+		t.Fatalf("expected corrupt op log error, got: %v", err)
+	}
+}
+
+// Ensure syncing a fragment in the interval durability mode waits for the
+// snapshot of mutations which bypass the op log.
+func TestFragment_Sync_Unlogged(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+	f.durability = DurabilityInterval
+
+	// Replace the snapshot queue with one which is serviced by the test.
+	queue := f.snapshotQueue
+	f.snapshotQueue = make(chan *fragment, 1)
+	defer func() { f.snapshotQueue = queue }()
+
+	if _, err := f.setRow(NewRow(1, 2), 10); err != nil {
+		t.Fatal(err)
+	} else if !f.unlogged {
+		t.Fatal("expected unlogged mutations")
+	}
+
+	done := make(chan error)
+	go func() { done <- f.Sync() }()
+	select {
+	case err := <-done:
+		t.Fatalf("expected sync to wait for snapshot, got: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	<-f.snapshotQueue
+	if err := f.protectedSnapshot(true); err != nil {
+		t.Fatal(err)
+	}
+	f.snapshotCond.Broadcast()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	} else if f.unlogged {
+		t.Fatal("expected unlogged mutations to be snapshotted")
+	}
+}
+
 // Ensure a fragment can iterate over all bits in order.
 func TestFragment_ForEachBit(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, "")
@@ -1269,6 +1623,40 @@ func TestFragment_Blocks_Empty(t *testing.T) {
 	}
 }
 
+// Ensure a block can be replaced without affecting other blocks.
+func TestFragment_ReplaceBlock(t *testing.T) {
+	f := mustOpenFragment("i", "f", viewStandard, 0, "")
+	defer f.Clean(t)
+
+	for _, bit := range [][2]uint64{{1, 1}, {1, 2}, {50, 3}, {100, 4}} {
+		if _, err := f.setBit(bit[0], bit[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := f.Blocks()
+
+	// Keep (1,2), add (2,5) and clear the rest of block 0.
+	if err := f.replaceBlock(0, []uint64{1*ShardWidth + 2, 2*ShardWidth + 5, 100*ShardWidth + 6}); err != nil {
+		t.Fatal(err)
+	}
+	if rowIDs, columnIDs := f.blockData(0); !reflect.DeepEqual(rowIDs, []uint64{1, 2}) || !reflect.DeepEqual(columnIDs, []uint64{2, 5}) {
+		t.Fatalf("unexpected block data: %v %v", rowIDs, columnIDs)
+	}
+	if rowIDs, columnIDs := f.blockData(1); !reflect.DeepEqual(rowIDs, []uint64{100}) || !reflect.DeepEqual(columnIDs, []uint64{4}) {
+		t.Fatalf("unexpected block data: %v %v", rowIDs, columnIDs)
+	}
+	if n := f.row(1).Count(); n != 1 {
+		t.Fatalf("unexpected row count: %d", n)
+	}
+
+	// The block checksum is recalculated.
+	if blocks := f.Blocks(); bytes.Equal(blocks[0].Checksum, orig[0].Checksum) {
+		t.Fatalf("expected checksum to change: %x", blocks[0].Checksum)
+	} else if !bytes.Equal(blocks[1].Checksum, orig[1].Checksum) {
+		t.Fatalf("expected checksum not to change: %x", blocks[1].Checksum)
+	}
+}
+
 // Ensure a fragment's cache can be persisted between restarts.
 func TestFragment_LRUCache_Persistence(t *testing.T) {
 	f := mustOpenFragment("i", "f", viewStandard, 0, CacheTypeLRU)
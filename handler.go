@@ -95,14 +95,17 @@ func (n nopHandler) Close() error {
 var NopHandler Handler = nopHandler{}
 
 // ImportValueRequest describes the import request structure
-// for a value (BSI) import.
+// for a value (BSI) import. For decimal fields, Values are the
+// scaled integer representations; FloatValues may be provided
+// instead to have them scaled according to the field.
 type ImportValueRequest struct {
-	Index      string
-	Field      string
-	Shard      uint64
-	ColumnIDs  []uint64
-	ColumnKeys []string
-	Values     []int64
+	Index       string
+	Field       string
+	Shard       uint64
+	ColumnIDs   []uint64
+	ColumnKeys  []string
+	Values      []int64
+	FloatValues []float64
 }
 
 // ImportRequest describes the import request structure
@@ -39,6 +39,14 @@ const (
 	// defaultCacheFlushInterval is the default value for Fragment.CacheFlushInterval.
 	defaultCacheFlushInterval = 1 * time.Minute
 
+	// defaultDurabilityInterval is the default interval at which fragment
+	// op logs are synced to disk in the DurabilityInterval mode.
+	defaultDurabilityInterval = 1 * time.Second
+
+	// defaultTimeViewInterval is the default interval at which time views
+	// are rolled up or deleted once they are old enough.
+	defaultTimeViewInterval = 1 * time.Minute
+
 	// fileLimit is the maximum open file limit (ulimit -n) to automatically set.
 	fileLimit = 262144 // (512^2)
 
@@ -77,9 +85,27 @@ type Holder struct {
 	// The interval at which the cached row ids are persisted to disk.
 	cacheFlushInterval time.Duration
 
+	// The interval at which time views are rolled up or deleted according
+	// to their field's rollup policy and TTL.
+	timeViewInterval time.Duration
+
 	Logger logger.Logger
 
 	snapshotQueue chan *fragment
+
+	// Determines when fragment mutations are synced to disk.
+	durability         string
+	durabilityInterval time.Duration
+
+	// Blocks mutations while a backup is taken.
+	writes writeGate
+
+	// Returns true if this node coordinates cluster-wide maintenance such as
+	// deleting expired time views.
+	isCoordinator func() bool
+
+	// Records mutations for change data capture, if enabled.
+	changeLog *ChangeLog
 }
 
 // lockedChan looks a little ridiculous admittedly, but exists for good reason.
@@ -119,12 +145,18 @@ func NewHolder() *Holder {
 		translateFile:            NewTranslateFile(),
 		NewPrimaryTranslateStore: newNopTranslateStore,
 
-		broadcaster: NopBroadcaster,
-		Stats:       stats.NopStatsClient,
+		broadcaster:   NopBroadcaster,
+		isCoordinator: func() bool { return true },
+
+		Stats: stats.NopStatsClient,
 
 		NewAttrStore: newNopAttrStore,
 
 		cacheFlushInterval: defaultCacheFlushInterval,
+		timeViewInterval:   defaultTimeViewInterval,
+
+		durability:         DurabilityNone,
+		durabilityInterval: defaultDurabilityInterval,
 
 		Logger: logger.NopLogger,
 	}
@@ -142,6 +174,12 @@ func (h *Holder) Open() error {
 		return errors.Wrap(err, "creating directory")
 	}
 
+	if h.changeLog != nil {
+		if err := h.changeLog.Open(); err != nil {
+			return errors.Wrap(err, "opening change log")
+		}
+	}
+
 	// Open path to read all index directories.
 	f, err := os.Open(h.Path)
 	if err != nil {
@@ -191,6 +229,16 @@ func (h *Holder) Open() error {
 	h.wg.Add(1)
 	go func() { defer h.wg.Done(); h.monitorCacheFlush() }()
 
+	// Periodically roll up and delete old time views.
+	h.wg.Add(1)
+	go func() { defer h.wg.Done(); h.monitorTimeViews() }()
+
+	// Periodically sync fragment op logs.
+	if h.durability == DurabilityInterval {
+		h.wg.Add(1)
+		go func() { defer h.wg.Done(); h.monitorDurability() }()
+	}
+
 	h.Stats.Open()
 
 	h.opened.Close()
@@ -222,6 +270,12 @@ func (h *Holder) Close() error {
 		}
 	}
 
+	if h.changeLog != nil {
+		if err := h.changeLog.Close(); err != nil {
+			return errors.Wrap(err, "closing change log")
+		}
+	}
+
 	// Reset opened in case Holder needs to be reopened.
 	h.opened.mu.Lock()
 	h.opened.ch = make(chan struct{})
@@ -258,7 +312,8 @@ func (h *Holder) HasData() (bool, error) {
 	}
 
 	for _, fi := range fis {
-		if !fi.IsDir() {
+		// Skip files or hidden directories, such as the change log.
+		if !fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
 			continue
 		}
 		return true, nil
@@ -444,6 +499,8 @@ func (h *Holder) newIndex(path, name string) (*Index, error) {
 	index.newAttrStore = h.NewAttrStore
 	index.columnAttrs = h.NewAttrStore(filepath.Join(index.path, ".data"))
 	index.snapshotQueue = h.snapshotQueue
+	index.durability = h.durability
+	index.changeLog = h.changeLog
 	return index, nil
 }
 
@@ -537,6 +594,144 @@ func (h *Holder) flushCaches() {
 	}
 }
 
+func (h *Holder) monitorDurability() {
+	ticker := time.NewTicker(h.durabilityInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			h.syncFragments()
+		}
+	}
+}
+
+// syncFragments syncs the op log of every fragment with unsynced mutations.
+func (h *Holder) syncFragments() {
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			for _, view := range field.views() {
+				for _, fragment := range view.allFragments() {
+					select {
+					case <-h.closing:
+						return
+					default:
+					}
+
+					if err := fragment.Sync(); err != nil {
+						h.Logger.Printf("ERROR syncing fragment: err=%s, path=%s", err, fragment.path)
+					}
+				}
+			}
+		}
+	}
+}
+
+// monitorTimeViews periodically rolls up time views which have outlived
+// their field's rollup policy and deletes those which have outlived their
+// field's TTL. This is run in a goroutine.
+func (h *Holder) monitorTimeViews() {
+	ticker := time.NewTicker(h.timeViewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closing:
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			h.rollupViews(now)
+			h.deleteExpiredViews(now)
+		}
+	}
+}
+
+// rollupViews merges the time views which have outlived their field's
+// rollup policy into coarser views and deletes them. Each node rolls up its
+// own fragments, so the deletion is not broadcast to the cluster. The pass is
+// skipped while writes are paused for a backup.
+func (h *Holder) rollupViews(now time.Time) {
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			for _, name := range field.rollupCandidates(now) {
+				select {
+				case <-h.closing:
+					return
+				default:
+				}
+
+				if !h.rollupView(index, field, name) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// rollupView rolls up a single view. Returns false if writes are paused.
+func (h *Holder) rollupView(index *Index, field *Field, name string) bool {
+	if err := h.writes.enter(); err != nil {
+		return false
+	}
+	defer h.writes.exit()
+
+	h.Logger.Printf("rolling up view: index=%s, field=%s, view=%s", index.Name(), field.Name(), name)
+	if err := field.rollupView(name); err != nil {
+		h.Logger.Printf("ERROR rolling up view: err=%s, path=%s", err, field.path)
+	}
+	return true
+}
+
+// deleteExpiredViews deletes the time views which ended more than their
+// field's TTL before now, and instructs the rest of the cluster to do the same.
+// Only the coordinator sweeps expired views, and the sweep is skipped while
+// writes are paused for a backup.
+func (h *Holder) deleteExpiredViews(now time.Time) {
+	if !h.isCoordinator() {
+		return
+	}
+	for _, index := range h.Indexes() {
+		for _, field := range index.Fields() {
+			for _, name := range field.expiredViews(now) {
+				select {
+				case <-h.closing:
+					return
+				default:
+				}
+
+				if !h.deleteExpiredView(index, field, name) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// deleteExpiredView deletes a single expired view and broadcasts the deletion.
+// Returns false if writes are paused.
+func (h *Holder) deleteExpiredView(index *Index, field *Field, name string) bool {
+	if err := h.writes.enter(); err != nil {
+		return false
+	}
+	defer h.writes.exit()
+
+	h.Logger.Printf("deleting expired view: index=%s, field=%s, view=%s", index.Name(), field.Name(), name)
+	if err := field.deleteView(name); err != nil && err != ErrInvalidView {
+		h.Logger.Printf("ERROR deleting expired view: err=%s, path=%s", err, field.path)
+		return true
+	}
+	if err := h.broadcaster.SendSync(&DeleteViewMessage{
+		Index: index.Name(),
+		Field: field.Name(),
+		View:  name,
+	}); err != nil {
+		h.Logger.Printf("ERROR sending DeleteView message: %s", err)
+	}
+	return true
+}
+
 // recalculateCaches recalculates caches on every index in the holder. This is
 // probably not practical to call in real-world workloads, but makes writing
 // integration tests much eaiser, since one doesn't have to wait 10 seconds
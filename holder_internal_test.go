@@ -20,7 +20,9 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pilosa/pilosa/roaring"
 )
@@ -180,6 +182,183 @@ func TestHolder_Optn(t *testing.T) {
 
 }
 
+// Ensure expired time views are deleted and the deletion is broadcast.
+func TestHolder_DeleteExpiredViews(t *testing.T) {
+	h := newHolder()
+	b := &recordingBroadcaster{}
+	h.broadcaster = b
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx := h.MustCreateIndexIfNotExists("i", IndexOptions{})
+	f, err := idx.CreateField("f", func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("D"))(fo); err != nil {
+			return err
+		}
+		return OptFieldTTL(24 * time.Hour)(fo)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ts := range []time.Time{
+		time.Date(2019, time.January, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2019, time.January, 3, 12, 0, 0, 0, time.UTC),
+	} {
+		if _, err := f.SetBit(1, 1, &ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b.messages = nil
+	h.deleteExpiredViews(time.Date(2019, time.January, 3, 0, 0, 0, 0, time.UTC))
+
+	if f.view("standard_20190101") != nil {
+		t.Fatal("expected expired view to be deleted")
+	} else if f.view("standard_20190103") == nil || f.view(viewStandard) == nil {
+		t.Fatal("expected unexpired views to remain")
+	}
+	if exp := []Message{&DeleteViewMessage{Index: "i", Field: "f", View: "standard_20190101"}}; !reflect.DeepEqual(b.messages, exp) {
+		t.Fatalf("unexpected messages: %#v", b.messages)
+	}
+
+	// Hourly views after noon expire by their end on a 24-hour clock.
+	hf, err := idx.CreateField("h", func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("YMDH"))(fo); err != nil {
+			return err
+		}
+		return OptFieldTTL(2 * time.Hour)(fo)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ts := range []time.Time{
+		time.Date(2019, time.January, 1, 13, 0, 0, 0, time.UTC),
+		time.Date(2019, time.January, 1, 23, 0, 0, 0, time.UTC),
+	} {
+		if _, err := hf.SetBit(1, 1, &ts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b.messages = nil
+	h.deleteExpiredViews(time.Date(2019, time.January, 1, 23, 30, 0, 0, time.UTC))
+
+	if hf.view("standard_2019010113") != nil {
+		t.Fatal("expected expired hourly view to be deleted")
+	}
+	for _, name := range []string{"standard_2019010123", "standard_20190101", "standard_201901", "standard_2019", viewStandard} {
+		if hf.view(name) == nil {
+			t.Fatalf("expected unexpired view %s to remain", name)
+		}
+	}
+	if exp := []Message{&DeleteViewMessage{Index: "i", Field: "h", View: "standard_2019010113"}}; !reflect.DeepEqual(b.messages, exp) {
+		t.Fatalf("unexpected messages: %#v", b.messages)
+	}
+}
+
+// Ensure only the coordinator deletes expired views, and not while writes
+// are paused.
+func TestHolder_DeleteExpiredViews_Gated(t *testing.T) {
+	h := newHolder()
+	b := &recordingBroadcaster{}
+	h.broadcaster = b
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx := h.MustCreateIndexIfNotExists("i", IndexOptions{})
+	f, err := idx.CreateField("f", func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("D"))(fo); err != nil {
+			return err
+		}
+		return OptFieldTTL(24 * time.Hour)(fo)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2019, time.January, 1, 12, 0, 0, 0, time.UTC)
+	if _, err := f.SetBit(1, 1, &ts); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2019, time.January, 3, 0, 0, 0, 0, time.UTC)
+
+	h.isCoordinator = func() bool { return false }
+	h.deleteExpiredViews(now)
+	if f.view("standard_20190101") == nil {
+		t.Fatal("expected non-coordinator to keep expired view")
+	}
+
+	h.isCoordinator = func() bool { return true }
+	h.writes.pause(0)
+	h.deleteExpiredViews(now)
+	if f.view("standard_20190101") == nil {
+		t.Fatal("expected expired view to be kept while writes are paused")
+	}
+
+	h.writes.resume()
+	h.deleteExpiredViews(now)
+	if f.view("standard_20190101") != nil {
+		t.Fatal("expected expired view to be deleted")
+	}
+}
+
+// Ensure time views are not rolled up while writes are paused.
+func TestHolder_RollupViews_Paused(t *testing.T) {
+	h := newHolder()
+	if err := h.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	idx := h.MustCreateIndexIfNotExists("i", IndexOptions{})
+	f, err := idx.CreateField("f", func(fo *FieldOptions) error {
+		if err := OptFieldTypeTime(TimeQuantum("DH"))(fo); err != nil {
+			return err
+		}
+		return OptFieldRollup("H:48h")(fo)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2019, time.January, 2, 3, 0, 0, 0, time.UTC)
+	if _, err := f.SetBit(1, 1, &ts); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2019, time.January, 4, 5, 0, 0, 0, time.UTC)
+
+	h.writes.pause(0)
+	h.rollupViews(now)
+	if f.view("standard_2019010203") == nil {
+		t.Fatal("expected hourly view to be kept while writes are paused")
+	}
+
+	h.writes.resume()
+	h.rollupViews(now)
+	if f.view("standard_2019010203") != nil {
+		t.Fatal("expected hourly view to be rolled up")
+	}
+}
+
+// recordingBroadcaster is a broadcaster which records the messages sent.
+type recordingBroadcaster struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (b *recordingBroadcaster) SendSync(m Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, m)
+	return nil
+}
+
+func (b *recordingBroadcaster) SendAsync(m Message) error { return b.SendSync(m) }
+
+func (b *recordingBroadcaster) SendTo(_ *Node, m Message) error { return b.SendSync(m) }
+
 // Ensure holder can clean up orphaned fragments.
 func TestHolderCleaner_CleanHolder(t *testing.T) {
 	cluster := NewTestCluster(2)
@@ -597,15 +597,17 @@ func (c *InternalClient) marshalImportValuePayload(index, field string, shard ui
 	columnIDs := FieldValues(vals).ColumnIDs()
 	columnKeys := FieldValues(vals).ColumnKeys()
 	values := FieldValues(vals).Values()
+	floatValues := FieldValues(vals).FloatValues()
 
 	// Marshal data to protobuf.
 	buf, err := c.serializer.Marshal(&pilosa.ImportValueRequest{
-		Index:      index,
-		Field:      field,
-		Shard:      shard,
-		ColumnIDs:  columnIDs,
-		ColumnKeys: columnKeys,
-		Values:     values,
+		Index:       index,
+		Field:       field,
+		Shard:       shard,
+		ColumnIDs:   columnIDs,
+		ColumnKeys:  columnKeys,
+		Values:      values,
+		FloatValues: floatValues,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("marshal import request: %s", err)
@@ -775,6 +777,208 @@ func (c *InternalClient) RetrieveShardFromURI(ctx context.Context, index, field,
 	return resp.Body, nil
 }
 
+// RestoreFragment replaces a fragment on the node at uri with the fragment
+// data in rd, as written by the fragment's WriteTo method.
+func (c *InternalClient) RestoreFragment(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64, rd io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.RestoreFragment")
+	defer span.Finish()
+
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	u := uriPathToURL(uri, "/internal/fragment/data")
+	u.RawQuery = url.Values{
+		"index": {index},
+		"field": {field},
+		"view":  {view},
+		"shard": {strconv.FormatUint(shard, 10)},
+	}.Encode()
+
+	// Build request.
+	req, err := http.NewRequest("POST", u.String(), rd)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(resp.Body.Close(), "closing response body")
+}
+
+// RestoreBlock replaces the bits of a single fragment block on the node at
+// uri with the bits encoded in rd by an incremental backup.
+func (c *InternalClient) RestoreBlock(ctx context.Context, uri *pilosa.URI, index, field, view string, shard uint64, block int, rd io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.RestoreBlock")
+	defer span.Finish()
+
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	u := uriPathToURL(uri, "/internal/fragment/block/data")
+	u.RawQuery = url.Values{
+		"index": {index},
+		"field": {field},
+		"view":  {view},
+		"shard": {strconv.FormatUint(shard, 10)},
+		"block": {strconv.Itoa(block)},
+	}.Encode()
+
+	// Build request.
+	req, err := http.NewRequest("POST", u.String(), rd)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(resp.Body.Close(), "closing response body")
+}
+
+// RestoreAttrs sets the attributes encoded in rd on the node at uri. The
+// column attributes of the index are set if field is blank, otherwise the
+// row attributes of the field.
+func (c *InternalClient) RestoreAttrs(ctx context.Context, uri *pilosa.URI, index, field string, rd io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.RestoreAttrs")
+	defer span.Finish()
+
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	path := fmt.Sprintf("/internal/index/%s/attr/restore", index)
+	if field != "" {
+		path = fmt.Sprintf("/internal/index/%s/field/%s/attr/restore", index, field)
+	}
+	u := uriPathToURL(uri, path)
+
+	// Build request.
+	req, err := http.NewRequest("POST", u.String(), rd)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(resp.Body.Close(), "closing response body")
+}
+
+// DeleteColumns deletes the columns with the given keys from an index on the
+// node at uri. Returns the number of columns deleted.
+func (c *InternalClient) DeleteColumns(ctx context.Context, uri *pilosa.URI, index string, columnKeys []string) (uint64, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.DeleteColumns")
+	defer span.Finish()
+
+	if uri == nil {
+		uri = c.defaultURI
+	}
+	buf, err := json.Marshal(postDeleteColumnsRequest{ColumnKeys: columnKeys})
+	if err != nil {
+		return 0, errors.Wrap(err, "marshaling request")
+	}
+	u := uriPathToURL(uri, fmt.Sprintf("/index/%s/delete-columns", index))
+
+	// Build request.
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return 0, errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var rsp postDeleteColumnsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rsp); err != nil {
+		return 0, errors.Wrap(err, "decoding response")
+	}
+	return rsp.Deleted, nil
+}
+
+// Backup writes an archive of the whole cluster to w. The archive can be
+// restored onto an empty cluster using Restore. If prev is not nil then an
+// incremental backup based on the backup described by prev is written.
+func (c *InternalClient) Backup(ctx context.Context, w io.Writer, prev *pilosa.BackupManifest) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Backup")
+	defer span.Finish()
+
+	// Incremental backups send the manifest of the previous backup.
+	method, body := "GET", io.Reader(nil)
+	if prev != nil {
+		buf, err := json.Marshal(prev)
+		if err != nil {
+			return errors.Wrap(err, "marshaling manifest")
+		}
+		method, body = "POST", bytes.NewReader(buf)
+	}
+
+	u := uriPathToURL(c.defaultURI, "/backup")
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	if prev != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/x-tar")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The archive is only complete if it is followed by the trailer.
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "copying archive")
+	} else if msg := resp.Trailer.Get(backupErrorTrailer); msg != "" {
+		return errors.Errorf("backup failed: %s", msg)
+	}
+	return nil
+}
+
+// Restore restores an archive written by Backup onto an empty cluster.
+func (c *InternalClient) Restore(ctx context.Context, rd io.Reader) error {
+	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.Restore")
+	defer span.Finish()
+
+	u := uriPathToURL(c.defaultURI, "/restore")
+	req, err := http.NewRequest("POST", u.String(), rd)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.Header.Set("User-Agent", "pilosa/"+pilosa.Version)
+
+	// Execute request.
+	resp, err := c.executeRequest(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(resp.Body.Close(), "closing response body")
+}
+
 func (c *InternalClient) CreateField(ctx context.Context, index, field string) error {
 	span, ctx := tracing.StartSpanFromContext(ctx, "InternalClient.CreateField")
 	defer span.Finish()
@@ -799,10 +1003,32 @@ func (c *InternalClient) CreateFieldWithOptions(ctx context.Context, index, fiel
 		fieldOpt.CacheType = &opt.CacheType
 		fieldOpt.CacheSize = &opt.CacheSize
 	} else if fieldOpt.Type == "int" {
-		fieldOpt.Min = &opt.Min
-		fieldOpt.Max = &opt.Max
+		min, max := json.Number(strconv.FormatInt(opt.Min, 10)), json.Number(strconv.FormatInt(opt.Max, 10))
+		fieldOpt.Min = &min
+		fieldOpt.Max = &max
+		if opt.ForeignIndex != "" {
+			fieldOpt.ForeignIndex = &opt.ForeignIndex
+		}
+	} else if fieldOpt.Type == "decimal" {
+		min, max := json.Number(opt.DecimalMin()), json.Number(opt.DecimalMax())
+		fieldOpt.Min = &min
+		fieldOpt.Max = &max
+		fieldOpt.Scale = &opt.Scale
+	} else if fieldOpt.Type == "timestamp" {
+		fieldOpt.TimeUnit = &opt.TimeUnit
 	} else if fieldOpt.Type == "time" {
 		fieldOpt.TimeQuantum = &opt.TimeQuantum
+		if opt.CacheType != "" && opt.CacheType != pilosa.CacheTypeNone {
+			fieldOpt.CacheType = &opt.CacheType
+			fieldOpt.CacheSize = &opt.CacheSize
+		}
+		if opt.TTL != 0 {
+			ttl := opt.TTL.String()
+			fieldOpt.TTL = &ttl
+		}
+		if opt.Rollup != "" {
+			fieldOpt.Rollup = &opt.Rollup
+		}
 	}
 
 	// TODO: remove buf completely? (depends on whether importer needs to create specific field types)
@@ -1220,8 +1446,21 @@ func (p FieldValues) ColumnKeys() []string {
 	return other
 }
 
+// HasFloatValues returns true if any values use a float value.
+func (p FieldValues) HasFloatValues() bool {
+	for i := range p {
+		if p[i].FloatValue != 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Values returns a slice of all the values.
 func (p FieldValues) Values() []int64 {
+	if p.HasFloatValues() {
+		return nil
+	}
 	other := make([]int64, len(p))
 	for i := range p {
 		other[i] = p[i].Value
@@ -1229,6 +1468,18 @@ func (p FieldValues) Values() []int64 {
 	return other
 }
 
+// FloatValues returns a slice of all the float values.
+func (p FieldValues) FloatValues() []float64 {
+	if !p.HasFloatValues() {
+		return nil
+	}
+	other := make([]float64, len(p))
+	for i := range p {
+		other[i] = p[i].FloatValue
+	}
+	return other
+}
+
 // GroupByShard returns a map of field values by shard.
 func (p FieldValues) GroupByShard() map[uint64][]pilosa.FieldValue {
 	m := make(map[uint64][]pilosa.FieldValue)
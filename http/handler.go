@@ -173,6 +173,9 @@ func (h *Handler) Close() error {
 func (h *Handler) populateValidators() {
 	h.validators = map[string]*queryValidationSpec{}
 	h.validators["Home"] = queryValidationSpecRequired()
+	h.validators["GetBackup"] = queryValidationSpecRequired()
+	h.validators["PostBackup"] = queryValidationSpecRequired()
+	h.validators["GetChangeEvents"] = queryValidationSpecRequired().Optional("offset", "limit", "timeout")
 	h.validators["PostClusterResizeAbort"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeRemoveNode"] = queryValidationSpecRequired()
 	h.validators["PostClusterResizeSetCoordinator"] = queryValidationSpecRequired()
@@ -183,22 +186,28 @@ func (h *Handler) populateValidators() {
 	h.validators["DeleteIndex"] = queryValidationSpecRequired()
 	h.validators["PostField"] = queryValidationSpecRequired()
 	h.validators["DeleteField"] = queryValidationSpecRequired()
+	h.validators["PostDeleteColumns"] = queryValidationSpecRequired()
 	h.validators["PostImport"] = queryValidationSpecRequired().Optional("clear", "ignoreKeyCheck")
 	h.validators["PostImportRoaring"] = queryValidationSpecRequired().Optional("remote", "clear")
 	h.validators["PostQuery"] = queryValidationSpecRequired().Optional("shards", "columnAttrs", "excludeRowAttrs", "excludeColumns")
 	h.validators["GetInfo"] = queryValidationSpecRequired()
 	h.validators["RecalculateCaches"] = queryValidationSpecRequired()
+	h.validators["PostRestore"] = queryValidationSpecRequired()
 	h.validators["GetSchema"] = queryValidationSpecRequired()
 	h.validators["PostSchema"] = queryValidationSpecRequired().Optional("remote")
 	h.validators["GetStatus"] = queryValidationSpecRequired()
 	h.validators["GetVersion"] = queryValidationSpecRequired()
 	h.validators["PostClusterMessage"] = queryValidationSpecRequired()
 	h.validators["GetFragmentBlockData"] = queryValidationSpecRequired()
+	h.validators["PostFragmentBlockData"] = queryValidationSpecRequired("index", "field", "view", "shard", "block")
 	h.validators["GetFragmentBlocks"] = queryValidationSpecRequired("index", "field", "view", "shard")
 	h.validators["GetFragmentData"] = queryValidationSpecRequired("index", "field", "view", "shard")
+	h.validators["PostFragmentData"] = queryValidationSpecRequired("index", "field", "view", "shard")
 	h.validators["GetFragmentNodes"] = queryValidationSpecRequired("shard", "index")
 	h.validators["PostIndexAttrDiff"] = queryValidationSpecRequired()
+	h.validators["PostIndexAttrRestore"] = queryValidationSpecRequired()
 	h.validators["PostFieldAttrDiff"] = queryValidationSpecRequired()
+	h.validators["PostFieldAttrRestore"] = queryValidationSpecRequired()
 	h.validators["GetNodes"] = queryValidationSpecRequired()
 	h.validators["GetShardMax"] = queryValidationSpecRequired()
 	h.validators["GetTranslateData"] = queryValidationSpecRequired("offset")
@@ -274,6 +283,9 @@ func (h *Handler) collectStats(next http.Handler) http.Handler {
 func newRouter(handler *Handler) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc("/", handler.handleHome).Methods("GET").Name("Home")
+	router.HandleFunc("/backup", handler.handleGetBackup).Methods("GET").Name("GetBackup")
+	router.HandleFunc("/backup", handler.handlePostBackup).Methods("POST").Name("PostBackup")
+	router.HandleFunc("/cdc", handler.handleGetChangeEvents).Methods("GET").Name("GetChangeEvents")
 	router.HandleFunc("/cluster/resize/abort", handler.handlePostClusterResizeAbort).Methods("POST").Name("PostClusterResizeAbort")
 	router.HandleFunc("/cluster/resize/remove-node", handler.handlePostClusterResizeRemoveNode).Methods("POST").Name("PostClusterResizeRemoveNode")
 	router.HandleFunc("/cluster/resize/set-coordinator", handler.handlePostClusterResizeSetCoordinator).Methods("POST").Name("PostClusterResizeSetCoordinator")
@@ -286,6 +298,7 @@ func newRouter(handler *Handler) *mux.Router {
 	router.HandleFunc("/index/{index}", handler.handlePostIndex).Methods("POST").Name("PostIndex")
 	router.HandleFunc("/index/{index}", handler.handleDeleteIndex).Methods("DELETE").Name("DeleteIndex")
 	//router.HandleFunc("/index/{index}/field", handler.handleGetFields).Methods("GET") // Not implemented.
+	router.HandleFunc("/index/{index}/delete-columns", handler.handlePostDeleteColumns).Methods("POST").Name("PostDeleteColumns")
 	router.HandleFunc("/index/{index}/field/{field}", handler.handlePostField).Methods("POST").Name("PostField")
 	router.HandleFunc("/index/{index}/field/{field}", handler.handleDeleteField).Methods("DELETE").Name("DeleteField")
 	router.HandleFunc("/index/{index}/field/{field}/import", handler.handlePostImport).Methods("POST").Name("PostImport")
@@ -293,6 +306,7 @@ func newRouter(handler *Handler) *mux.Router {
 	router.HandleFunc("/index/{index}/query", handler.handlePostQuery).Methods("POST").Name("PostQuery")
 	router.HandleFunc("/info", handler.handleGetInfo).Methods("GET").Name("GetInfo")
 	router.HandleFunc("/recalculate-caches", handler.handleRecalculateCaches).Methods("POST").Name("RecalculateCaches")
+	router.HandleFunc("/restore", handler.handlePostRestore).Methods("POST").Name("PostRestore")
 	router.HandleFunc("/schema", handler.handleGetSchema).Methods("GET").Name("GetSchema")
 	router.HandleFunc("/schema", handler.handlePostSchema).Methods("POST").Name("PostSchema")
 	router.HandleFunc("/status", handler.handleGetStatus).Methods("GET").Name("GetStatus")
@@ -302,11 +316,15 @@ func newRouter(handler *Handler) *mux.Router {
 	// DO NOT rely on these for external applications!
 	router.HandleFunc("/internal/cluster/message", handler.handlePostClusterMessage).Methods("POST").Name("PostClusterMessage")
 	router.HandleFunc("/internal/fragment/block/data", handler.handleGetFragmentBlockData).Methods("GET").Name("GetFragmentBlockData")
+	router.HandleFunc("/internal/fragment/block/data", handler.handlePostFragmentBlockData).Methods("POST").Name("PostFragmentBlockData")
 	router.HandleFunc("/internal/fragment/blocks", handler.handleGetFragmentBlocks).Methods("GET").Name("GetFragmentBlocks")
 	router.HandleFunc("/internal/fragment/data", handler.handleGetFragmentData).Methods("GET").Name("GetFragmentData")
+	router.HandleFunc("/internal/fragment/data", handler.handlePostFragmentData).Methods("POST").Name("PostFragmentData")
 	router.HandleFunc("/internal/fragment/nodes", handler.handleGetFragmentNodes).Methods("GET").Name("GetFragmentNodes")
 	router.HandleFunc("/internal/index/{index}/attr/diff", handler.handlePostIndexAttrDiff).Methods("POST").Name("PostIndexAttrDiff")
+	router.HandleFunc("/internal/index/{index}/attr/restore", handler.handlePostAttrRestore).Methods("POST").Name("PostIndexAttrRestore")
 	router.HandleFunc("/internal/index/{index}/field/{field}/attr/diff", handler.handlePostFieldAttrDiff).Methods("POST").Name("PostFieldAttrDiff")
+	router.HandleFunc("/internal/index/{index}/field/{field}/attr/restore", handler.handlePostAttrRestore).Methods("POST").Name("PostFieldAttrRestore")
 	router.HandleFunc("/internal/index/{index}/field/{field}/remote-available-shards/{shardID}", handler.handleDeleteRemoteAvailableShard).Methods("DELETE")
 	router.HandleFunc("/internal/nodes", handler.handleGetNodes).Methods("GET").Name("GetNodes")
 	router.HandleFunc("/internal/shards/max", handler.handleGetShardsMax).Methods("GET").Name("GetShardsMax") // TODO: deprecate, but it's being used by the client
@@ -506,6 +524,12 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	// TODO: Remove
 	req.Index = mux.Vars(r)["index"]
 
+	// Stream results as newline-delimited JSON, if requested.
+	if r.Header.Get("Accept") == "application/x-ndjson" {
+		h.writeQueryStream(w, r, req)
+		return
+	}
+
 	resp, err := h.api.Query(r.Context(), req)
 	if err != nil {
 		switch errors.Cause(resp.Err) {
@@ -539,6 +563,54 @@ func (h *Handler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// writeQueryStream executes the query and writes each result to w as a line
+// of JSON as soon as it is available. The columns of row results are written
+// one shard per line. Errors which occur after the first line has been
+// written are reported on a final line.
+func (h *Handler) writeQueryStream(w http.ResponseWriter, r *http.Request, req *pilosa.QueryRequest) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	var written bool
+	err := h.api.QueryStream(r.Context(), req, func(call int, result interface{}) error {
+		written = true
+		if err := enc.Encode(queryStreamLine{Call: call, Result: result}); err != nil {
+			return errors.Wrap(err, "writing")
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		return
+	}
+
+	if !written {
+		switch errors.Cause(err) {
+		case pilosa.ErrTooManyWrites:
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+	if e := enc.Encode(queryStreamError{Err: err.Error()}); e != nil {
+		h.logger.Printf("write query stream error: %v (while trying to write another error: %v)", e, err)
+	}
+}
+
+// queryStreamLine is a single line of a streamed query response.
+type queryStreamLine struct {
+	Call   int         `json:"call"`
+	Result interface{} `json:"result"`
+}
+
+// queryStreamError is the final line of a streamed query response which failed.
+type queryStreamError struct {
+	Err string `json:"error"`
+}
+
 // handleGetShardsMax handles GET /internal/shards/max requests.
 func (h *Handler) handleGetShardsMax(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -583,7 +655,7 @@ type postIndexRequest struct {
 	Options pilosa.IndexOptions `json:"options"`
 }
 
-//_postIndexRequest is necessary to avoid recursion while decoding.
+// _postIndexRequest is necessary to avoid recursion while decoding.
 type _postIndexRequest postIndexRequest
 
 // Custom Unmarshal JSON to validate request body when creating a new index.
@@ -739,6 +811,55 @@ type postIndexAttrDiffResponse struct {
 	Attrs map[uint64]map[string]interface{} `json:"attrs"`
 }
 
+// handlePostDeleteColumns handles POST /index/{index}/delete-columns requests.
+func (h *Handler) handlePostDeleteColumns(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+	indexName := mux.Vars(r)["index"]
+
+	// Decode request.
+	var req postDeleteColumnsRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n, err := h.api.DeleteColumns(r.Context(), indexName, req.ColumnIDs, req.ColumnKeys)
+	if err != nil {
+		switch cause := errors.Cause(err); cause.(type) {
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			if cause == pilosa.ErrWritesPaused {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+		}
+		return
+	}
+
+	// Encode response.
+	if err := json.NewEncoder(w).Encode(postDeleteColumnsResponse{Deleted: n}); err != nil {
+		h.logger.Printf("response encoding error: %s", err)
+	}
+}
+
+type postDeleteColumnsRequest struct {
+	ColumnIDs  []uint64 `json:"columnIDs"`
+	ColumnKeys []string `json:"columnKeys"`
+}
+
+type postDeleteColumnsResponse struct {
+	Deleted uint64 `json:"deleted"`
+}
+
 // handlePostField handles POST /field request.
 func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -772,17 +893,43 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 	case pilosa.FieldTypeSet:
 		fos = append(fos, pilosa.OptFieldTypeSet(*req.Options.CacheType, *req.Options.CacheSize))
 	case pilosa.FieldTypeInt:
-		if req.Options.Min == nil {
-			min := int64(math.MinInt64)
-			req.Options.Min = &min
+		min, max := int64(math.MinInt64), int64(math.MaxInt64)
+		if req.Options.Min != nil {
+			if min, err = req.Options.Min.Int64(); err != nil {
+				http.Error(w, "invalid min: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if req.Options.Max != nil {
+			if max, err = req.Options.Max.Int64(); err != nil {
+				http.Error(w, "invalid max: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		fos = append(fos, pilosa.OptFieldTypeInt(min, max))
+	case pilosa.FieldTypeDecimal:
+		// Bounds outside of the range representable at the scale are clamped.
+		min, max := math.Inf(-1), math.Inf(1)
+		if req.Options.Min != nil {
+			if min, err = req.Options.Min.Float64(); err != nil {
+				http.Error(w, "invalid min: "+err.Error(), http.StatusBadRequest)
+				return
+			}
 		}
-		if req.Options.Max == nil {
-			max := int64(math.MaxInt64)
-			req.Options.Max = &max
+		if req.Options.Max != nil {
+			if max, err = req.Options.Max.Float64(); err != nil {
+				http.Error(w, "invalid max: "+err.Error(), http.StatusBadRequest)
+				return
+			}
 		}
-		fos = append(fos, pilosa.OptFieldTypeInt(*req.Options.Min, *req.Options.Max))
+		fos = append(fos, pilosa.OptFieldTypeDecimal(*req.Options.Scale, min, max))
+	case pilosa.FieldTypeTimestamp:
+		fos = append(fos, pilosa.OptFieldTypeTimestamp(*req.Options.TimeUnit))
 	case pilosa.FieldTypeTime:
 		fos = append(fos, pilosa.OptFieldTypeTime(*req.Options.TimeQuantum, req.Options.NoStandardView))
+		if req.Options.CacheType != nil {
+			fos = append(fos, pilosa.OptFieldCache(*req.Options.CacheType, *req.Options.CacheSize))
+		}
 	case pilosa.FieldTypeMutex:
 		fos = append(fos, pilosa.OptFieldTypeMutex(*req.Options.CacheType, *req.Options.CacheSize))
 	case pilosa.FieldTypeBool:
@@ -793,6 +940,20 @@ func (h *Handler) handlePostField(w http.ResponseWriter, r *http.Request) {
 			fos = append(fos, pilosa.OptFieldKeys())
 		}
 	}
+	if req.Options.ForeignIndex != nil {
+		fos = append(fos, pilosa.OptFieldForeignIndex(*req.Options.ForeignIndex))
+	}
+	if req.Options.TTL != nil {
+		ttl, err := time.ParseDuration(*req.Options.TTL)
+		if err != nil {
+			http.Error(w, "invalid ttl: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		fos = append(fos, pilosa.OptFieldTTL(ttl))
+	}
+	if req.Options.Rollup != nil {
+		fos = append(fos, pilosa.OptFieldRollup(*req.Options.Rollup))
+	}
 
 	_, err = h.api.CreateField(r.Context(), indexName, fieldName, fos...)
 	if _, ok := err.(pilosa.BadRequestError); ok {
@@ -809,20 +970,26 @@ type postFieldRequest struct {
 // fieldOptions tracks pilosa.FieldOptions. It is made up of pointers to values,
 // and used for input validation.
 type fieldOptions struct {
-	Type           string              `json:"type,omitempty"`
-	CacheType      *string             `json:"cacheType,omitempty"`
-	CacheSize      *uint32             `json:"cacheSize,omitempty"`
-	Min            *int64              `json:"min,omitempty"`
-	Max            *int64              `json:"max,omitempty"`
-	TimeQuantum    *pilosa.TimeQuantum `json:"timeQuantum,omitempty"`
-	Keys           *bool               `json:"keys,omitempty"`
-	NoStandardView bool                `json:"noStandardView,omitempty"`
+	Type           string               `json:"type,omitempty"`
+	CacheType      *string              `json:"cacheType,omitempty"`
+	CacheSize      *uint32              `json:"cacheSize,omitempty"`
+	Min            *json.Number         `json:"min,omitempty"`
+	Max            *json.Number         `json:"max,omitempty"`
+	Scale          *int64               `json:"scale,omitempty"`
+	TimeUnit       *string              `json:"timeUnit,omitempty"`
+	ForeignIndex   *string              `json:"foreignIndex,omitempty"`
+	TimeQuantum    *pilosa.TimeQuantum  `json:"timeQuantum,omitempty"`
+	TTL            *string              `json:"ttl,omitempty"`
+	Rollup         *pilosa.RollupPolicy `json:"rollup,omitempty"`
+	Keys           *bool                `json:"keys,omitempty"`
+	NoStandardView bool                 `json:"noStandardView,omitempty"`
 }
 
 func (o *fieldOptions) validate() error {
 	// Pointers to default values.
 	defaultCacheType := pilosa.DefaultCacheType
 	defaultCacheSize := uint32(pilosa.DefaultCacheSize)
+	defaultTimeUnit := pilosa.TimeUnitSeconds
 
 	switch o.Type {
 	case pilosa.FieldTypeSet, "":
@@ -852,13 +1019,51 @@ func (o *fieldOptions) validate() error {
 			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type int"))
 		} else if o.TimeQuantum != nil {
 			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type int"))
+		} else if o.Scale != nil {
+			return pilosa.NewBadRequestError(errors.New("scale does not apply to field type int"))
+		} else if o.TimeUnit != nil {
+			return pilosa.NewBadRequestError(errors.New("timeUnit does not apply to field type int"))
+		}
+	case pilosa.FieldTypeDecimal:
+		if o.CacheType != nil {
+			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type decimal"))
+		} else if o.CacheSize != nil {
+			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type decimal"))
+		} else if o.TimeQuantum != nil {
+			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type decimal"))
+		} else if o.Scale == nil {
+			return pilosa.NewBadRequestError(errors.New("scale is required for field type decimal"))
+		} else if o.TimeUnit != nil {
+			return pilosa.NewBadRequestError(errors.New("timeUnit does not apply to field type decimal"))
+		}
+	case pilosa.FieldTypeTimestamp:
+		if o.TimeUnit == nil {
+			o.TimeUnit = &defaultTimeUnit
 		}
-	case pilosa.FieldTypeTime:
 		if o.CacheType != nil {
-			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type time"))
+			return pilosa.NewBadRequestError(errors.New("cacheType does not apply to field type timestamp"))
 		} else if o.CacheSize != nil {
-			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type time"))
+			return pilosa.NewBadRequestError(errors.New("cacheSize does not apply to field type timestamp"))
 		} else if o.Min != nil {
+			return pilosa.NewBadRequestError(errors.New("min does not apply to field type timestamp"))
+		} else if o.Max != nil {
+			return pilosa.NewBadRequestError(errors.New("max does not apply to field type timestamp"))
+		} else if o.Scale != nil {
+			return pilosa.NewBadRequestError(errors.New("scale does not apply to field type timestamp"))
+		} else if o.TimeQuantum != nil {
+			return pilosa.NewBadRequestError(errors.New("timeQuantum does not apply to field type timestamp"))
+		} else if o.Keys != nil && *o.Keys {
+			return pilosa.NewBadRequestError(errors.New("keys does not apply to field type timestamp"))
+		}
+	case pilosa.FieldTypeTime:
+		// Time fields have no cache unless one is requested.
+		if o.CacheType == nil && o.CacheSize != nil {
+			o.CacheType = &defaultCacheType
+		}
+		if o.CacheType != nil && o.CacheSize == nil {
+			o.CacheSize = &defaultCacheSize
+		}
+		if o.Min != nil {
 			return pilosa.NewBadRequestError(errors.New("min does not apply to field type time"))
 		} else if o.Max != nil {
 			return pilosa.NewBadRequestError(errors.New("max does not apply to field type time"))
@@ -896,6 +1101,15 @@ func (o *fieldOptions) validate() error {
 	default:
 		return errors.Errorf("invalid field type: %s", o.Type)
 	}
+	if o.ForeignIndex != nil && o.Type != pilosa.FieldTypeInt {
+		return pilosa.NewBadRequestError(errors.Errorf("foreignIndex does not apply to field type %s", o.Type))
+	}
+	if o.TTL != nil && o.Type != pilosa.FieldTypeTime {
+		return pilosa.NewBadRequestError(errors.Errorf("ttl does not apply to field type %s", o.Type))
+	}
+	if o.Rollup != nil && o.Type != pilosa.FieldTypeTime {
+		return pilosa.NewBadRequestError(errors.Errorf("rollup does not apply to field type %s", o.Type))
+	}
 	return nil
 }
 
@@ -1096,8 +1310,8 @@ func (h *Handler) handlePostImport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Unmarshal request based on field type.
-	if field.Type() == pilosa.FieldTypeInt {
-		// Field type: Int
+	if field.Type() == pilosa.FieldTypeInt || field.Type() == pilosa.FieldTypeDecimal || field.Type() == pilosa.FieldTypeTimestamp {
+		// Field type: Int, Decimal, Timestamp
 		// Marshal into request object.
 		req := &pilosa.ImportValueRequest{}
 		if err := h.api.Serializer.Unmarshal(body, req); err != nil {
@@ -1307,6 +1521,222 @@ func (h *Handler) handleGetFragmentData(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// handlePostFragmentData handles POST /internal/fragment/data requests.
+func (h *Handler) handlePostFragmentData(w http.ResponseWriter, r *http.Request) {
+	// Read shard parameter.
+	q := r.URL.Query()
+	shard, err := strconv.ParseUint(q.Get("shard"), 10, 64)
+	if err != nil {
+		http.Error(w, "shard required", http.StatusBadRequest)
+		return
+	}
+	// Replace fragment data in holder.
+	if err := h.api.RestoreFragment(r.Context(), q.Get("index"), q.Get("field"), q.Get("view"), shard, r.Body); err != nil {
+		if _, ok := err.(pilosa.NotFoundError); ok {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePostFragmentBlockData handles POST /internal/fragment/block/data requests.
+func (h *Handler) handlePostFragmentBlockData(w http.ResponseWriter, r *http.Request) {
+	// Read shard and block parameters.
+	q := r.URL.Query()
+	shard, err := strconv.ParseUint(q.Get("shard"), 10, 64)
+	if err != nil {
+		http.Error(w, "shard required", http.StatusBadRequest)
+		return
+	}
+	block, err := strconv.Atoi(q.Get("block"))
+	if err != nil || block < 0 {
+		http.Error(w, "block required", http.StatusBadRequest)
+		return
+	}
+	// Replace block data in holder.
+	if err := h.api.RestoreBlock(r.Context(), q.Get("index"), q.Get("field"), q.Get("view"), shard, block, r.Body); err != nil {
+		switch err.(type) {
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePostAttrRestore handles POST /internal/index/{index}/attr/restore and
+// /internal/index/{index}/field/{field}/attr/restore requests.
+func (h *Handler) handlePostAttrRestore(w http.ResponseWriter, r *http.Request) {
+	indexName := mux.Vars(r)["index"]
+	fieldName := mux.Vars(r)["field"]
+
+	if err := h.api.RestoreAttrs(r.Context(), indexName, fieldName, r.Body); err != nil {
+		switch err.(type) {
+		case pilosa.NotFoundError:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case pilosa.BadRequestError:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// backupErrorTrailer is the HTTP trailer used to report an error which
+// occurred after the backup archive started streaming.
+const backupErrorTrailer = "X-Pilosa-Backup-Error"
+
+// handleGetBackup handles GET /backup requests.
+func (h *Handler) handleGetBackup(w http.ResponseWriter, r *http.Request) {
+	h.writeBackup(w, r, nil)
+}
+
+// handlePostBackup handles POST /backup requests. The body contains the
+// manifest of the backup which the incremental backup is based on.
+func (h *Handler) handlePostBackup(w http.ResponseWriter, r *http.Request) {
+	var prev pilosa.BackupManifest
+	if err := json.NewDecoder(r.Body).Decode(&prev); err != nil {
+		http.Error(w, "decoding manifest: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeBackup(w, r, &prev)
+}
+
+// writeBackup writes a backup archive to the response.
+func (h *Handler) writeBackup(w http.ResponseWriter, r *http.Request, prev *pilosa.BackupManifest) {
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Trailer", backupErrorTrailer)
+
+	bw := &backupWriter{w: w}
+	if err := h.api.Backup(r.Context(), bw, prev); err != nil {
+		// Errors can only be reported with a status code until the
+		// archive starts streaming.
+		if bw.n > 0 {
+			w.Header().Set(backupErrorTrailer, err.Error())
+			return
+		}
+		switch errors.Cause(err) {
+		case pilosa.ErrNodeNotCoordinator, pilosa.ErrInvalidBackup:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// backupWriter counts the bytes written to the response.
+type backupWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *backupWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// handlePostRestore handles POST /restore requests.
+func (h *Handler) handlePostRestore(w http.ResponseWriter, r *http.Request) {
+	if err := h.api.Restore(r.Context(), r.Body); err != nil {
+		switch errors.Cause(err) {
+		case pilosa.ErrNodeNotCoordinator, pilosa.ErrRestoreNotEmpty, pilosa.ErrInvalidBackup, pilosa.ErrBackupParent:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Defaults for GET /cdc requests.
+const (
+	defaultChangeEventsLimit   = 1000
+	defaultChangeEventsTimeout = 30 * time.Second
+)
+
+// handleGetChangeEvents handles GET /cdc requests. The request waits until
+// events are available or the timeout elapses, so consumers can long-poll
+// by passing the returned offset to the next request.
+func (h *Handler) handleGetChangeEvents(w http.ResponseWriter, r *http.Request) {
+	if !validHeaderAcceptJSON(r.Header) {
+		http.Error(w, "JSON only acceptable response", http.StatusNotAcceptable)
+		return
+	}
+
+	q := r.URL.Query()
+	var offset int64
+	if s := q.Get("offset"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		offset = v
+	}
+	limit := defaultChangeEventsLimit
+	if s := q.Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+	timeout := defaultChangeEventsTimeout
+	if s := q.Get("timeout"); s != "" {
+		v, err := time.ParseDuration(s)
+		if err != nil || v < 0 {
+			http.Error(w, "invalid timeout", http.StatusBadRequest)
+			return
+		}
+		timeout = v
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	events, next, err := h.api.ChangeEvents(ctx, offset, limit)
+	if err != nil {
+		switch errors.Cause(err) {
+		case pilosa.ErrChangeLogDisabled:
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+		case pilosa.ErrInvalidChangeLogOffset:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case pilosa.ErrChangeLogTruncated:
+			http.Error(w, err.Error(), http.StatusGone)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if events == nil {
+		events = []pilosa.ChangeEvent{}
+	}
+	if err := json.NewEncoder(w).Encode(getChangeEventsResponse{
+		Events: events,
+		Offset: next,
+	}); err != nil {
+		h.logger.Printf("write change events response error: %s", err)
+	}
+}
+
+type getChangeEventsResponse struct {
+	Events []pilosa.ChangeEvent `json:"events"`
+	Offset int64                `json:"offset"`
+}
+
 // handleGetVersion handles /version requests.
 func (h *Handler) handleGetVersion(w http.ResponseWriter, r *http.Request) {
 	if !validHeaderAcceptJSON(r.Header) {
@@ -1439,7 +1869,7 @@ func (h *Handler) handlePostClusterResizeAbort(w http.ResponseWriter, r *http.Re
 	var msg string
 	if err != nil {
 		switch errors.Cause(err) {
-		case pilosa.ErrNodeNotCoordinator:
+		case pilosa.ErrNodeNotCoordinator, pilosa.ErrInvalidBackup:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		case pilosa.ErrResizeNotRunning:
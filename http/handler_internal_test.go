@@ -103,10 +103,17 @@ func int64Ptr(i int64) *int64 {
 	return &i
 }
 
+func numberPtr(s string) *json.Number {
+	n := json.Number(s)
+	return &n
+}
+
 // Test fieldOption validation.
 func TestFieldOptionValidation(t *testing.T) {
 	timeQuantum := pilosa.TimeQuantum("YMD")
+	rollup := pilosa.RollupPolicy("D:2160h")
 	defaultCacheSize := uint32(pilosa.DefaultCacheSize)
+	cacheSize := uint32(1000)
 	tests := []struct {
 		json     string
 		expected postFieldRequest
@@ -137,12 +144,45 @@ func TestFieldOptionValidation(t *testing.T) {
 		{json: `{"options": {"type": "int", "min": 0}}`, err: "max is required for field type int"},
 		{json: `{"options": {"type": "int", "min": 0, "max": 1000}}`, expected: postFieldRequest{Options: fieldOptions{
 			Type: pilosa.FieldTypeInt,
-			Min:  int64Ptr(0),
-			Max:  int64Ptr(1000),
+			Min:  numberPtr("0"),
+			Max:  numberPtr("1000"),
 		}}},
 		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "cacheType": "ranked"}}`, err: "cacheType does not apply to field type int"},
 		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "cacheSize": 1000}}`, err: "cacheSize does not apply to field type int"},
 		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "timeQuantum": "YMD"}}`, err: "timeQuantum does not apply to field type int"},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "scale": 2}}`, err: "scale does not apply to field type int"},
+		{json: `{"options": {"type": "int", "min": 0, "max": 1000, "foreignIndex": "users"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:         pilosa.FieldTypeInt,
+			Min:          numberPtr("0"),
+			Max:          numberPtr("1000"),
+			ForeignIndex: stringPtr("users"),
+		}}},
+		{json: `{"options": {"type": "set", "foreignIndex": "users"}}`, err: "foreignIndex does not apply to field type set"},
+
+		// FieldType: Decimal
+		{json: `{"options": {"type": "decimal", "min": 0, "max": 9.99}}`, err: "scale is required for field type decimal"},
+		{json: `{"options": {"type": "decimal", "scale": 2, "min": -0.5, "max": 9.99}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:  pilosa.FieldTypeDecimal,
+			Min:   numberPtr("-0.5"),
+			Max:   numberPtr("9.99"),
+			Scale: int64Ptr(2),
+		}}},
+		{json: `{"options": {"type": "decimal", "scale": 2, "cacheType": "ranked"}}`, err: "cacheType does not apply to field type decimal"},
+		{json: `{"options": {"type": "decimal", "scale": 2, "timeQuantum": "YMD"}}`, err: "timeQuantum does not apply to field type decimal"},
+
+		// FieldType: Timestamp
+		{json: `{"options": {"type": "timestamp"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:     pilosa.FieldTypeTimestamp,
+			TimeUnit: stringPtr(pilosa.TimeUnitSeconds),
+		}}},
+		{json: `{"options": {"type": "timestamp", "timeUnit": "ms"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:     pilosa.FieldTypeTimestamp,
+			TimeUnit: stringPtr(pilosa.TimeUnitMilliseconds),
+		}}},
+		{json: `{"options": {"type": "timestamp", "min": 0}}`, err: "min does not apply to field type timestamp"},
+		{json: `{"options": {"type": "timestamp", "cacheType": "ranked"}}`, err: "cacheType does not apply to field type timestamp"},
+		{json: `{"options": {"type": "timestamp", "timeQuantum": "YMD"}}`, err: "timeQuantum does not apply to field type timestamp"},
+		{json: `{"options": {"type": "timestamp", "keys": true}}`, err: "keys does not apply to field type timestamp"},
 
 		// FieldType: Time
 		{json: `{"options": {"type": "time"}}`, err: "timeQuantum is required for field type time"},
@@ -152,8 +192,36 @@ func TestFieldOptionValidation(t *testing.T) {
 		}}},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "min": 0}}`, err: "min does not apply to field type time"},
 		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "max": 1000}}`, err: "max does not apply to field type time"},
-		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "ranked"}}`, err: "cacheType does not apply to field type time"},
-		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheSize": 1000}}`, err: "cacheSize does not apply to field type time"},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "ranked"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:        pilosa.FieldTypeTime,
+			TimeQuantum: &timeQuantum,
+			CacheType:   stringPtr("ranked"),
+			CacheSize:   &defaultCacheSize,
+		}}},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheSize": 1000}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:        pilosa.FieldTypeTime,
+			TimeQuantum: &timeQuantum,
+			CacheType:   stringPtr(pilosa.DefaultCacheType),
+			CacheSize:   &cacheSize,
+		}}},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "cacheType": "none"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:        pilosa.FieldTypeTime,
+			TimeQuantum: &timeQuantum,
+			CacheType:   stringPtr(pilosa.CacheTypeNone),
+			CacheSize:   &defaultCacheSize,
+		}}},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "ttl": "720h"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:        pilosa.FieldTypeTime,
+			TimeQuantum: &timeQuantum,
+			TTL:         stringPtr("720h"),
+		}}},
+		{json: `{"options": {"type": "set", "ttl": "720h"}}`, err: "ttl does not apply to field type set"},
+		{json: `{"options": {"type": "time", "timeQuantum": "YMD", "rollup": "D:2160h"}}`, expected: postFieldRequest{Options: fieldOptions{
+			Type:        pilosa.FieldTypeTime,
+			TimeQuantum: &timeQuantum,
+			Rollup:      &rollup,
+		}}},
+		{json: `{"options": {"type": "set", "rollup": "D:2160h"}}`, err: "rollup does not apply to field type set"},
 	}
 	for i, test := range tests {
 		actual := &postFieldRequest{}
@@ -57,6 +57,8 @@ type Index struct {
 
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+	durability    string
+	changeLog     *ChangeLog
 }
 
 // NewIndex returns a new instance of Index.
@@ -93,6 +95,21 @@ func (i *Index) Keys() bool { return i.keys }
 // ColumnAttrStore returns the storage for column attributes.
 func (i *Index) ColumnAttrStore() AttrStore { return i.columnAttrs }
 
+// logColumnAttrs logs an event for a set of column attributes, if change data
+// capture is enabled.
+func (i *Index) logColumnAttrs(columnID uint64, attrs map[string]interface{}) error {
+	if i.changeLog == nil {
+		return nil
+	}
+	return errors.Wrap(i.changeLog.append(ChangeEvent{
+		Type:   ChangeEventSetColumnAttrs,
+		Index:  i.name,
+		Shard:  columnID / ShardWidth,
+		Column: columnID,
+		Attrs:  attrs,
+	}), "logging changes")
+}
+
 // Options returns all options for this index.
 func (i *Index) Options() IndexOptions {
 	i.mu.RLock()
@@ -433,6 +450,8 @@ func (i *Index) newField(path, name string) (*Field, error) {
 	f.broadcaster = i.broadcaster
 	f.rowAttrStore = i.newAttrStore(filepath.Join(f.path, ".data"))
 	f.snapshotQueue = i.snapshotQueue
+	f.durability = i.durability
+	f.changeLog = i.changeLog
 	return f, nil
 }
 
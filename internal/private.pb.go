@@ -42,6 +42,7 @@
 		UpdateCoordinatorMessage
 		Topology
 		RecalculateCaches
+		PauseWritesMessage
 */
 package internal
 
@@ -91,12 +92,17 @@ type FieldOptions struct {
 	CacheType      string `protobuf:"bytes,3,opt,name=CacheType,proto3" json:"CacheType,omitempty"`
 	CacheSize      uint32 `protobuf:"varint,4,opt,name=CacheSize,proto3" json:"CacheSize,omitempty"`
 	TimeQuantum    string `protobuf:"bytes,5,opt,name=TimeQuantum,proto3" json:"TimeQuantum,omitempty"`
+	Min            int64  `protobuf:"varint,9,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max            int64  `protobuf:"varint,10,opt,name=Max,proto3" json:"Max,omitempty"`
 	Keys           bool   `protobuf:"varint,11,opt,name=Keys,proto3" json:"Keys,omitempty"`
 	NoStandardView bool   `protobuf:"varint,12,opt,name=NoStandardView,proto3" json:"NoStandardView,omitempty"`
 	Base           int64  `protobuf:"varint,13,opt,name=Base,proto3" json:"Base,omitempty"`
 	BitDepth       uint64 `protobuf:"varint,14,opt,name=BitDepth,proto3" json:"BitDepth,omitempty"`
-	Min            int64  `protobuf:"varint,9,opt,name=Min,proto3" json:"Min,omitempty"`
-	Max            int64  `protobuf:"varint,10,opt,name=Max,proto3" json:"Max,omitempty"`
+	Scale          int64  `protobuf:"varint,15,opt,name=Scale,proto3" json:"Scale,omitempty"`
+	TimeUnit       string `protobuf:"bytes,16,opt,name=TimeUnit,proto3" json:"TimeUnit,omitempty"`
+	ForeignIndex   string `protobuf:"bytes,17,opt,name=ForeignIndex,proto3" json:"ForeignIndex,omitempty"`
+	TTL            int64  `protobuf:"varint,18,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Rollup         string `protobuf:"bytes,19,opt,name=Rollup,proto3" json:"Rollup,omitempty"`
 }
 
 func (m *FieldOptions) Reset()                    { *m = FieldOptions{} }
@@ -132,6 +138,20 @@ func (m *FieldOptions) GetTimeQuantum() string {
 	return ""
 }
 
+func (m *FieldOptions) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *FieldOptions) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
 func (m *FieldOptions) GetKeys() bool {
 	if m != nil {
 		return m.Keys
@@ -160,20 +180,41 @@ func (m *FieldOptions) GetBitDepth() uint64 {
 	return 0
 }
 
-func (m *FieldOptions) GetMin() int64 {
+func (m *FieldOptions) GetScale() int64 {
 	if m != nil {
-		return m.Min
+		return m.Scale
 	}
 	return 0
 }
 
-func (m *FieldOptions) GetMax() int64 {
+func (m *FieldOptions) GetTimeUnit() string {
 	if m != nil {
-		return m.Max
+		return m.TimeUnit
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetForeignIndex() string {
+	if m != nil {
+		return m.ForeignIndex
+	}
+	return ""
+}
+
+func (m *FieldOptions) GetTTL() int64 {
+	if m != nil {
+		return m.TTL
 	}
 	return 0
 }
 
+func (m *FieldOptions) GetRollup() string {
+	if m != nil {
+		return m.Rollup
+	}
+	return ""
+}
+
 type ImportResponse struct {
 	Err string `protobuf:"bytes,1,opt,name=Err,proto3" json:"Err,omitempty"`
 }
@@ -1022,10 +1063,12 @@ type UpdateCoordinatorMessage struct {
 	New *Node `protobuf:"bytes,1,opt,name=New" json:"New,omitempty"`
 }
 
-func (m *UpdateCoordinatorMessage) Reset()                    { *m = UpdateCoordinatorMessage{} }
-func (m *UpdateCoordinatorMessage) String() string            { return proto.CompactTextString(m) }
-func (*UpdateCoordinatorMessage) ProtoMessage()               {}
-func (*UpdateCoordinatorMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{31} }
+func (m *UpdateCoordinatorMessage) Reset()         { *m = UpdateCoordinatorMessage{} }
+func (m *UpdateCoordinatorMessage) String() string { return proto.CompactTextString(m) }
+func (*UpdateCoordinatorMessage) ProtoMessage()    {}
+func (*UpdateCoordinatorMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptorPrivate, []int{31}
+}
 
 func (m *UpdateCoordinatorMessage) GetNew() *Node {
 	if m != nil {
@@ -1066,6 +1109,38 @@ func (m *RecalculateCaches) String() string            { return proto.CompactTex
 func (*RecalculateCaches) ProtoMessage()               {}
 func (*RecalculateCaches) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{33} }
 
+type PauseWritesMessage struct {
+	Pause   bool  `protobuf:"varint,1,opt,name=Pause,proto3" json:"Pause,omitempty"`
+	Timeout int64 `protobuf:"varint,2,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
+	Renew   bool  `protobuf:"varint,3,opt,name=Renew,proto3" json:"Renew,omitempty"`
+}
+
+func (m *PauseWritesMessage) Reset()                    { *m = PauseWritesMessage{} }
+func (m *PauseWritesMessage) String() string            { return proto.CompactTextString(m) }
+func (*PauseWritesMessage) ProtoMessage()               {}
+func (*PauseWritesMessage) Descriptor() ([]byte, []int) { return fileDescriptorPrivate, []int{34} }
+
+func (m *PauseWritesMessage) GetPause() bool {
+	if m != nil {
+		return m.Pause
+	}
+	return false
+}
+
+func (m *PauseWritesMessage) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *PauseWritesMessage) GetRenew() bool {
+	if m != nil {
+		return m.Renew
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*IndexMeta)(nil), "internal.IndexMeta")
 	proto.RegisterType((*FieldOptions)(nil), "internal.FieldOptions")
@@ -1101,6 +1176,7 @@ func init() {
 	proto.RegisterType((*UpdateCoordinatorMessage)(nil), "internal.UpdateCoordinatorMessage")
 	proto.RegisterType((*Topology)(nil), "internal.Topology")
 	proto.RegisterType((*RecalculateCaches)(nil), "internal.RecalculateCaches")
+	proto.RegisterType((*PauseWritesMessage)(nil), "internal.PauseWritesMessage")
 }
 func (m *IndexMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -1218,6 +1294,42 @@ func (m *FieldOptions) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPrivate(dAtA, i, uint64(m.BitDepth))
 	}
+	if m.Scale != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Scale))
+	}
+	if len(m.TimeUnit) > 0 {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.TimeUnit)))
+		i += copy(dAtA[i:], m.TimeUnit)
+	}
+	if len(m.ForeignIndex) > 0 {
+		dAtA[i] = 0x8a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.ForeignIndex)))
+		i += copy(dAtA[i:], m.ForeignIndex)
+	}
+	if m.TTL != 0 {
+		dAtA[i] = 0x90
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.TTL))
+	}
+	if len(m.Rollup) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(len(m.Rollup)))
+		i += copy(dAtA[i:], m.Rollup)
+	}
 	return i, nil
 }
 
@@ -2432,6 +2544,49 @@ func (m *RecalculateCaches) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *PauseWritesMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PauseWritesMessage) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Pause {
+		dAtA[i] = 0x8
+		i++
+		if m.Pause {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Timeout != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPrivate(dAtA, i, uint64(m.Timeout))
+	}
+	if m.Renew {
+		dAtA[i] = 0x18
+		i++
+		if m.Renew {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
 func encodeVarintPrivate(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -2489,6 +2644,24 @@ func (m *FieldOptions) Size() (n int) {
 	if m.BitDepth != 0 {
 		n += 1 + sovPrivate(uint64(m.BitDepth))
 	}
+	if m.Scale != 0 {
+		n += 1 + sovPrivate(uint64(m.Scale))
+	}
+	l = len(m.TimeUnit)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	l = len(m.ForeignIndex)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
+	if m.TTL != 0 {
+		n += 2 + sovPrivate(uint64(m.TTL))
+	}
+	l = len(m.Rollup)
+	if l > 0 {
+		n += 2 + l + sovPrivate(uint64(l))
+	}
 	return n
 }
 
@@ -3020,6 +3193,21 @@ func (m *RecalculateCaches) Size() (n int) {
 	return n
 }
 
+func (m *PauseWritesMessage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Pause {
+		n += 2
+	}
+	if m.Timeout != 0 {
+		n += 1 + sovPrivate(uint64(m.Timeout))
+	}
+	if m.Renew {
+		n += 2
+	}
+	return n
+}
+
 func sovPrivate(x uint64) (n int) {
 	for {
 		n++
@@ -3374,6 +3562,131 @@ func (m *FieldOptions) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scale", wireType)
+			}
+			m.Scale = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Scale |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeUnit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForeignIndex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ForeignIndex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			m.TTL = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TTL |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rollup", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rollup = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPrivate(dAtA[iNdEx:])
@@ -7449,6 +7762,115 @@ func (m *RecalculateCaches) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PauseWritesMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPrivate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PauseWritesMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PauseWritesMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pause", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pause = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			m.Timeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timeout |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Renew", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPrivate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Renew = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPrivate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPrivate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPrivate(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -7557,79 +7979,83 @@ var (
 func init() { proto.RegisterFile("private.proto", fileDescriptorPrivate) }
 
 var fileDescriptorPrivate = []byte{
-	// 1180 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x6e, 0x1b, 0x45,
-	0x18, 0x66, 0x0f, 0x71, 0xec, 0xdf, 0x75, 0xea, 0x4c, 0xdb, 0xb0, 0x2d, 0x28, 0x98, 0x51, 0x45,
-	0x4d, 0x25, 0x42, 0xd5, 0x72, 0xc1, 0xa9, 0x52, 0x71, 0x1c, 0xca, 0x52, 0x12, 0xca, 0x38, 0xc9,
-	0x1d, 0x17, 0x13, 0x7b, 0xd4, 0xac, 0xb2, 0xde, 0x35, 0xbb, 0xb3, 0x49, 0xdc, 0x0b, 0x6e, 0x41,
-	0xe2, 0x05, 0xfa, 0x04, 0x3c, 0x0b, 0x97, 0x3c, 0x02, 0x0a, 0x2f, 0x82, 0xe6, 0x9f, 0xd9, 0x83,
-	0x1d, 0x87, 0x44, 0x81, 0xbb, 0xf9, 0xbf, 0x7f, 0xfe, 0xf3, 0x61, 0x67, 0xa1, 0x35, 0x49, 0x82,
-	0x63, 0x2e, 0xc5, 0xc6, 0x24, 0x89, 0x65, 0x4c, 0xea, 0x41, 0x24, 0x45, 0x12, 0xf1, 0x90, 0x3e,
-	0x87, 0x86, 0x1f, 0x8d, 0xc4, 0xe9, 0xb6, 0x90, 0x9c, 0x10, 0x70, 0x5f, 0x88, 0x69, 0xea, 0x39,
-	0x1d, 0xab, 0x5b, 0x67, 0x78, 0x26, 0x1f, 0xc0, 0xca, 0x6e, 0xc2, 0x87, 0x47, 0x5b, 0xa7, 0x41,
-	0x2a, 0x45, 0x34, 0x14, 0x9e, 0x8b, 0xdc, 0x39, 0x94, 0xbe, 0xb1, 0xe1, 0xc6, 0xd7, 0x81, 0x08,
-	0x47, 0xdf, 0x4f, 0x64, 0x10, 0x47, 0x29, 0x79, 0x17, 0x1a, 0x9b, 0x7c, 0x78, 0x28, 0x76, 0xa7,
-	0x13, 0x81, 0x1a, 0x1b, 0xac, 0x04, 0x0a, 0xee, 0x20, 0x78, 0xad, 0x35, 0xb6, 0x58, 0x09, 0x90,
-	0x0e, 0x34, 0x77, 0x83, 0xb1, 0xf8, 0x21, 0xe3, 0x91, 0xcc, 0xc6, 0xde, 0x12, 0x4a, 0x57, 0x21,
-	0xe5, 0x2a, 0x2a, 0xae, 0x23, 0x0b, 0xcf, 0xe4, 0x36, 0x38, 0xdb, 0x41, 0xe4, 0x35, 0x3a, 0x56,
-	0xd7, 0xe9, 0xd9, 0x9e, 0xc5, 0x14, 0x89, 0x28, 0x3f, 0xf5, 0xa0, 0x82, 0xf2, 0xd3, 0x22, 0xd4,
-	0xe6, 0x6c, 0xa8, 0x3b, 0xf1, 0x40, 0xf2, 0x68, 0xc4, 0x93, 0xd1, 0x7e, 0x20, 0x4e, 0xbc, 0x1b,
-	0x3a, 0xd4, 0x59, 0x54, 0xc9, 0xf6, 0x78, 0x2a, 0xbc, 0x96, 0x52, 0xc9, 0xf0, 0x4c, 0xee, 0x41,
-	0xbd, 0x17, 0xc8, 0xbe, 0x98, 0xc8, 0x43, 0x6f, 0xa5, 0x63, 0x75, 0x5d, 0x56, 0xd0, 0x94, 0xc2,
-	0x8a, 0x3f, 0x9e, 0xc4, 0x89, 0x64, 0x22, 0x9d, 0xc4, 0x51, 0x2a, 0x48, 0x1b, 0x9c, 0xad, 0x24,
-	0xf1, 0x2c, 0x74, 0x5e, 0x1d, 0xe9, 0xcf, 0xd0, 0xee, 0x85, 0xf1, 0xf0, 0xa8, 0xcf, 0x25, 0x67,
-	0xe2, 0xa7, 0x4c, 0xa4, 0x92, 0xdc, 0x86, 0x25, 0xac, 0x8d, 0xb9, 0xa7, 0x09, 0x85, 0x62, 0x9e,
-	0x3d, 0x5b, 0xa3, 0x48, 0x28, 0x14, 0xe5, 0x31, 0xd3, 0x2e, 0xd3, 0x84, 0x42, 0x07, 0x87, 0x3c,
-	0x19, 0x61, 0x86, 0x5d, 0xa6, 0x09, 0xe5, 0x3f, 0x46, 0xa7, 0xd3, 0x8a, 0x67, 0xea, 0xc3, 0x6a,
-	0xc5, 0xbe, 0x71, 0x73, 0x0d, 0x6a, 0x2c, 0x3e, 0xf1, 0xfb, 0xa9, 0x67, 0x75, 0x9c, 0xae, 0xcb,
-	0x0c, 0x85, 0xc5, 0x8b, 0xc3, 0x6c, 0x1c, 0x29, 0x96, 0x8d, 0xac, 0x12, 0xa0, 0x77, 0x61, 0x09,
-	0x2b, 0xa9, 0xa2, 0x2c, 0x65, 0xd5, 0x91, 0xfe, 0x62, 0x41, 0x63, 0x9b, 0x9f, 0xa2, 0x1b, 0x29,
-	0x79, 0x0a, 0xf5, 0x3c, 0xaf, 0x78, 0xa9, 0xf9, 0xf8, 0xfd, 0x8d, 0xbc, 0x31, 0x37, 0x8a, 0x6b,
-	0x1b, 0xf9, 0x9d, 0xad, 0x48, 0x26, 0x53, 0x56, 0x88, 0xdc, 0xfb, 0x02, 0x5a, 0x33, 0x2c, 0x65,
-	0xef, 0x48, 0x4c, 0xf3, 0xac, 0x1e, 0x89, 0xa9, 0x8a, 0xff, 0x98, 0x87, 0x99, 0xc0, 0x5c, 0xb9,
-	0x4c, 0x13, 0x9f, 0xdb, 0x9f, 0x5a, 0x74, 0x1f, 0xc8, 0x66, 0x22, 0xb8, 0x14, 0x68, 0x64, 0x5b,
-	0xa4, 0x29, 0x7f, 0x25, 0x2e, 0xce, 0xb8, 0xce, 0xa2, 0x5d, 0xcd, 0x62, 0x51, 0x07, 0xa7, 0x52,
-	0x07, 0xfa, 0x10, 0x48, 0x5f, 0x84, 0x42, 0x0a, 0x33, 0x55, 0xff, 0xa2, 0x97, 0x0e, 0x72, 0x1f,
-	0x2e, 0xbf, 0x4b, 0x1e, 0x80, 0xab, 0x46, 0x14, 0x5d, 0x68, 0x3e, 0xbe, 0x55, 0xe6, 0xa9, 0x98,
-	0x5e, 0x86, 0x17, 0x68, 0x98, 0x2b, 0x45, 0x7f, 0x2e, 0x0d, 0x6c, 0x41, 0x2b, 0x3d, 0x34, 0xa6,
-	0x1c, 0x34, 0xb5, 0x56, 0x9a, 0xaa, 0x8e, 0xb7, 0xb1, 0xf6, 0x2c, 0x0f, 0xf7, 0xba, 0xd6, 0xe8,
-	0x10, 0xde, 0xd1, 0x1a, 0xbe, 0x3a, 0xe6, 0x41, 0xc8, 0x0f, 0xc2, 0x2b, 0x56, 0x64, 0x81, 0xe3,
-	0x1e, 0x2c, 0xa3, 0xac, 0xdf, 0x37, 0x53, 0x90, 0x93, 0xf4, 0x47, 0x73, 0x5f, 0xb5, 0xfe, 0x0e,
-	0x1f, 0x0b, 0xa3, 0x0d, 0xcf, 0x45, 0xbc, 0xf6, 0xe5, 0xf1, 0x2a, 0xc3, 0x6a, 0x5c, 0xd4, 0x8a,
-	0x74, 0x94, 0x61, 0x24, 0xe8, 0x13, 0xa8, 0x0d, 0x86, 0x87, 0x62, 0xcc, 0xc9, 0x87, 0xb0, 0x8c,
-	0x1e, 0x8a, 0xd4, 0x74, 0xf4, 0xcd, 0xb9, 0x4a, 0xb1, 0x9c, 0x4f, 0xfb, 0x26, 0xb2, 0x85, 0x3e,
-	0x3d, 0x80, 0x1a, 0x5a, 0x4f, 0x3d, 0x77, 0x5e, 0x0d, 0xe2, 0xcc, 0xb0, 0xe9, 0x16, 0x38, 0x7b,
-	0xcc, 0x57, 0x93, 0x8a, 0x1e, 0xe4, 0x5a, 0x0c, 0xa5, 0x74, 0x7f, 0x13, 0xa7, 0xd2, 0xe4, 0x09,
-	0xcf, 0x0a, 0x7b, 0x19, 0x27, 0x12, 0x73, 0xd4, 0x62, 0x78, 0xa6, 0x29, 0xb8, 0x3b, 0xf1, 0x48,
-	0x90, 0x15, 0xb0, 0xfd, 0xbe, 0xd1, 0x61, 0xfb, 0x7d, 0xf2, 0x1e, 0xaa, 0x37, 0xa9, 0x69, 0x95,
-	0x4e, 0xec, 0x31, 0x9f, 0xa1, 0xe1, 0xfb, 0xd0, 0xf2, 0xd3, 0xcd, 0x38, 0x4e, 0x46, 0x41, 0xc4,
-	0x65, 0x9c, 0x98, 0x6f, 0xc7, 0x2c, 0x88, 0x13, 0x24, 0xb9, 0xd4, 0x9b, 0xbe, 0xc1, 0x34, 0x41,
-	0x9f, 0x41, 0x5b, 0x19, 0x45, 0x22, 0xaf, 0xf7, 0x1a, 0xd4, 0x14, 0x56, 0x38, 0x61, 0xa8, 0x52,
-	0x83, 0x5d, 0xd5, 0xf0, 0x9d, 0xd6, 0xb0, 0x75, 0x2c, 0x22, 0x59, 0xe9, 0x18, 0xa4, 0x51, 0x41,
-	0x8b, 0x69, 0x82, 0x50, 0x1d, 0xa0, 0x89, 0x64, 0xa5, 0x8c, 0x44, 0xa1, 0x0c, 0x79, 0xf4, 0x37,
-	0x0b, 0x20, 0x77, 0x28, 0x4b, 0x0b, 0x11, 0xeb, 0x62, 0x11, 0xd2, 0xcd, 0x2b, 0x6f, 0xa6, 0xa5,
-	0x5d, 0xde, 0xd2, 0x38, 0xcb, 0x3b, 0xe3, 0xe3, 0xb2, 0x33, 0x74, 0x49, 0xef, 0xcc, 0x75, 0x86,
-	0xb6, 0x5a, 0xf6, 0xc7, 0x4b, 0x68, 0x56, 0xf0, 0x85, 0x5d, 0xf2, 0x51, 0xd1, 0x25, 0xf6, 0xbc,
-	0x4a, 0xc4, 0x8d, 0xca, 0xbc, 0x57, 0x5e, 0x40, 0xb3, 0x02, 0x2f, 0xd4, 0xd8, 0x85, 0x9b, 0xb3,
-	0x73, 0x98, 0xef, 0xf7, 0x79, 0x98, 0x06, 0xd0, 0xda, 0x0c, 0xb3, 0x54, 0x8a, 0xc4, 0xa8, 0x53,
-	0x1f, 0x05, 0x0d, 0x14, 0xc5, 0x2b, 0x81, 0xc5, 0xf5, 0x23, 0xf7, 0x61, 0x49, 0xa5, 0x51, 0x8f,
-	0xd3, 0xf9, 0x1c, 0x6b, 0x26, 0xdd, 0x87, 0x7a, 0x6f, 0xe0, 0x3f, 0x4f, 0xe2, 0x6c, 0xb2, 0xd0,
-	0xe9, 0xfc, 0x2d, 0x60, 0x57, 0xde, 0x02, 0x6d, 0xfd, 0x16, 0x70, 0xf0, 0x13, 0x8d, 0xef, 0x80,
-	0xb6, 0x7e, 0x07, 0xb8, 0x06, 0xe1, 0x6a, 0xff, 0xae, 0xea, 0x55, 0xa9, 0xa6, 0xf8, 0x3a, 0x0b,
-	0x27, 0xff, 0x90, 0x3a, 0x95, 0x0f, 0xe9, 0x00, 0x56, 0xf5, 0x3e, 0xfb, 0x3f, 0x95, 0xfe, 0x6e,
-	0xc3, 0x2a, 0x13, 0x69, 0xf0, 0x5a, 0xf8, 0x51, 0x2a, 0x93, 0x6c, 0xa8, 0x76, 0x92, 0x92, 0xff,
-	0x36, 0x3e, 0x30, 0xd9, 0x76, 0x98, 0x26, 0xae, 0xd2, 0xe9, 0xe4, 0x11, 0x34, 0xe7, 0x67, 0xf6,
-	0xfc, 0xd5, 0xea, 0x15, 0xf2, 0x08, 0x96, 0x07, 0x71, 0x96, 0x0c, 0x8b, 0xf6, 0xad, 0xec, 0x49,
-	0xed, 0x99, 0x66, 0xb3, 0xfc, 0x1a, 0x79, 0x3a, 0xd7, 0x20, 0x5e, 0x0d, 0xad, 0xbc, 0x5d, 0xca,
-	0xcd, 0xb0, 0xd9, 0x5c, 0x3b, 0x7d, 0x52, 0x9d, 0x45, 0x6f, 0x19, 0x65, 0x6f, 0xcf, 0x7a, 0x68,
-	0x04, 0x2b, 0xf7, 0xe8, 0xaf, 0x16, 0xdc, 0xa8, 0xba, 0x73, 0xa5, 0x21, 0x2e, 0xaa, 0x63, 0x2f,
-	0xac, 0x8e, 0xb3, 0xa8, 0x3a, 0x6e, 0x59, 0x9d, 0xf2, 0x7d, 0xb0, 0x54, 0x79, 0x1f, 0xd0, 0x23,
-	0xb8, 0x7b, 0xae, 0x64, 0x9b, 0xf1, 0x78, 0xa2, 0x7a, 0xe3, 0x3f, 0x94, 0x4e, 0xad, 0xb7, 0x24,
-	0x31, 0x45, 0x6b, 0x30, 0x4d, 0xd0, 0xcf, 0xe0, 0xce, 0x40, 0xc8, 0x4a, 0xc1, 0xf2, 0xce, 0xeb,
-	0x80, 0xb3, 0x23, 0x4e, 0x2e, 0x08, 0x5f, 0xb1, 0xe8, 0x97, 0xe0, 0xed, 0x4d, 0x46, 0x5c, 0x8a,
-	0x6b, 0x49, 0xf7, 0xa0, 0xbe, 0x1b, 0x4f, 0xe2, 0x30, 0x7e, 0x35, 0xbd, 0x64, 0x03, 0x78, 0xb0,
-	0xac, 0x77, 0xb9, 0x5e, 0x29, 0x0d, 0x96, 0x93, 0xf4, 0x96, 0x6a, 0xee, 0x21, 0x0f, 0x87, 0x59,
-	0xa8, 0xdc, 0x50, 0x6f, 0xc7, 0xb4, 0xd7, 0xfe, 0xe3, 0x6c, 0xdd, 0xfa, 0xf3, 0x6c, 0xdd, 0xfa,
-	0xeb, 0x6c, 0xdd, 0x7a, 0xf3, 0xf7, 0xfa, 0x5b, 0x07, 0x35, 0xfc, 0x77, 0x79, 0xf2, 0x4f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x4f, 0xa0, 0xa9, 0x8b, 0xcc, 0x0c, 0x00, 0x00,
+	// 1244 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcb, 0x72, 0x1b, 0x45,
+	0x17, 0xfe, 0xe7, 0x62, 0x5b, 0x3a, 0xb2, 0x1c, 0xb9, 0x93, 0xf8, 0x9f, 0x04, 0xca, 0x88, 0xae,
+	0x14, 0x11, 0xa9, 0xc2, 0xa4, 0x12, 0x16, 0xdc, 0x52, 0x15, 0x64, 0x39, 0x61, 0x48, 0x6c, 0x42,
+	0x4b, 0x0e, 0x2b, 0x16, 0x1d, 0xa9, 0x2b, 0x9e, 0xf2, 0x68, 0x66, 0x98, 0xe9, 0x71, 0xac, 0x2c,
+	0xd8, 0x42, 0x15, 0x1b, 0x96, 0x3c, 0x01, 0xcf, 0xc2, 0x92, 0x47, 0xa0, 0xc2, 0x8b, 0x50, 0x7d,
+	0xba, 0xe7, 0x22, 0x59, 0xc1, 0x29, 0xc3, 0xae, 0xcf, 0x77, 0xee, 0x97, 0x3e, 0xd3, 0x03, 0xed,
+	0x24, 0x0d, 0x4e, 0xb8, 0x14, 0x3b, 0x49, 0x1a, 0xcb, 0x98, 0x34, 0x82, 0x48, 0x8a, 0x34, 0xe2,
+	0x21, 0x7d, 0x08, 0x4d, 0x3f, 0x9a, 0x88, 0xd3, 0x7d, 0x21, 0x39, 0x21, 0xe0, 0x3e, 0x12, 0xb3,
+	0xcc, 0x73, 0xba, 0x56, 0xaf, 0xc1, 0xf0, 0x4c, 0xde, 0x83, 0x8d, 0x51, 0xca, 0xc7, 0xc7, 0x7b,
+	0xa7, 0x41, 0x26, 0x45, 0x34, 0x16, 0x9e, 0x8b, 0xdc, 0x05, 0x94, 0xfe, 0xe2, 0xc0, 0xfa, 0x83,
+	0x40, 0x84, 0x93, 0xaf, 0x13, 0x19, 0xc4, 0x51, 0x46, 0xde, 0x86, 0xe6, 0x2e, 0x1f, 0x1f, 0x89,
+	0xd1, 0x2c, 0x11, 0x68, 0xb1, 0xc9, 0x2a, 0xa0, 0xe4, 0x0e, 0x83, 0x97, 0xda, 0x62, 0x9b, 0x55,
+	0x00, 0xe9, 0x42, 0x6b, 0x14, 0x4c, 0xc5, 0x37, 0x39, 0x8f, 0x64, 0x3e, 0xf5, 0x56, 0x50, 0xbb,
+	0x0e, 0xa9, 0x50, 0xd1, 0x70, 0x03, 0x59, 0x78, 0x26, 0x1d, 0x70, 0xf6, 0x83, 0xc8, 0x6b, 0x76,
+	0xad, 0x9e, 0xc3, 0xd4, 0x11, 0x11, 0x7e, 0xea, 0x81, 0x41, 0xf8, 0x69, 0x99, 0x62, 0x6b, 0x3e,
+	0xc5, 0x83, 0x78, 0x28, 0x79, 0x34, 0xe1, 0xe9, 0xe4, 0x69, 0x20, 0x5e, 0x78, 0xeb, 0x3a, 0xc5,
+	0x79, 0x54, 0xe9, 0xf6, 0x79, 0x26, 0xbc, 0x36, 0x9a, 0xc3, 0x33, 0xb9, 0x0e, 0x8d, 0x7e, 0x20,
+	0x07, 0x22, 0x91, 0x47, 0xde, 0x46, 0xd7, 0xea, 0xb9, 0xac, 0xa4, 0xc9, 0x15, 0x58, 0x19, 0x8e,
+	0x79, 0x28, 0xbc, 0x4b, 0xa8, 0xa0, 0x09, 0xa5, 0xa1, 0x12, 0x39, 0x8c, 0x02, 0xe9, 0x75, 0x30,
+	0xfa, 0x92, 0x26, 0x14, 0xd6, 0x1f, 0xc4, 0xa9, 0x08, 0x9e, 0x47, 0xd8, 0x14, 0x6f, 0x13, 0xf9,
+	0x73, 0x98, 0xca, 0x69, 0x34, 0x7a, 0xec, 0x11, 0x9d, 0xd3, 0x68, 0xf4, 0x98, 0x6c, 0xc1, 0x2a,
+	0x8b, 0xc3, 0x30, 0x4f, 0xbc, 0xcb, 0x28, 0x6f, 0x28, 0x4a, 0x61, 0xc3, 0x9f, 0x26, 0x71, 0x2a,
+	0x99, 0xc8, 0x92, 0x38, 0xca, 0xb0, 0x42, 0x7b, 0x69, 0xea, 0x59, 0x28, 0xa6, 0x8e, 0xf4, 0x07,
+	0xe8, 0xf4, 0xc3, 0x78, 0x7c, 0x3c, 0xe0, 0x92, 0x33, 0xf1, 0x7d, 0x2e, 0x32, 0xa9, 0xe2, 0xd6,
+	0xee, 0xb5, 0x9c, 0x26, 0x14, 0x8a, 0xfd, 0xf5, 0x6c, 0x8d, 0x22, 0xa1, 0x50, 0xd4, 0xc7, 0x0e,
+	0xbb, 0x4c, 0x13, 0x98, 0xf9, 0x11, 0x4f, 0x27, 0xd8, 0x59, 0x97, 0x69, 0x42, 0xd5, 0x0f, 0xab,
+	0xab, 0xdb, 0x89, 0x67, 0xea, 0xc3, 0x66, 0xcd, 0xbf, 0x09, 0x13, 0x13, 0x7a, 0xe1, 0x0f, 0x32,
+	0xcf, 0xea, 0x3a, 0x3d, 0x97, 0x19, 0x0a, 0x87, 0x26, 0x0e, 0xf3, 0x69, 0xa4, 0x58, 0x36, 0xb2,
+	0x2a, 0x80, 0x5e, 0x83, 0x15, 0x9c, 0x20, 0x95, 0x65, 0xa5, 0xab, 0x8e, 0xf4, 0x47, 0x0b, 0x9a,
+	0xfb, 0xfc, 0x14, 0xc3, 0xc8, 0xc8, 0x3d, 0x68, 0x14, 0x7d, 0x45, 0xa1, 0xd6, 0x9d, 0x77, 0x77,
+	0x8a, 0x0b, 0xb1, 0x53, 0x8a, 0xed, 0x14, 0x32, 0x7b, 0x91, 0x4c, 0x67, 0xac, 0x54, 0xb9, 0xfe,
+	0x19, 0xb4, 0xe7, 0x58, 0xca, 0xdf, 0xb1, 0x98, 0x15, 0x55, 0x3d, 0x16, 0x33, 0x95, 0xff, 0x09,
+	0x0f, 0x73, 0x81, 0xb5, 0x72, 0x99, 0x26, 0x3e, 0xb5, 0x3f, 0xb6, 0xe8, 0x53, 0x20, 0xbb, 0xa9,
+	0xe0, 0x52, 0xa0, 0x93, 0x7d, 0x91, 0x65, 0xfc, 0xb9, 0x78, 0x7d, 0xc5, 0x75, 0x15, 0xed, 0x7a,
+	0x15, 0xcb, 0x3e, 0x38, 0xb5, 0x3e, 0xd0, 0x5b, 0x40, 0x06, 0x22, 0x14, 0x52, 0x98, 0xdb, 0xfc,
+	0x0f, 0x76, 0xe9, 0xb0, 0x88, 0xe1, 0x7c, 0x59, 0x72, 0x13, 0x5c, 0xb5, 0x1a, 0x30, 0x84, 0xd6,
+	0x9d, 0xcb, 0x55, 0x9d, 0xca, 0xad, 0xc1, 0x50, 0x80, 0x86, 0x85, 0x51, 0x8c, 0xe7, 0xdc, 0xc4,
+	0x96, 0x8c, 0xd2, 0x2d, 0xe3, 0xca, 0x41, 0x57, 0x5b, 0x95, 0xab, 0xfa, 0x5a, 0x31, 0xde, 0xee,
+	0x17, 0xe9, 0x5e, 0xd4, 0x1b, 0x1d, 0xc3, 0x5b, 0xda, 0xc2, 0x17, 0x27, 0x3c, 0x08, 0xf9, 0xb3,
+	0xf0, 0x0d, 0x3b, 0xb2, 0x24, 0x70, 0x0f, 0xd6, 0x50, 0xd7, 0x1f, 0x98, 0x5b, 0x50, 0x90, 0xf4,
+	0x3b, 0x23, 0xaf, 0x46, 0xff, 0x80, 0x4f, 0x85, 0xb1, 0x86, 0xe7, 0x32, 0x5f, 0xfb, 0xfc, 0x7c,
+	0x95, 0x63, 0x75, 0x5d, 0xd4, 0x6a, 0x76, 0x94, 0x63, 0x24, 0xe8, 0x5d, 0x58, 0x1d, 0x8e, 0x8f,
+	0xc4, 0x94, 0x93, 0xf7, 0x61, 0x0d, 0x23, 0x14, 0x99, 0x99, 0xe8, 0x4b, 0x0b, 0x9d, 0x62, 0x05,
+	0x9f, 0x0e, 0x4c, 0x66, 0x4b, 0x63, 0xba, 0x09, 0xab, 0xe8, 0x3d, 0xf3, 0xdc, 0x45, 0x33, 0x88,
+	0x33, 0xc3, 0xa6, 0x7b, 0xe0, 0x1c, 0x32, 0x9f, 0x6c, 0x99, 0x08, 0x0a, 0x2b, 0x86, 0x52, 0xb6,
+	0xbf, 0x8c, 0x33, 0x69, 0xea, 0x84, 0x67, 0x85, 0x3d, 0x89, 0x53, 0x89, 0x35, 0x6a, 0x33, 0x3c,
+	0xd3, 0x0c, 0xdc, 0x83, 0x78, 0x22, 0xc8, 0x06, 0xd8, 0xfe, 0xc0, 0xd8, 0xb0, 0xfd, 0x01, 0x79,
+	0x07, 0xcd, 0x9b, 0xd2, 0xb4, 0xab, 0x20, 0x0e, 0x99, 0xcf, 0xd0, 0xf1, 0x0d, 0x68, 0xfb, 0xd9,
+	0x6e, 0x1c, 0xa7, 0x93, 0x20, 0xe2, 0x32, 0x4e, 0xcd, 0x37, 0x6b, 0x1e, 0xc4, 0x1b, 0x24, 0xb9,
+	0xd4, 0x5f, 0x98, 0x26, 0xd3, 0x04, 0xbd, 0x0f, 0x1d, 0xe5, 0x14, 0x89, 0xa2, 0xdf, 0x5b, 0xb0,
+	0xaa, 0xb0, 0x32, 0x08, 0x43, 0x55, 0x16, 0xec, 0xba, 0x85, 0xc7, 0xda, 0xc2, 0xde, 0x89, 0x88,
+	0x64, 0x6d, 0x62, 0x90, 0x46, 0x03, 0x6d, 0xa6, 0x09, 0x42, 0x75, 0x82, 0x26, 0x93, 0x8d, 0x2a,
+	0x13, 0x85, 0x32, 0xe4, 0xd1, 0x9f, 0x2d, 0x80, 0x22, 0xa0, 0x3c, 0x2b, 0x55, 0xac, 0xd7, 0xab,
+	0x90, 0x5e, 0xd1, 0x79, 0x73, 0x5b, 0x3a, 0x95, 0x94, 0xc6, 0x59, 0x31, 0x19, 0x1f, 0x56, 0x93,
+	0xa1, 0x5b, 0x7a, 0x75, 0x61, 0x32, 0xb4, 0xd7, 0x6a, 0x3e, 0x9e, 0x40, 0xab, 0x86, 0x2f, 0x9d,
+	0x92, 0x0f, 0xca, 0x29, 0xb1, 0x17, 0x4d, 0x22, 0x6e, 0x4c, 0x16, 0xb3, 0xf2, 0x08, 0x5a, 0x35,
+	0x78, 0xa9, 0xc5, 0x1e, 0x5c, 0x9a, 0xbf, 0x87, 0xc5, 0x7e, 0x5f, 0x84, 0x69, 0x00, 0xed, 0xdd,
+	0x30, 0xcf, 0xa4, 0x48, 0x8d, 0x39, 0xf5, 0x51, 0xd0, 0x40, 0xd9, 0xbc, 0x0a, 0x58, 0xde, 0x3f,
+	0x72, 0x03, 0x56, 0x54, 0x19, 0xf5, 0x75, 0x3a, 0x5b, 0x63, 0xcd, 0xa4, 0x4f, 0xa1, 0xd1, 0x1f,
+	0xfa, 0x0f, 0xd3, 0x38, 0x4f, 0x96, 0x06, 0x5d, 0xbc, 0x41, 0xec, 0xb3, 0x6f, 0x10, 0xe7, 0xcc,
+	0x1b, 0xc4, 0x2d, 0xdf, 0x20, 0x74, 0x08, 0x9b, 0x7a, 0x55, 0xaa, 0x5b, 0x7c, 0x91, 0x85, 0x53,
+	0x7c, 0x48, 0x9d, 0xda, 0x87, 0x74, 0x08, 0x9b, 0x7a, 0x9f, 0xfd, 0x97, 0x46, 0x7f, 0xb3, 0x61,
+	0x93, 0x89, 0x2c, 0x78, 0x29, 0xfc, 0x28, 0x93, 0x69, 0x3e, 0x56, 0x3b, 0x49, 0xe9, 0x7f, 0x15,
+	0x3f, 0x33, 0xd5, 0x76, 0x98, 0x26, 0xde, 0x64, 0xd2, 0xc9, 0x6d, 0x68, 0x2d, 0xde, 0xd9, 0xb3,
+	0xa2, 0x75, 0x11, 0x72, 0x1b, 0xd6, 0x86, 0x71, 0x9e, 0x8e, 0xcb, 0xf1, 0xad, 0xed, 0x49, 0x1d,
+	0x99, 0x66, 0xb3, 0x42, 0x8c, 0xdc, 0x5b, 0x18, 0x10, 0x6f, 0x15, 0xbd, 0xfc, 0xbf, 0xd2, 0x9b,
+	0x63, 0xb3, 0x85, 0x71, 0xfa, 0xa8, 0x7e, 0x17, 0xbd, 0x35, 0xd4, 0xbd, 0x32, 0x1f, 0xa1, 0x51,
+	0xac, 0xc9, 0xd1, 0x9f, 0x2c, 0x58, 0xaf, 0x87, 0xf3, 0x46, 0x97, 0xb8, 0xec, 0x8e, 0xbd, 0xb4,
+	0x3b, 0xce, 0xb2, 0xee, 0xb8, 0x55, 0x77, 0xaa, 0xf7, 0xc1, 0x4a, 0xed, 0x7d, 0x40, 0x8f, 0xe1,
+	0xda, 0x99, 0x96, 0xed, 0xc6, 0xd3, 0x44, 0xcd, 0xc6, 0xbf, 0x68, 0x9d, 0x5a, 0x6f, 0x69, 0x6a,
+	0x9a, 0xd6, 0x64, 0x9a, 0xa0, 0x9f, 0xc0, 0xd5, 0xa1, 0x90, 0xb5, 0x86, 0x15, 0x93, 0xd7, 0x05,
+	0xe7, 0x40, 0xbc, 0x78, 0x4d, 0xfa, 0x8a, 0x45, 0x3f, 0x07, 0xef, 0x30, 0x99, 0x70, 0x29, 0x2e,
+	0xa4, 0xdd, 0x87, 0xc6, 0x28, 0x4e, 0xe2, 0x30, 0x7e, 0x3e, 0x3b, 0x67, 0x03, 0x78, 0xb0, 0xa6,
+	0x77, 0xb9, 0x5e, 0x29, 0x4d, 0x56, 0x90, 0xf4, 0xb2, 0x1a, 0xee, 0x31, 0x0f, 0xc7, 0x79, 0xa8,
+	0xc2, 0x50, 0x6f, 0xc7, 0x4c, 0x3d, 0xa4, 0x9e, 0xf0, 0x3c, 0x13, 0xdf, 0xa6, 0x81, 0x14, 0x59,
+	0xed, 0x22, 0x21, 0x8a, 0xe6, 0x1b, 0x4c, 0x13, 0xfd, 0xce, 0xef, 0xaf, 0xb6, 0xad, 0x3f, 0x5e,
+	0x6d, 0x5b, 0x7f, 0xbe, 0xda, 0xb6, 0x7e, 0xfd, 0x6b, 0xfb, 0x7f, 0xcf, 0x56, 0xf1, 0xff, 0xea,
+	0xee, 0xdf, 0x03, 0x00, 0xe6, 0xe9, 0xaa, 0xb3, 0x70, 0x0d, 0x00, 0x00,
 }
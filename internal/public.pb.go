@@ -9,11 +9,13 @@
 
 	It has these top-level messages:
 		Row
+		RowCursor
 		RowIdentifiers
 		Pair
 		FieldRow
 		GroupCount
 		ValCount
+		ColumnValue
 		ColumnAttrSet
 		Attr
 		AttrMap
@@ -26,6 +28,9 @@
 		TranslateKeysResponse
 		ImportRoaringRequestView
 		ImportRoaringRequest
+		SeriesCount
+		RetentionCohort
+		RowSimilarity
 */
 package internal
 
@@ -49,9 +54,10 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Row struct {
-	Columns []uint64 `protobuf:"varint,1,rep,packed,name=Columns" json:"Columns,omitempty"`
-	Keys    []string `protobuf:"bytes,3,rep,name=Keys" json:"Keys,omitempty"`
-	Attrs   []*Attr  `protobuf:"bytes,2,rep,name=Attrs" json:"Attrs,omitempty"`
+	Columns []uint64   `protobuf:"varint,1,rep,packed,name=Columns" json:"Columns,omitempty"`
+	Keys    []string   `protobuf:"bytes,3,rep,name=Keys" json:"Keys,omitempty"`
+	Attrs   []*Attr    `protobuf:"bytes,2,rep,name=Attrs" json:"Attrs,omitempty"`
+	Next    *RowCursor `protobuf:"bytes,4,opt,name=Next" json:"Next,omitempty"`
 }
 
 func (m *Row) Reset()                    { *m = Row{} }
@@ -80,6 +86,37 @@ func (m *Row) GetAttrs() []*Attr {
 	return nil
 }
 
+func (m *Row) GetNext() *RowCursor {
+	if m != nil {
+		return m.Next
+	}
+	return nil
+}
+
+type RowCursor struct {
+	Column uint64 `protobuf:"varint,1,opt,name=Column,proto3" json:"Column,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+}
+
+func (m *RowCursor) Reset()                    { *m = RowCursor{} }
+func (m *RowCursor) String() string            { return proto.CompactTextString(m) }
+func (*RowCursor) ProtoMessage()               {}
+func (*RowCursor) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{1} }
+
+func (m *RowCursor) GetColumn() uint64 {
+	if m != nil {
+		return m.Column
+	}
+	return 0
+}
+
+func (m *RowCursor) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
 type RowIdentifiers struct {
 	Rows []uint64 `protobuf:"varint,1,rep,packed,name=Rows" json:"Rows,omitempty"`
 	Keys []string `protobuf:"bytes,2,rep,name=Keys" json:"Keys,omitempty"`
@@ -88,7 +125,7 @@ type RowIdentifiers struct {
 func (m *RowIdentifiers) Reset()                    { *m = RowIdentifiers{} }
 func (m *RowIdentifiers) String() string            { return proto.CompactTextString(m) }
 func (*RowIdentifiers) ProtoMessage()               {}
-func (*RowIdentifiers) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{1} }
+func (*RowIdentifiers) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{2} }
 
 func (m *RowIdentifiers) GetRows() []uint64 {
 	if m != nil {
@@ -113,7 +150,7 @@ type Pair struct {
 func (m *Pair) Reset()                    { *m = Pair{} }
 func (m *Pair) String() string            { return proto.CompactTextString(m) }
 func (*Pair) ProtoMessage()               {}
-func (*Pair) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{2} }
+func (*Pair) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{3} }
 
 func (m *Pair) GetID() uint64 {
 	if m != nil {
@@ -145,7 +182,7 @@ type FieldRow struct {
 func (m *FieldRow) Reset()                    { *m = FieldRow{} }
 func (m *FieldRow) String() string            { return proto.CompactTextString(m) }
 func (*FieldRow) ProtoMessage()               {}
-func (*FieldRow) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{3} }
+func (*FieldRow) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{4} }
 
 func (m *FieldRow) GetField() string {
 	if m != nil {
@@ -169,14 +206,16 @@ func (m *FieldRow) GetRowKey() string {
 }
 
 type GroupCount struct {
-	Group []*FieldRow `protobuf:"bytes,1,rep,name=Group" json:"Group,omitempty"`
-	Count uint64      `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Group  []*FieldRow `protobuf:"bytes,1,rep,name=Group" json:"Group,omitempty"`
+	Count  uint64      `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Sum    int64       `protobuf:"varint,3,opt,name=Sum,proto3" json:"Sum,omitempty"`
+	HasSum bool        `protobuf:"varint,4,opt,name=HasSum,proto3" json:"HasSum,omitempty"`
 }
 
 func (m *GroupCount) Reset()                    { *m = GroupCount{} }
 func (m *GroupCount) String() string            { return proto.CompactTextString(m) }
 func (*GroupCount) ProtoMessage()               {}
-func (*GroupCount) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{4} }
+func (*GroupCount) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{5} }
 
 func (m *GroupCount) GetGroup() []*FieldRow {
 	if m != nil {
@@ -192,15 +231,31 @@ func (m *GroupCount) GetCount() uint64 {
 	return 0
 }
 
+func (m *GroupCount) GetSum() int64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *GroupCount) GetHasSum() bool {
+	if m != nil {
+		return m.HasSum
+	}
+	return false
+}
+
 type ValCount struct {
-	Val   int64 `protobuf:"varint,1,opt,name=Val,proto3" json:"Val,omitempty"`
-	Count int64 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Val      int64  `protobuf:"varint,1,opt,name=Val,proto3" json:"Val,omitempty"`
+	Count    int64  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	Scale    int64  `protobuf:"varint,3,opt,name=Scale,proto3" json:"Scale,omitempty"`
+	TimeUnit string `protobuf:"bytes,4,opt,name=TimeUnit,proto3" json:"TimeUnit,omitempty"`
 }
 
 func (m *ValCount) Reset()                    { *m = ValCount{} }
 func (m *ValCount) String() string            { return proto.CompactTextString(m) }
 func (*ValCount) ProtoMessage()               {}
-func (*ValCount) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{5} }
+func (*ValCount) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{6} }
 
 func (m *ValCount) GetVal() int64 {
 	if m != nil {
@@ -216,6 +271,52 @@ func (m *ValCount) GetCount() int64 {
 	return 0
 }
 
+func (m *ValCount) GetScale() int64 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *ValCount) GetTimeUnit() string {
+	if m != nil {
+		return m.TimeUnit
+	}
+	return ""
+}
+
+type ColumnValue struct {
+	ID    uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Key   string `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value int64  `protobuf:"varint,3,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (m *ColumnValue) Reset()                    { *m = ColumnValue{} }
+func (m *ColumnValue) String() string            { return proto.CompactTextString(m) }
+func (*ColumnValue) ProtoMessage()               {}
+func (*ColumnValue) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{7} }
+
+func (m *ColumnValue) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *ColumnValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ColumnValue) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
 type ColumnAttrSet struct {
 	ID    uint64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 	Key   string  `protobuf:"bytes,3,opt,name=Key,proto3" json:"Key,omitempty"`
@@ -225,7 +326,7 @@ type ColumnAttrSet struct {
 func (m *ColumnAttrSet) Reset()                    { *m = ColumnAttrSet{} }
 func (m *ColumnAttrSet) String() string            { return proto.CompactTextString(m) }
 func (*ColumnAttrSet) ProtoMessage()               {}
-func (*ColumnAttrSet) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{6} }
+func (*ColumnAttrSet) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{8} }
 
 func (m *ColumnAttrSet) GetID() uint64 {
 	if m != nil {
@@ -260,7 +361,7 @@ type Attr struct {
 func (m *Attr) Reset()                    { *m = Attr{} }
 func (m *Attr) String() string            { return proto.CompactTextString(m) }
 func (*Attr) ProtoMessage()               {}
-func (*Attr) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{7} }
+func (*Attr) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{9} }
 
 func (m *Attr) GetKey() string {
 	if m != nil {
@@ -311,7 +412,7 @@ type AttrMap struct {
 func (m *AttrMap) Reset()                    { *m = AttrMap{} }
 func (m *AttrMap) String() string            { return proto.CompactTextString(m) }
 func (*AttrMap) ProtoMessage()               {}
-func (*AttrMap) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{8} }
+func (*AttrMap) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{10} }
 
 func (m *AttrMap) GetAttrs() []*Attr {
 	if m != nil {
@@ -332,7 +433,7 @@ type QueryRequest struct {
 func (m *QueryRequest) Reset()                    { *m = QueryRequest{} }
 func (m *QueryRequest) String() string            { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()               {}
-func (*QueryRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{9} }
+func (*QueryRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{11} }
 
 func (m *QueryRequest) GetQuery() string {
 	if m != nil {
@@ -385,7 +486,7 @@ type QueryResponse struct {
 func (m *QueryResponse) Reset()                    { *m = QueryResponse{} }
 func (m *QueryResponse) String() string            { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()               {}
-func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{10} }
+func (*QueryResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{12} }
 
 func (m *QueryResponse) GetErr() string {
 	if m != nil {
@@ -409,21 +510,28 @@ func (m *QueryResponse) GetColumnAttrSets() []*ColumnAttrSet {
 }
 
 type QueryResult struct {
-	Type           uint32          `protobuf:"varint,6,opt,name=Type,proto3" json:"Type,omitempty"`
-	Row            *Row            `protobuf:"bytes,1,opt,name=Row" json:"Row,omitempty"`
-	N              uint64          `protobuf:"varint,2,opt,name=N,proto3" json:"N,omitempty"`
-	Pairs          []*Pair         `protobuf:"bytes,3,rep,name=Pairs" json:"Pairs,omitempty"`
-	Changed        bool            `protobuf:"varint,4,opt,name=Changed,proto3" json:"Changed,omitempty"`
-	ValCount       *ValCount       `protobuf:"bytes,5,opt,name=ValCount" json:"ValCount,omitempty"`
-	RowIDs         []uint64        `protobuf:"varint,7,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
-	GroupCounts    []*GroupCount   `protobuf:"bytes,8,rep,name=GroupCounts" json:"GroupCounts,omitempty"`
-	RowIdentifiers *RowIdentifiers `protobuf:"bytes,9,opt,name=RowIdentifiers" json:"RowIdentifiers,omitempty"`
+	Type             uint32             `protobuf:"varint,6,opt,name=Type,proto3" json:"Type,omitempty"`
+	Row              *Row               `protobuf:"bytes,1,opt,name=Row" json:"Row,omitempty"`
+	N                uint64             `protobuf:"varint,2,opt,name=N,proto3" json:"N,omitempty"`
+	Pairs            []*Pair            `protobuf:"bytes,3,rep,name=Pairs" json:"Pairs,omitempty"`
+	Changed          bool               `protobuf:"varint,4,opt,name=Changed,proto3" json:"Changed,omitempty"`
+	ValCount         *ValCount          `protobuf:"bytes,5,opt,name=ValCount" json:"ValCount,omitempty"`
+	RowIDs           []uint64           `protobuf:"varint,7,rep,packed,name=RowIDs" json:"RowIDs,omitempty"`
+	GroupCounts      []*GroupCount      `protobuf:"bytes,8,rep,name=GroupCounts" json:"GroupCounts,omitempty"`
+	RowIdentifiers   *RowIdentifiers    `protobuf:"bytes,9,opt,name=RowIdentifiers" json:"RowIdentifiers,omitempty"`
+	DistinctValues   []int64            `protobuf:"varint,10,rep,packed,name=DistinctValues" json:"DistinctValues,omitempty"`
+	DistinctKeys     []string           `protobuf:"bytes,11,rep,name=DistinctKeys" json:"DistinctKeys,omitempty"`
+	ColumnValues     []*ColumnValue     `protobuf:"bytes,12,rep,name=ColumnValues" json:"ColumnValues,omitempty"`
+	SeriesCounts     []*SeriesCount     `protobuf:"bytes,13,rep,name=SeriesCounts" json:"SeriesCounts,omitempty"`
+	RetentionCohorts []*RetentionCohort `protobuf:"bytes,14,rep,name=RetentionCohorts" json:"RetentionCohorts,omitempty"`
+	RowSimilarities  []*RowSimilarity   `protobuf:"bytes,15,rep,name=RowSimilarities" json:"RowSimilarities,omitempty"`
+	Similarity       float64            `protobuf:"fixed64,16,opt,name=Similarity,proto3" json:"Similarity,omitempty"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
 func (m *QueryResult) String() string            { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()               {}
-func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{11} }
+func (*QueryResult) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{13} }
 
 func (m *QueryResult) GetType() uint32 {
 	if m != nil {
@@ -488,6 +596,55 @@ func (m *QueryResult) GetRowIdentifiers() *RowIdentifiers {
 	return nil
 }
 
+func (m *QueryResult) GetDistinctValues() []int64 {
+	if m != nil {
+		return m.DistinctValues
+	}
+	return nil
+}
+
+func (m *QueryResult) GetDistinctKeys() []string {
+	if m != nil {
+		return m.DistinctKeys
+	}
+	return nil
+}
+
+func (m *QueryResult) GetColumnValues() []*ColumnValue {
+	if m != nil {
+		return m.ColumnValues
+	}
+	return nil
+}
+
+func (m *QueryResult) GetSeriesCounts() []*SeriesCount {
+	if m != nil {
+		return m.SeriesCounts
+	}
+	return nil
+}
+
+func (m *QueryResult) GetRetentionCohorts() []*RetentionCohort {
+	if m != nil {
+		return m.RetentionCohorts
+	}
+	return nil
+}
+
+func (m *QueryResult) GetRowSimilarities() []*RowSimilarity {
+	if m != nil {
+		return m.RowSimilarities
+	}
+	return nil
+}
+
+func (m *QueryResult) GetSimilarity() float64 {
+	if m != nil {
+		return m.Similarity
+	}
+	return 0
+}
+
 type ImportRequest struct {
 	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -502,7 +659,7 @@ type ImportRequest struct {
 func (m *ImportRequest) Reset()                    { *m = ImportRequest{} }
 func (m *ImportRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRequest) ProtoMessage()               {}
-func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{12} }
+func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
 
 func (m *ImportRequest) GetIndex() string {
 	if m != nil {
@@ -561,18 +718,19 @@ func (m *ImportRequest) GetTimestamps() []int64 {
 }
 
 type ImportValueRequest struct {
-	Index      string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
-	Field      string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
-	Shard      uint64   `protobuf:"varint,3,opt,name=Shard,proto3" json:"Shard,omitempty"`
-	ColumnIDs  []uint64 `protobuf:"varint,5,rep,packed,name=ColumnIDs" json:"ColumnIDs,omitempty"`
-	ColumnKeys []string `protobuf:"bytes,7,rep,name=ColumnKeys" json:"ColumnKeys,omitempty"`
-	Values     []int64  `protobuf:"varint,6,rep,packed,name=Values" json:"Values,omitempty"`
+	Index       string    `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	Field       string    `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Shard       uint64    `protobuf:"varint,3,opt,name=Shard,proto3" json:"Shard,omitempty"`
+	ColumnIDs   []uint64  `protobuf:"varint,5,rep,packed,name=ColumnIDs" json:"ColumnIDs,omitempty"`
+	ColumnKeys  []string  `protobuf:"bytes,7,rep,name=ColumnKeys" json:"ColumnKeys,omitempty"`
+	Values      []int64   `protobuf:"varint,6,rep,packed,name=Values" json:"Values,omitempty"`
+	FloatValues []float64 `protobuf:"fixed64,8,rep,packed,name=FloatValues" json:"FloatValues,omitempty"`
 }
 
 func (m *ImportValueRequest) Reset()                    { *m = ImportValueRequest{} }
 func (m *ImportValueRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportValueRequest) ProtoMessage()               {}
-func (*ImportValueRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{13} }
+func (*ImportValueRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
 
 func (m *ImportValueRequest) GetIndex() string {
 	if m != nil {
@@ -616,6 +774,13 @@ func (m *ImportValueRequest) GetValues() []int64 {
 	return nil
 }
 
+func (m *ImportValueRequest) GetFloatValues() []float64 {
+	if m != nil {
+		return m.FloatValues
+	}
+	return nil
+}
+
 type TranslateKeysRequest struct {
 	Index string   `protobuf:"bytes,1,opt,name=Index,proto3" json:"Index,omitempty"`
 	Field string   `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
@@ -625,7 +790,7 @@ type TranslateKeysRequest struct {
 func (m *TranslateKeysRequest) Reset()                    { *m = TranslateKeysRequest{} }
 func (m *TranslateKeysRequest) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysRequest) ProtoMessage()               {}
-func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{14} }
+func (*TranslateKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
 
 func (m *TranslateKeysRequest) GetIndex() string {
 	if m != nil {
@@ -655,7 +820,7 @@ type TranslateKeysResponse struct {
 func (m *TranslateKeysResponse) Reset()                    { *m = TranslateKeysResponse{} }
 func (m *TranslateKeysResponse) String() string            { return proto.CompactTextString(m) }
 func (*TranslateKeysResponse) ProtoMessage()               {}
-func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{15} }
+func (*TranslateKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
 
 func (m *TranslateKeysResponse) GetIDs() []uint64 {
 	if m != nil {
@@ -672,7 +837,7 @@ type ImportRoaringRequestView struct {
 func (m *ImportRoaringRequestView) Reset()                    { *m = ImportRoaringRequestView{} }
 func (m *ImportRoaringRequestView) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequestView) ProtoMessage()               {}
-func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{16} }
+func (*ImportRoaringRequestView) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{18} }
 
 func (m *ImportRoaringRequestView) GetName() string {
 	if m != nil {
@@ -696,7 +861,7 @@ type ImportRoaringRequest struct {
 func (m *ImportRoaringRequest) Reset()                    { *m = ImportRoaringRequest{} }
 func (m *ImportRoaringRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportRoaringRequest) ProtoMessage()               {}
-func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{17} }
+func (*ImportRoaringRequest) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{19} }
 
 func (m *ImportRoaringRequest) GetClear() bool {
 	if m != nil {
@@ -712,13 +877,119 @@ func (m *ImportRoaringRequest) GetViews() []*ImportRoaringRequestView {
 	return nil
 }
 
+type SeriesCount struct {
+	Timestamp int64  `protobuf:"varint,1,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Count     uint64 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+}
+
+func (m *SeriesCount) Reset()                    { *m = SeriesCount{} }
+func (m *SeriesCount) String() string            { return proto.CompactTextString(m) }
+func (*SeriesCount) ProtoMessage()               {}
+func (*SeriesCount) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{20} }
+
+func (m *SeriesCount) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *SeriesCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type RetentionCohort struct {
+	Timestamp  int64    `protobuf:"varint,1,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	CohortSize uint64   `protobuf:"varint,2,opt,name=CohortSize,proto3" json:"CohortSize,omitempty"`
+	Counts     []uint64 `protobuf:"varint,3,rep,packed,name=Counts" json:"Counts,omitempty"`
+}
+
+func (m *RetentionCohort) Reset()                    { *m = RetentionCohort{} }
+func (m *RetentionCohort) String() string            { return proto.CompactTextString(m) }
+func (*RetentionCohort) ProtoMessage()               {}
+func (*RetentionCohort) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{21} }
+
+func (m *RetentionCohort) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RetentionCohort) GetCohortSize() uint64 {
+	if m != nil {
+		return m.CohortSize
+	}
+	return 0
+}
+
+func (m *RetentionCohort) GetCounts() []uint64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type RowSimilarity struct {
+	ID           uint64  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Key          string  `protobuf:"bytes,2,opt,name=Key,proto3" json:"Key,omitempty"`
+	Count        uint64  `protobuf:"varint,3,opt,name=Count,proto3" json:"Count,omitempty"`
+	Intersection uint64  `protobuf:"varint,4,opt,name=Intersection,proto3" json:"Intersection,omitempty"`
+	Score        float64 `protobuf:"fixed64,5,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (m *RowSimilarity) Reset()                    { *m = RowSimilarity{} }
+func (m *RowSimilarity) String() string            { return proto.CompactTextString(m) }
+func (*RowSimilarity) ProtoMessage()               {}
+func (*RowSimilarity) Descriptor() ([]byte, []int) { return fileDescriptorPublic, []int{22} }
+
+func (m *RowSimilarity) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *RowSimilarity) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *RowSimilarity) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *RowSimilarity) GetIntersection() uint64 {
+	if m != nil {
+		return m.Intersection
+	}
+	return 0
+}
+
+func (m *RowSimilarity) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Row)(nil), "internal.Row")
+	proto.RegisterType((*RowCursor)(nil), "internal.RowCursor")
 	proto.RegisterType((*RowIdentifiers)(nil), "internal.RowIdentifiers")
 	proto.RegisterType((*Pair)(nil), "internal.Pair")
 	proto.RegisterType((*FieldRow)(nil), "internal.FieldRow")
 	proto.RegisterType((*GroupCount)(nil), "internal.GroupCount")
 	proto.RegisterType((*ValCount)(nil), "internal.ValCount")
+	proto.RegisterType((*ColumnValue)(nil), "internal.ColumnValue")
 	proto.RegisterType((*ColumnAttrSet)(nil), "internal.ColumnAttrSet")
 	proto.RegisterType((*Attr)(nil), "internal.Attr")
 	proto.RegisterType((*AttrMap)(nil), "internal.AttrMap")
@@ -731,6 +1002,9 @@ func init() {
 	proto.RegisterType((*TranslateKeysResponse)(nil), "internal.TranslateKeysResponse")
 	proto.RegisterType((*ImportRoaringRequestView)(nil), "internal.ImportRoaringRequestView")
 	proto.RegisterType((*ImportRoaringRequest)(nil), "internal.ImportRoaringRequest")
+	proto.RegisterType((*SeriesCount)(nil), "internal.SeriesCount")
+	proto.RegisterType((*RetentionCohort)(nil), "internal.RetentionCohort")
+	proto.RegisterType((*RowSimilarity)(nil), "internal.RowSimilarity")
 }
 func (m *Row) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -791,6 +1065,45 @@ func (m *Row) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.Next != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Next.Size()))
+		n3, err := m.Next.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+
+func (m *RowCursor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowCursor) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Column != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Column))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
 	return i, nil
 }
 
@@ -810,21 +1123,21 @@ func (m *RowIdentifiers) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Rows) > 0 {
-		dAtA4 := make([]byte, len(m.Rows)*10)
-		var j3 int
+		dAtA5 := make([]byte, len(m.Rows)*10)
+		var j4 int
 		for _, num := range m.Rows {
 			for num >= 1<<7 {
-				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j3++
+				j4++
 			}
-			dAtA4[j3] = uint8(num)
-			j3++
+			dAtA5[j4] = uint8(num)
+			j4++
 		}
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j3))
-		i += copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintPublic(dAtA, i, uint64(j4))
+		i += copy(dAtA[i:], dAtA5[:j4])
 	}
 	if len(m.Keys) > 0 {
 		for _, s := range m.Keys {
@@ -945,6 +1258,21 @@ func (m *GroupCount) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.Count))
 	}
+	if m.Sum != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Sum))
+	}
+	if m.HasSum {
+		dAtA[i] = 0x20
+		i++
+		if m.HasSum {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -973,6 +1301,51 @@ func (m *ValCount) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.Count))
 	}
+	if m.Scale != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Scale))
+	}
+	if len(m.TimeUnit) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.TimeUnit)))
+		i += copy(dAtA[i:], m.TimeUnit)
+	}
+	return i, nil
+}
+
+func (m *ColumnValue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ColumnValue) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.ID))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.Value != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Value))
+	}
 	return i, nil
 }
 
@@ -1125,21 +1498,21 @@ func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
 		i += copy(dAtA[i:], m.Query)
 	}
 	if len(m.Shards) > 0 {
-		dAtA6 := make([]byte, len(m.Shards)*10)
-		var j5 int
+		dAtA7 := make([]byte, len(m.Shards)*10)
+		var j6 int
 		for _, num := range m.Shards {
 			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j5++
+				j6++
 			}
-			dAtA6[j5] = uint8(num)
-			j5++
+			dAtA7[j6] = uint8(num)
+			j6++
 		}
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j5))
-		i += copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintPublic(dAtA, i, uint64(j6))
+		i += copy(dAtA[i:], dAtA7[:j6])
 	}
 	if m.ColumnAttrs {
 		dAtA[i] = 0x18
@@ -1251,11 +1624,11 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.Row.Size()))
-		n7, err := m.Row.MarshalTo(dAtA[i:])
+		n8, err := m.Row.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n7
+		i += n8
 	}
 	if m.N != 0 {
 		dAtA[i] = 0x10
@@ -1288,11 +1661,11 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.ValCount.Size()))
-		n8, err := m.ValCount.MarshalTo(dAtA[i:])
+		n9, err := m.ValCount.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n8
+		i += n9
 	}
 	if m.Type != 0 {
 		dAtA[i] = 0x30
@@ -1300,21 +1673,21 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Type))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA10 := make([]byte, len(m.RowIDs)*10)
-		var j9 int
+		dAtA11 := make([]byte, len(m.RowIDs)*10)
+		var j10 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA11[j10] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j9++
+				j10++
 			}
-			dAtA10[j9] = uint8(num)
-			j9++
+			dAtA11[j10] = uint8(num)
+			j10++
 		}
 		dAtA[i] = 0x3a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j9))
-		i += copy(dAtA[i:], dAtA10[:j9])
+		i = encodeVarintPublic(dAtA, i, uint64(j10))
+		i += copy(dAtA[i:], dAtA11[:j10])
 	}
 	if len(m.GroupCounts) > 0 {
 		for _, msg := range m.GroupCounts {
@@ -1332,21 +1705,110 @@ func (m *QueryResult) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0x4a
 		i++
 		i = encodeVarintPublic(dAtA, i, uint64(m.RowIdentifiers.Size()))
-		n11, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
+		n12, err := m.RowIdentifiers.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n11
+		i += n12
 	}
-	return i, nil
-}
-
-func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.DistinctValues) > 0 {
+		dAtA14 := make([]byte, len(m.DistinctValues)*10)
+		var j13 int
+		for _, num1 := range m.DistinctValues {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA14[j13] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j13++
+			}
+			dAtA14[j13] = uint8(num)
+			j13++
+		}
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j13))
+		i += copy(dAtA[i:], dAtA14[:j13])
+	}
+	if len(m.DistinctKeys) > 0 {
+		for _, s := range m.DistinctKeys {
+			dAtA[i] = 0x5a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ColumnValues) > 0 {
+		for _, msg := range m.ColumnValues {
+			dAtA[i] = 0x62
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.SeriesCounts) > 0 {
+		for _, msg := range m.SeriesCounts {
+			dAtA[i] = 0x6a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.RetentionCohorts) > 0 {
+		for _, msg := range m.RetentionCohorts {
+			dAtA[i] = 0x72
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.RowSimilarities) > 0 {
+		for _, msg := range m.RowSimilarities {
+			dAtA[i] = 0x7a
+			i++
+			i = encodeVarintPublic(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Similarity != 0 {
+		dAtA[i] = 0x81
+		i++
+		dAtA[i] = 0x1
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Similarity))))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
 	return dAtA[:n], nil
 }
@@ -1374,56 +1836,56 @@ func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.RowIDs) > 0 {
-		dAtA13 := make([]byte, len(m.RowIDs)*10)
-		var j12 int
+		dAtA16 := make([]byte, len(m.RowIDs)*10)
+		var j15 int
 		for _, num := range m.RowIDs {
 			for num >= 1<<7 {
-				dAtA13[j12] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA16[j15] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j12++
+				j15++
 			}
-			dAtA13[j12] = uint8(num)
-			j12++
+			dAtA16[j15] = uint8(num)
+			j15++
 		}
 		dAtA[i] = 0x22
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j12))
-		i += copy(dAtA[i:], dAtA13[:j12])
+		i = encodeVarintPublic(dAtA, i, uint64(j15))
+		i += copy(dAtA[i:], dAtA16[:j15])
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA15 := make([]byte, len(m.ColumnIDs)*10)
-		var j14 int
+		dAtA18 := make([]byte, len(m.ColumnIDs)*10)
+		var j17 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j14++
+				j17++
 			}
-			dAtA15[j14] = uint8(num)
-			j14++
+			dAtA18[j17] = uint8(num)
+			j17++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j14))
-		i += copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintPublic(dAtA, i, uint64(j17))
+		i += copy(dAtA[i:], dAtA18[:j17])
 	}
 	if len(m.Timestamps) > 0 {
-		dAtA17 := make([]byte, len(m.Timestamps)*10)
-		var j16 int
+		dAtA20 := make([]byte, len(m.Timestamps)*10)
+		var j19 int
 		for _, num1 := range m.Timestamps {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j16++
+				j19++
 			}
-			dAtA17[j16] = uint8(num)
-			j16++
+			dAtA20[j19] = uint8(num)
+			j19++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j16))
-		i += copy(dAtA[i:], dAtA17[:j16])
+		i = encodeVarintPublic(dAtA, i, uint64(j19))
+		i += copy(dAtA[i:], dAtA20[:j19])
 	}
 	if len(m.RowKeys) > 0 {
 		for _, s := range m.RowKeys {
@@ -1491,39 +1953,39 @@ func (m *ImportValueRequest) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPublic(dAtA, i, uint64(m.Shard))
 	}
 	if len(m.ColumnIDs) > 0 {
-		dAtA19 := make([]byte, len(m.ColumnIDs)*10)
-		var j18 int
+		dAtA22 := make([]byte, len(m.ColumnIDs)*10)
+		var j21 int
 		for _, num := range m.ColumnIDs {
 			for num >= 1<<7 {
-				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j18++
+				j21++
 			}
-			dAtA19[j18] = uint8(num)
-			j18++
+			dAtA22[j21] = uint8(num)
+			j21++
 		}
 		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j18))
-		i += copy(dAtA[i:], dAtA19[:j18])
+		i = encodeVarintPublic(dAtA, i, uint64(j21))
+		i += copy(dAtA[i:], dAtA22[:j21])
 	}
 	if len(m.Values) > 0 {
-		dAtA21 := make([]byte, len(m.Values)*10)
-		var j20 int
+		dAtA24 := make([]byte, len(m.Values)*10)
+		var j23 int
 		for _, num1 := range m.Values {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA24[j23] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j20++
+				j23++
 			}
-			dAtA21[j20] = uint8(num)
-			j20++
+			dAtA24[j23] = uint8(num)
+			j23++
 		}
 		dAtA[i] = 0x32
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j20))
-		i += copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintPublic(dAtA, i, uint64(j23))
+		i += copy(dAtA[i:], dAtA24[:j23])
 	}
 	if len(m.ColumnKeys) > 0 {
 		for _, s := range m.ColumnKeys {
@@ -1540,6 +2002,16 @@ func (m *ImportValueRequest) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.FloatValues) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.FloatValues)*8))
+		for _, num := range m.FloatValues {
+			f25 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f25))
+			i += 8
+		}
+	}
 	return i, nil
 }
 
@@ -1604,21 +2076,21 @@ func (m *TranslateKeysResponse) MarshalTo(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.IDs) > 0 {
-		dAtA23 := make([]byte, len(m.IDs)*10)
-		var j22 int
+		dAtA27 := make([]byte, len(m.IDs)*10)
+		var j26 int
 		for _, num := range m.IDs {
 			for num >= 1<<7 {
-				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j22++
+				j26++
 			}
-			dAtA23[j22] = uint8(num)
-			j22++
+			dAtA27[j26] = uint8(num)
+			j26++
 		}
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintPublic(dAtA, i, uint64(j22))
-		i += copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPublic(dAtA, i, uint64(j26))
+		i += copy(dAtA[i:], dAtA27[:j26])
 	}
 	return i, nil
 }
@@ -1693,6 +2165,124 @@ func (m *ImportRoaringRequest) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Timestamp))
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
+}
+
+func (m *RetentionCohort) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetentionCohort) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Timestamp))
+	}
+	if m.CohortSize != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.CohortSize))
+	}
+	if len(m.Counts) > 0 {
+		dAtA29 := make([]byte, len(m.Counts)*10)
+		var j28 int
+		for _, num := range m.Counts {
+			for num >= 1<<7 {
+				dAtA29[j28] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j28++
+			}
+			dAtA29[j28] = uint8(num)
+			j28++
+		}
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(j28))
+		i += copy(dAtA[i:], dAtA29[:j28])
+	}
+	return i, nil
+}
+
+func (m *RowSimilarity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RowSimilarity) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ID != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.ID))
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Count))
+	}
+	if m.Intersection != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPublic(dAtA, i, uint64(m.Intersection))
+	}
+	if m.Score != 0 {
+		dAtA[i] = 0x29
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i += 8
+	}
+	return i, nil
+}
+
 func encodeVarintPublic(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1724,6 +2314,23 @@ func (m *Row) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if m.Next != nil {
+		l = m.Next.Size()
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+
+func (m *RowCursor) Size() (n int) {
+	var l int
+	_ = l
+	if m.Column != 0 {
+		n += 1 + sovPublic(uint64(m.Column))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
 	return n
 }
 
@@ -1791,6 +2398,12 @@ func (m *GroupCount) Size() (n int) {
 	if m.Count != 0 {
 		n += 1 + sovPublic(uint64(m.Count))
 	}
+	if m.Sum != 0 {
+		n += 1 + sovPublic(uint64(m.Sum))
+	}
+	if m.HasSum {
+		n += 2
+	}
 	return n
 }
 
@@ -1803,6 +2416,29 @@ func (m *ValCount) Size() (n int) {
 	if m.Count != 0 {
 		n += 1 + sovPublic(uint64(m.Count))
 	}
+	if m.Scale != 0 {
+		n += 1 + sovPublic(uint64(m.Scale))
+	}
+	l = len(m.TimeUnit)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	return n
+}
+
+func (m *ColumnValue) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovPublic(uint64(m.ID))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Value != 0 {
+		n += 1 + sovPublic(uint64(m.Value))
+	}
 	return n
 }
 
@@ -1957,6 +2593,46 @@ func (m *QueryResult) Size() (n int) {
 		l = m.RowIdentifiers.Size()
 		n += 1 + l + sovPublic(uint64(l))
 	}
+	if len(m.DistinctValues) > 0 {
+		l = 0
+		for _, e := range m.DistinctValues {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	if len(m.DistinctKeys) > 0 {
+		for _, s := range m.DistinctKeys {
+			l = len(s)
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.ColumnValues) > 0 {
+		for _, e := range m.ColumnValues {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.SeriesCounts) > 0 {
+		for _, e := range m.SeriesCounts {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.RetentionCohorts) > 0 {
+		for _, e := range m.RetentionCohorts {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if len(m.RowSimilarities) > 0 {
+		for _, e := range m.RowSimilarities {
+			l = e.Size()
+			n += 1 + l + sovPublic(uint64(l))
+		}
+	}
+	if m.Similarity != 0 {
+		n += 10
+	}
 	return n
 }
 
@@ -2044,6 +2720,9 @@ func (m *ImportValueRequest) Size() (n int) {
 			n += 1 + l + sovPublic(uint64(l))
 		}
 	}
+	if len(m.FloatValues) > 0 {
+		n += 1 + sovPublic(uint64(len(m.FloatValues)*8)) + len(m.FloatValues)*8
+	}
 	return n
 }
 
@@ -2109,29 +2788,82 @@ func (m *ImportRoaringRequest) Size() (n int) {
 	return n
 }
 
-func sovPublic(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *SeriesCount) Size() (n int) {
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		n += 1 + sovPublic(uint64(m.Timestamp))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPublic(uint64(m.Count))
 	}
 	return n
 }
-func sozPublic(x uint64) (n int) {
-	return sovPublic(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *RetentionCohort) Size() (n int) {
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		n += 1 + sovPublic(uint64(m.Timestamp))
+	}
+	if m.CohortSize != 0 {
+		n += 1 + sovPublic(uint64(m.CohortSize))
+	}
+	if len(m.Counts) > 0 {
+		l = 0
+		for _, e := range m.Counts {
+			l += sovPublic(uint64(e))
+		}
+		n += 1 + sovPublic(uint64(l)) + l
+	}
+	return n
 }
-func (m *Row) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
-			}
+
+func (m *RowSimilarity) Size() (n int) {
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovPublic(uint64(m.ID))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPublic(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovPublic(uint64(m.Count))
+	}
+	if m.Intersection != 0 {
+		n += 1 + sovPublic(uint64(m.Intersection))
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovPublic(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozPublic(x uint64) (n int) {
+	return sovPublic(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Row) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
 			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
@@ -2273,6 +3005,137 @@ func (m *Row) Unmarshal(dAtA []byte) error {
 			}
 			m.Keys = append(m.Keys, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Next", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Next == nil {
+				m.Next = &RowCursor{}
+			}
+			if err := m.Next.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RowCursor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowCursor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowCursor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Column", wireType)
+			}
+			m.Column = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Column |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -2758,6 +3621,45 @@ func (m *GroupCount) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			m.Sum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sum |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasSum", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasSum = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -2846,6 +3748,54 @@ func (m *ValCount) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scale", wireType)
+			}
+			m.Scale = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Scale |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeUnit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TimeUnit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -2867,7 +3817,7 @@ func (m *ValCount) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ColumnAttrSet) Unmarshal(dAtA []byte) error {
+func (m *ColumnValue) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2890,10 +3840,10 @@ func (m *ColumnAttrSet) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ColumnAttrSet: wiretype end group for non-group")
+			return fmt.Errorf("proto: ColumnValue: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ColumnAttrSet: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ColumnValue: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2917,9 +3867,9 @@ func (m *ColumnAttrSet) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -2929,20 +3879,137 @@ func (m *ColumnAttrSet) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attrs = append(m.Attrs, &Attr{})
-			if err := m.Attrs[len(m.Attrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ColumnAttrSet) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ColumnAttrSet: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ColumnAttrSet: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attrs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attrs = append(m.Attrs, &Attr{})
+			if err := m.Attrs[len(m.Attrs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3926,59 +4993,71 @@ func (m *QueryResult) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPublic(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPublic
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ImportRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPublic
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+		case 10:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (int64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.DistinctValues = append(m.DistinctValues, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (int64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.DistinctValues = append(m.DistinctValues, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field DistinctValues", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DistinctKeys", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4003,13 +5082,13 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Index = string(dAtA[iNdEx:postIndex])
+			m.DistinctKeys = append(m.DistinctKeys, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ColumnValues", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -4019,26 +5098,28 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPublic
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Field = string(dAtA[iNdEx:postIndex])
+			m.ColumnValues = append(m.ColumnValues, &ColumnValue{})
+			if err := m.ColumnValues[len(m.ColumnValues)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCounts", wireType)
 			}
-			m.Shard = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPublic
@@ -4048,21 +5129,233 @@ func (m *ImportRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Shard |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPublic
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SeriesCounts = append(m.SeriesCounts, &SeriesCount{})
+			if err := m.SeriesCounts[len(m.SeriesCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionCohorts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RetentionCohorts = append(m.RetentionCohorts, &RetentionCohort{})
+			if err := m.RetentionCohorts[len(m.RetentionCohorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowSimilarities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RowSimilarities = append(m.RowSimilarities, &RowSimilarity{})
+			if err := m.RowSimilarities[len(m.RowSimilarities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Similarity", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Similarity = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Index = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shard", wireType)
+			}
+			m.Shard = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shard |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
 					b := dAtA[iNdEx]
 					iNdEx++
 					v |= (uint64(b) & 0x7F) << shift
@@ -4577,6 +5870,52 @@ func (m *ImportValueRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.ColumnKeys = append(m.ColumnKeys, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 8:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.FloatValues = append(m.FloatValues, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.FloatValues = append(m.FloatValues, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatValues", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPublic(dAtA[iNdEx:])
@@ -5058,6 +6397,391 @@ func (m *ImportRoaringRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SeriesCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SeriesCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetentionCohort) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetentionCohort: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetentionCohort: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CohortSize", wireType)
+			}
+			m.CohortSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CohortSize |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Counts = append(m.Counts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPublic
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPublic
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPublic
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Counts = append(m.Counts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RowSimilarity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPublic
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RowSimilarity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RowSimilarity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPublic
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Intersection", wireType)
+			}
+			m.Intersection = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPublic
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Intersection |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPublic(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPublic
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipPublic(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5166,60 +6890,80 @@ var (
 func init() { proto.RegisterFile("public.proto", fileDescriptorPublic) }
 
 var fileDescriptorPublic = []byte{
-	// 880 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x8e, 0x1b, 0x45,
-	0x10, 0xa6, 0x3d, 0x63, 0x7b, 0x5c, 0x5e, 0x9b, 0xa8, 0xe5, 0x84, 0x11, 0x8a, 0x8c, 0x35, 0x42,
-	0x68, 0xb8, 0x6c, 0x24, 0x23, 0xa1, 0x9c, 0xf8, 0xd9, 0x78, 0x83, 0xac, 0xc0, 0x0a, 0x6a, 0x57,
-	0x46, 0x1c, 0x3b, 0x71, 0x27, 0x19, 0x69, 0x3c, 0x6d, 0x66, 0x7a, 0x70, 0xf6, 0x39, 0xb8, 0xf0,
-	0x08, 0x1c, 0x78, 0x90, 0x1c, 0x11, 0x4f, 0x00, 0xcb, 0x8b, 0xa0, 0xae, 0x9e, 0xde, 0x1e, 0x7b,
-	0x97, 0x08, 0xa1, 0xdc, 0xea, 0xab, 0xea, 0xaa, 0xa9, 0xaf, 0xfe, 0x6c, 0x38, 0xda, 0xd6, 0x4f,
-	0xf3, 0xec, 0xd9, 0xf1, 0xb6, 0x54, 0x5a, 0xf1, 0x28, 0x2b, 0xb4, 0x2c, 0x0b, 0x91, 0x27, 0x3f,
-	0x40, 0x80, 0x6a, 0xc7, 0x63, 0xe8, 0x3f, 0x52, 0x79, 0xbd, 0x29, 0xaa, 0x98, 0xcd, 0x82, 0x34,
-	0x44, 0x07, 0xf9, 0x87, 0xd0, 0xfd, 0x52, 0xeb, 0xb2, 0x8a, 0x3b, 0xb3, 0x20, 0x1d, 0xce, 0xc7,
-	0xc7, 0xce, 0xf5, 0xd8, 0xa8, 0xd1, 0x1a, 0x39, 0x87, 0xf0, 0x89, 0xbc, 0xac, 0xe2, 0x60, 0x16,
-	0xa4, 0x03, 0x24, 0x39, 0x79, 0x08, 0x63, 0x54, 0xbb, 0xe5, 0x5a, 0x16, 0x3a, 0x7b, 0x9e, 0x49,
-	0xfb, 0x0a, 0xd5, 0xce, 0x7d, 0x82, 0xe4, 0x6b, 0xcf, 0x4e, 0xcb, 0xf3, 0x33, 0x08, 0xbf, 0x15,
-	0x59, 0xc9, 0xc7, 0xd0, 0x59, 0x2e, 0x62, 0x36, 0x63, 0x69, 0x88, 0x9d, 0xe5, 0x82, 0x4f, 0xa0,
-	0xfb, 0x48, 0xd5, 0x85, 0x8e, 0x3b, 0xa4, 0xb2, 0x80, 0xdf, 0x81, 0xe0, 0x89, 0xbc, 0x8c, 0x83,
-	0x19, 0x4b, 0x07, 0x68, 0xc4, 0xe4, 0x0c, 0xa2, 0xc7, 0x99, 0xcc, 0xd7, 0x86, 0xd9, 0x04, 0xba,
-	0x24, 0x53, 0x98, 0x01, 0x5a, 0x60, 0xb4, 0x26, 0xb7, 0x85, 0x8b, 0x44, 0x80, 0xdf, 0x83, 0x1e,
-	0xaa, 0x9d, 0x0f, 0xd6, 0xa0, 0xe4, 0x6b, 0x80, 0xaf, 0x4a, 0x55, 0x6f, 0xed, 0xf7, 0x52, 0xe8,
-	0x12, 0x22, 0x1a, 0xc3, 0x39, 0xf7, 0x15, 0x71, 0x1f, 0x45, 0xfb, 0xe0, 0xf6, 0x7c, 0x93, 0x39,
-	0x44, 0x2b, 0x91, 0x5f, 0xe7, 0xbe, 0x12, 0x39, 0xe5, 0x16, 0xa0, 0x11, 0xf7, 0x7d, 0x02, 0xe7,
-	0xf3, 0x3d, 0x8c, 0x6c, 0x43, 0x4c, 0xb9, 0xcf, 0xa5, 0xbe, 0x51, 0x9a, 0xff, 0xd6, 0xa6, 0x9b,
-	0xa5, 0xfa, 0x95, 0x41, 0x68, 0x6c, 0xce, 0xc4, 0xae, 0x4d, 0xa6, 0x33, 0x17, 0x97, 0x5b, 0xd9,
-	0x24, 0x4f, 0x32, 0x9f, 0xc1, 0xf0, 0x5c, 0x97, 0x59, 0xf1, 0x62, 0x25, 0xf2, 0x5a, 0x36, 0x81,
-	0xda, 0x2a, 0xfe, 0x3e, 0x44, 0xcb, 0x42, 0x5b, 0x73, 0x48, 0x14, 0xae, 0x31, 0xbf, 0x0f, 0x83,
-	0x13, 0xa5, 0x72, 0x6b, 0xec, 0xce, 0x58, 0x1a, 0xa1, 0x57, 0xf0, 0x29, 0xc0, 0xe3, 0x5c, 0x89,
-	0xc6, 0xb7, 0x37, 0x63, 0x29, 0xc3, 0x96, 0x26, 0x79, 0x00, 0x7d, 0x93, 0xe9, 0x37, 0x62, 0xeb,
-	0xd9, 0xb2, 0x37, 0xb0, 0x4d, 0x5e, 0x33, 0x38, 0xfa, 0xae, 0x96, 0xe5, 0x25, 0xca, 0x1f, 0x6b,
-	0x59, 0x69, 0x53, 0x5b, 0xc2, 0x6e, 0x16, 0x08, 0x98, 0xae, 0x9f, 0xbf, 0x14, 0xe5, 0xda, 0xd6,
-	0x2e, 0xc4, 0x06, 0x19, 0xae, 0xbe, 0xe6, 0x15, 0x71, 0x8d, 0xb0, 0xad, 0xa2, 0x79, 0x91, 0x1b,
-	0xa5, 0x1d, 0x99, 0x06, 0xf1, 0x14, 0xde, 0x3d, 0x7d, 0xf5, 0x2c, 0xaf, 0xd7, 0x12, 0xd5, 0xce,
-	0x7a, 0xf7, 0xe8, 0xc1, 0xa1, 0x9a, 0x7f, 0x04, 0xe3, 0x46, 0xe5, 0xd6, 0xaf, 0x4f, 0x0f, 0x0f,
-	0xb4, 0xc9, 0xcf, 0x0c, 0x46, 0x0d, 0x95, 0x6a, 0xab, 0x8a, 0x4a, 0x9a, 0x7e, 0x9d, 0x96, 0xa5,
-	0xeb, 0xd7, 0x69, 0x59, 0xf2, 0x07, 0xd0, 0x47, 0x59, 0xd5, 0xb9, 0x76, 0x43, 0x70, 0xd7, 0x97,
-	0xc5, 0xf9, 0xd6, 0xb9, 0x46, 0xf7, 0x8a, 0x7f, 0x0e, 0xe3, 0xbd, 0xa1, 0xb2, 0xeb, 0x3b, 0x9c,
-	0xbf, 0xe7, 0xfd, 0xf6, 0xec, 0x78, 0xf0, 0x3c, 0xf9, 0xa3, 0x03, 0xc3, 0x56, 0x64, 0xfe, 0x01,
-	0x1d, 0x13, 0xca, 0x69, 0x38, 0x1f, 0xf9, 0x28, 0x66, 0x25, 0xe8, 0xcc, 0x1c, 0x01, 0x3b, 0x6b,
-	0xe6, 0x89, 0x9d, 0x99, 0x2e, 0x9a, 0x35, 0x77, 0x9f, 0x6d, 0x75, 0xd1, 0xa8, 0xd1, 0x1a, 0xe9,
-	0x34, 0xbd, 0x14, 0xc5, 0x0b, 0xb9, 0xa6, 0x79, 0x8a, 0xd0, 0x41, 0x7e, 0xec, 0x17, 0x89, 0x1a,
-	0xb0, 0xb7, 0x8b, 0xce, 0x82, 0x7e, 0xd9, 0xdc, 0x40, 0x9b, 0x5e, 0x8c, 0x9a, 0x81, 0xb6, 0x2b,
-	0xbf, 0x5c, 0x98, 0xc2, 0x53, 0xf3, 0x2d, 0xe2, 0x9f, 0xc2, 0xd0, 0xaf, 0x7c, 0x15, 0x47, 0x94,
-	0xe1, 0xc4, 0x87, 0xf7, 0x46, 0x6c, 0x3f, 0xe4, 0x5f, 0x1c, 0x1e, 0xbd, 0x78, 0x40, 0x99, 0xc5,
-	0x7b, 0xd5, 0x68, 0xd9, 0xf1, 0xe0, 0x7d, 0xf2, 0x17, 0x83, 0xd1, 0x72, 0xb3, 0x55, 0xa5, 0x6e,
-	0x8d, 0xed, 0xb2, 0x58, 0xcb, 0x57, 0x6e, 0x6c, 0x09, 0xf8, 0xc3, 0xd6, 0x39, 0x38, 0x6c, 0x34,
-	0xbe, 0x34, 0xae, 0x21, 0x5a, 0xd0, 0x62, 0x19, 0xee, 0xb1, 0xbc, 0x0f, 0x03, 0xdb, 0x52, 0x63,
-	0xea, 0x92, 0xc9, 0x2b, 0xcc, 0x42, 0x5e, 0x64, 0x1b, 0x59, 0x69, 0xb1, 0xd9, 0x9a, 0x09, 0x0e,
-	0xd2, 0x00, 0x5b, 0x1a, 0xd3, 0x19, 0x7b, 0x20, 0x6d, 0xf1, 0x06, 0xe8, 0xa0, 0xf1, 0xb4, 0x61,
-	0xc8, 0x18, 0x91, 0xb1, 0xa5, 0x49, 0x7e, 0x63, 0xc0, 0x2d, 0x47, 0x5a, 0xed, 0xb7, 0x47, 0xf4,
-	0xcd, 0x84, 0xee, 0x41, 0x8f, 0xbe, 0xe7, 0xc8, 0x34, 0xe8, 0x20, 0xdd, 0xfe, 0x8d, 0x74, 0x57,
-	0x30, 0xb9, 0x28, 0x45, 0x51, 0xe5, 0x42, 0x4b, 0xa3, 0xf8, 0x3f, 0xf9, 0xde, 0xf6, 0x0b, 0xf9,
-	0x31, 0xdc, 0x3d, 0x88, 0xeb, 0x97, 0xdb, 0x10, 0x08, 0x88, 0x80, 0x11, 0x93, 0x13, 0x88, 0x9b,
-	0xa1, 0x50, 0xc2, 0x1c, 0xdb, 0x26, 0x85, 0x55, 0x26, 0x77, 0x26, 0xf4, 0x99, 0xd8, 0xc8, 0x26,
-	0x0b, 0x92, 0x8d, 0x6e, 0x21, 0xb4, 0xa0, 0x1c, 0x8e, 0x90, 0xe4, 0xe4, 0x39, 0x4c, 0x6e, 0x8b,
-	0x41, 0x3f, 0x39, 0xb9, 0x14, 0xf6, 0x98, 0x44, 0x68, 0x01, 0x7f, 0x08, 0xdd, 0x9f, 0x32, 0xb9,
-	0x73, 0xc7, 0x24, 0xf1, 0x03, 0xfc, 0x6f, 0x89, 0xa0, 0x75, 0x38, 0xb9, 0xf3, 0xfa, 0x6a, 0xca,
-	0x7e, 0xbf, 0x9a, 0xb2, 0x3f, 0xaf, 0xa6, 0xec, 0x97, 0xbf, 0xa7, 0xef, 0x3c, 0xed, 0xd1, 0xdf,
-	0x8e, 0x4f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x9f, 0x87, 0xba, 0x9b, 0x86, 0x08, 0x00, 0x00,
+	// 1185 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x6e, 0x1b, 0xc5,
+	0x17, 0xff, 0xaf, 0x77, 0x9d, 0xd8, 0xc7, 0x1f, 0x89, 0xe6, 0xef, 0x96, 0x05, 0x55, 0xc1, 0x1a,
+	0x21, 0x30, 0x37, 0xa9, 0x14, 0x04, 0x2a, 0x37, 0x40, 0x1b, 0xa7, 0x60, 0x55, 0x44, 0x70, 0x1c,
+	0xc2, 0xf5, 0x36, 0x9e, 0x26, 0x2b, 0xad, 0x77, 0xcd, 0x7e, 0xd4, 0x09, 0x77, 0xf0, 0x0a, 0xdc,
+	0xf0, 0x08, 0x3c, 0x05, 0xd7, 0xbd, 0x42, 0x3c, 0x02, 0x84, 0x17, 0x41, 0xe7, 0xcc, 0x8e, 0x67,
+	0x77, 0x93, 0x96, 0x0a, 0x71, 0x37, 0xe7, 0xfb, 0x73, 0x7e, 0x3b, 0x0b, 0xfd, 0x55, 0xf1, 0x34,
+	0x0a, 0xcf, 0xf6, 0x57, 0x69, 0x92, 0x27, 0xa2, 0x13, 0xc6, 0xb9, 0x4a, 0xe3, 0x20, 0x92, 0x3f,
+	0x3a, 0xe0, 0x62, 0xb2, 0x16, 0x3e, 0x6c, 0x1f, 0x26, 0x51, 0xb1, 0x8c, 0x33, 0xdf, 0x19, 0xbb,
+	0x13, 0x0f, 0x0d, 0x29, 0xde, 0x81, 0xf6, 0xc3, 0x3c, 0x4f, 0x33, 0xbf, 0x35, 0x76, 0x27, 0xbd,
+	0x83, 0xe1, 0xbe, 0xb1, 0xdd, 0x27, 0x36, 0x6a, 0xa1, 0x10, 0xe0, 0x3d, 0x51, 0x57, 0x99, 0xef,
+	0x8e, 0xdd, 0x49, 0x17, 0xf9, 0x2c, 0xde, 0x03, 0xef, 0x58, 0x5d, 0xe6, 0xbe, 0x37, 0x76, 0x26,
+	0xbd, 0x83, 0xff, 0x5b, 0x43, 0x4c, 0xd6, 0x87, 0x45, 0x9a, 0x25, 0x29, 0xb2, 0x82, 0xfc, 0x10,
+	0xba, 0x1b, 0x96, 0xb8, 0x0b, 0x5b, 0x3a, 0xb4, 0xef, 0x8c, 0x9d, 0x89, 0x87, 0x25, 0x25, 0x76,
+	0xc1, 0x7d, 0xa2, 0xae, 0xfc, 0xd6, 0xd8, 0x99, 0x74, 0x91, 0x8e, 0xf2, 0x01, 0x0c, 0x31, 0x59,
+	0xcf, 0x16, 0x2a, 0xce, 0xc3, 0x67, 0xa1, 0xd2, 0x59, 0x60, 0xb2, 0x36, 0x25, 0xf0, 0x79, 0x93,
+	0x59, 0xcb, 0x66, 0x26, 0x3f, 0x01, 0xef, 0xab, 0x20, 0x4c, 0xc5, 0x10, 0x5a, 0xb3, 0x69, 0x19,
+	0xa7, 0x35, 0x9b, 0x8a, 0x11, 0xb4, 0x0f, 0x93, 0x22, 0xce, 0x39, 0x8a, 0x87, 0x9a, 0x30, 0x91,
+	0x5d, 0x1b, 0xf9, 0x18, 0x3a, 0x8f, 0x43, 0x15, 0x2d, 0xa8, 0x73, 0x23, 0x68, 0xf3, 0x99, 0xdd,
+	0x74, 0x51, 0x13, 0xc4, 0xa5, 0xdc, 0xa6, 0xc6, 0x13, 0x13, 0x54, 0x1b, 0x26, 0x6b, 0xeb, 0xac,
+	0xa4, 0xe4, 0x73, 0x80, 0xcf, 0xd3, 0xa4, 0x58, 0xe9, 0x78, 0x13, 0x68, 0x33, 0xc5, 0x65, 0xf4,
+	0x0e, 0x84, 0x6d, 0x9c, 0x09, 0x8a, 0x5a, 0xe1, 0xe5, 0xf9, 0xce, 0x8b, 0x25, 0x87, 0x70, 0x91,
+	0x8e, 0x14, 0xf7, 0x8b, 0x20, 0x23, 0x26, 0xcd, 0xa2, 0x83, 0x25, 0x25, 0x17, 0xd0, 0x39, 0x0d,
+	0xa2, 0x8d, 0xd5, 0x69, 0x10, 0x71, 0x15, 0x2e, 0xd2, 0xb1, 0xee, 0xdd, 0x35, 0xde, 0x47, 0xd0,
+	0x9e, 0x9f, 0x05, 0x91, 0x2a, 0xfd, 0x6b, 0x42, 0xbc, 0x05, 0x9d, 0x93, 0x70, 0xa9, 0xbe, 0x89,
+	0x43, 0x3d, 0xef, 0x2e, 0x6e, 0x68, 0x79, 0x04, 0x3d, 0x3d, 0xc3, 0xd3, 0x20, 0x2a, 0xd4, 0x8d,
+	0xa6, 0xdf, 0x18, 0x2c, 0x85, 0x60, 0x55, 0x13, 0x82, 0x09, 0xf9, 0x2d, 0x0c, 0xb4, 0x1b, 0xda,
+	0xb8, 0xb9, 0xca, 0x6f, 0x38, 0x7a, 0xbd, 0x4d, 0xbd, 0x39, 0xcd, 0x5f, 0x1c, 0xf0, 0x48, 0x66,
+	0x44, 0x8e, 0xcd, 0x44, 0x80, 0x77, 0x72, 0xb5, 0x52, 0x65, 0x7f, 0xf9, 0x2c, 0xc6, 0xd0, 0x9b,
+	0xe7, 0x69, 0x18, 0x9f, 0xdb, 0x1c, 0xbb, 0x58, 0x65, 0x51, 0x33, 0x66, 0x71, 0xae, 0xc5, 0x1e,
+	0x97, 0xb0, 0xa1, 0xc5, 0x3d, 0xe8, 0x3e, 0x4a, 0x92, 0x48, 0x0b, 0xdb, 0x3c, 0x0d, 0xcb, 0x10,
+	0x7b, 0x00, 0x8f, 0xa3, 0x24, 0x28, 0x6d, 0xb7, 0xc6, 0xce, 0xc4, 0xc1, 0x0a, 0x47, 0xde, 0x87,
+	0x6d, 0xca, 0xf4, 0xcb, 0x60, 0x65, 0xab, 0x75, 0x5e, 0x51, 0xad, 0x7c, 0xe1, 0x40, 0xff, 0xeb,
+	0x42, 0xa5, 0x57, 0xa8, 0xbe, 0x2b, 0x54, 0xc6, 0xe3, 0x63, 0xda, 0xac, 0x2b, 0x13, 0xb4, 0x20,
+	0xf3, 0x8b, 0x20, 0x5d, 0xe8, 0xde, 0x79, 0x58, 0x52, 0x54, 0xab, 0xed, 0x79, 0xc6, 0xb5, 0x76,
+	0xb0, 0xca, 0x22, 0x4b, 0x54, 0xcb, 0x24, 0x37, 0xc5, 0x94, 0x94, 0x98, 0xc0, 0xce, 0xd1, 0xe5,
+	0x59, 0x54, 0x2c, 0x14, 0x26, 0x6b, 0x6d, 0xbd, 0xc5, 0x0a, 0x4d, 0xb6, 0x78, 0x17, 0x86, 0x25,
+	0xcb, 0x20, 0xd0, 0x36, 0x2b, 0x36, 0xb8, 0xf2, 0x27, 0x07, 0x06, 0x65, 0x29, 0xd9, 0x2a, 0x89,
+	0x33, 0x45, 0xf3, 0x3a, 0x4a, 0x53, 0x33, 0xaf, 0xa3, 0x34, 0x15, 0xf7, 0x61, 0x1b, 0x55, 0x56,
+	0x44, 0xb9, 0x59, 0x82, 0x3b, 0xb6, 0x2d, 0xc6, 0xb6, 0x88, 0x72, 0x34, 0x5a, 0xe2, 0x53, 0x18,
+	0xd6, 0x96, 0x4a, 0x23, 0x58, 0xef, 0xe0, 0x0d, 0x6b, 0x57, 0x93, 0x63, 0x43, 0x5d, 0xfe, 0xda,
+	0x86, 0x5e, 0xc5, 0xb3, 0x78, 0x9b, 0xf1, 0x94, 0x73, 0xea, 0x1d, 0x0c, 0x6a, 0x98, 0x87, 0x24,
+	0x11, 0x7d, 0x70, 0x8e, 0xcb, 0x7d, 0x72, 0x8e, 0x69, 0x8a, 0x84, 0x44, 0x26, 0x6c, 0x65, 0x8a,
+	0xc4, 0x46, 0x2d, 0x64, 0x74, 0xbe, 0x08, 0xe2, 0x73, 0xb5, 0x28, 0x2f, 0xb0, 0x21, 0xc5, 0xbe,
+	0xbd, 0xc1, 0x3c, 0x80, 0x1a, 0x5c, 0x18, 0x09, 0xda, 0x5b, 0x6e, 0x16, 0x9a, 0x66, 0x31, 0x28,
+	0x17, 0x5a, 0xa3, 0xd2, 0x6c, 0x4a, 0x8d, 0xe7, 0xe1, 0x6b, 0x4a, 0x7c, 0x04, 0x3d, 0x8b, 0x4a,
+	0x99, 0xdf, 0xe1, 0x0c, 0x47, 0xd6, 0xbd, 0x15, 0x62, 0x55, 0x51, 0x7c, 0xd6, 0xc4, 0x65, 0xbf,
+	0xcb, 0x99, 0xf9, 0xb5, 0x6e, 0x54, 0xe4, 0xd8, 0xd0, 0xa7, 0x95, 0x98, 0x86, 0x59, 0x1e, 0xc6,
+	0x67, 0x7a, 0xef, 0x33, 0x1f, 0xc6, 0xee, 0xc4, 0xc5, 0x06, 0x57, 0x48, 0xe8, 0x1b, 0x0e, 0x63,
+	0x7c, 0x8f, 0x31, 0xbe, 0xc6, 0x13, 0x1f, 0x43, 0xbf, 0x82, 0x3e, 0x99, 0xdf, 0x6f, 0xee, 0x45,
+	0x45, 0x8a, 0x35, 0x55, 0x32, 0x9d, 0xab, 0x34, 0x54, 0x59, 0xd9, 0x81, 0x41, 0xd3, 0xb4, 0x22,
+	0xc5, 0x9a, 0xaa, 0x38, 0x82, 0x5d, 0x54, 0x39, 0x55, 0x94, 0xc4, 0x87, 0xc9, 0x45, 0x92, 0xe6,
+	0x99, 0x3f, 0x64, 0xf3, 0x37, 0xad, 0x79, 0x43, 0x03, 0x6f, 0x98, 0x88, 0x87, 0xb0, 0x83, 0xc9,
+	0x7a, 0x1e, 0x2e, 0xc3, 0x28, 0x48, 0xc3, 0x3c, 0x54, 0x99, 0xbf, 0xd3, 0xdc, 0xcf, 0xaa, 0xc2,
+	0x15, 0x36, 0xf5, 0x09, 0x52, 0xac, 0xd8, 0xdf, 0xd5, 0x90, 0x62, 0x39, 0xf2, 0x4f, 0x07, 0x06,
+	0xb3, 0xe5, 0x8a, 0xe2, 0x5b, 0x88, 0x98, 0xc5, 0x0b, 0x75, 0x69, 0x20, 0x82, 0x09, 0xfb, 0x9d,
+	0x6b, 0x35, 0xbe, 0x73, 0x0c, 0x15, 0x0c, 0x0d, 0x1e, 0x6a, 0xa2, 0xb2, 0x51, 0x5e, 0x6d, 0xa3,
+	0xee, 0x41, 0x57, 0x37, 0x98, 0x44, 0x6d, 0x16, 0x59, 0x06, 0x65, 0x4a, 0xdf, 0x8c, 0x2c, 0x0f,
+	0x96, 0x2b, 0x42, 0x0b, 0x9a, 0x78, 0x85, 0x43, 0xb7, 0x40, 0x7f, 0x2f, 0xf5, 0xa2, 0x76, 0xd1,
+	0x90, 0x64, 0xa9, 0xdd, 0xb0, 0xb0, 0xc3, 0xc2, 0x0a, 0x47, 0xfe, 0xe6, 0x80, 0xd0, 0x35, 0xea,
+	0x31, 0xff, 0x67, 0x85, 0xbe, 0xba, 0xa0, 0xbb, 0xb0, 0x55, 0x2e, 0x9d, 0x2e, 0xa6, 0xa4, 0x1a,
+	0xe9, 0x6e, 0x37, 0xd3, 0x25, 0xd4, 0xb5, 0x98, 0xaf, 0xeb, 0x71, 0xb0, 0xca, 0x92, 0xa7, 0x30,
+	0x3a, 0x49, 0x83, 0x38, 0x8b, 0x82, 0x5c, 0x91, 0xc9, 0xbf, 0xa9, 0xe8, 0x96, 0x27, 0x9b, 0x7c,
+	0x1f, 0xee, 0x34, 0xfc, 0x5a, 0xa8, 0x9d, 0x4d, 0xb5, 0xae, 0x87, 0x74, 0x94, 0x8f, 0xc0, 0x2f,
+	0xd7, 0x26, 0x09, 0xe8, 0xd3, 0x57, 0xa6, 0x70, 0x1a, 0xaa, 0x35, 0xb9, 0x3e, 0x0e, 0x96, 0xaa,
+	0xcc, 0x82, 0xcf, 0xc4, 0x9b, 0x06, 0x79, 0xc0, 0x39, 0xf4, 0x91, 0xcf, 0xf2, 0x19, 0x8c, 0x6e,
+	0xf3, 0xc1, 0x2f, 0x8f, 0x48, 0x05, 0x1a, 0xda, 0x3b, 0xa8, 0x09, 0xf1, 0x00, 0xda, 0xcf, 0x43,
+	0xb5, 0x36, 0xd0, 0x2e, 0xed, 0x15, 0x78, 0x59, 0x22, 0xa8, 0x0d, 0xe4, 0x43, 0xe8, 0x55, 0x6e,
+	0x27, 0x4d, 0x6d, 0xb3, 0x56, 0xe5, 0x83, 0xc7, 0x32, 0x6e, 0x7f, 0x54, 0xc9, 0x73, 0xd8, 0x69,
+	0xdc, 0xce, 0x7f, 0x70, 0xc3, 0x43, 0x26, 0xbd, 0x79, 0xf8, 0xbd, 0x79, 0x40, 0x54, 0x38, 0xfa,
+	0x9d, 0xcb, 0xb0, 0xa2, 0x9b, 0x5a, 0x52, 0xf2, 0x07, 0x07, 0x06, 0xb5, 0x2b, 0xfd, 0x7a, 0x0f,
+	0x26, 0x9d, 0xb2, 0x5b, 0x7d, 0x07, 0x4a, 0xe8, 0xcf, 0xa8, 0x43, 0x99, 0x3a, 0xa3, 0xac, 0xf9,
+	0xd3, 0xe1, 0x61, 0x8d, 0xa7, 0x5f, 0x73, 0x49, 0xaa, 0xbf, 0xde, 0x0e, 0x6a, 0xe2, 0xd1, 0xee,
+	0x8b, 0xeb, 0x3d, 0xe7, 0xf7, 0xeb, 0x3d, 0xe7, 0x8f, 0xeb, 0x3d, 0xe7, 0xe7, 0xbf, 0xf6, 0xfe,
+	0xf7, 0x74, 0x8b, 0x7f, 0x1c, 0x3e, 0xf8, 0x7b, 0x00, 0xb3, 0xd9, 0xff, 0xa1, 0x48, 0x0c, 0x00,
+	0x00,
 }
@@ -45,8 +45,9 @@ var (
 	ErrInvalidRangeOperation    = errors.New("invalid range operation")
 	ErrInvalidBetweenValue      = errors.New("invalid value for between operation")
 
-	ErrInvalidView      = errors.New("invalid view")
-	ErrInvalidCacheType = errors.New("invalid cache type")
+	ErrInvalidView       = errors.New("invalid view")
+	ErrInvalidCacheType  = errors.New("invalid cache type")
+	ErrInvalidDurability = errors.New("invalid durability mode")
 
 	ErrName  = errors.New("invalid index or field name, must match [a-z][a-z0-9_-]* and contain at most 64 characters")
 	ErrLabel = errors.New("invalid row or column label, must match [A-Za-z0-9_-]")
@@ -57,6 +58,8 @@ var (
 	ErrQueryCancelled   = errors.New("query cancelled")
 	ErrQueryTimeout     = errors.New("query timeout")
 	ErrTooManyWrites    = errors.New("too many write commands")
+	ErrWritesPaused     = errors.New("writes are paused for backup")
+	ErrWritesResumed    = errors.New("writes resumed before backup completed")
 
 	// TODO(2.0) poorly named - used when a *node* doesn't own a shard. Probably
 	// we won't need this error at all by 2.0 though.
@@ -66,6 +69,10 @@ var (
 	ErrNodeNotCoordinator = errors.New("node is not the coordinator")
 	ErrResizeNotRunning   = errors.New("no resize job currently running")
 
+	ErrRestoreNotEmpty = errors.New("restore requires an empty cluster")
+	ErrInvalidBackup   = errors.New("invalid backup archive")
+	ErrBackupParent    = errors.New("incremental backup is not based on the last backup restored")
+
 	ErrNotImplemented            = errors.New("not implemented")
 	ErrFieldsArgumentRequired    = errors.New("fields argument required")
 	ErrExpectedFieldListArgument = errors.New("expected field list argument")
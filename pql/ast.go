@@ -129,8 +129,16 @@ func (q *Query) addVal(val interface{}) {
 		panic(fmt.Sprintf("addVal called with '%s' when lastField is empty", val))
 	}
 	if elem.inList {
-		list := elem.call.Args[elem.lastField].([]interface{})
-		elem.call.Args[elem.lastField] = append(list, val)
+		if elem.lastCond != ILLEGAL {
+			list := elem.call.Args[elem.lastField].(*Condition).Value.([]interface{})
+			elem.call.Args[elem.lastField] = &Condition{
+				Op:    elem.lastCond,
+				Value: append(list, val),
+			}
+		} else {
+			list := elem.call.Args[elem.lastField].([]interface{})
+			elem.call.Args[elem.lastField] = append(list, val)
+		}
 		return
 	}
 	if elem.lastCond != ILLEGAL {
@@ -236,13 +244,24 @@ func (q *Query) WriteCallN() int {
 	var n int
 	for _, call := range q.Calls {
 		switch call.Name {
-		case "Set", "Clear", "SetRowAttrs", "SetColumnAttrs":
+		case "Set", "Clear", "SetRowAttrs", "SetColumnAttrs", "ClearRow", "Store", "Delete":
 			n++
 		}
 	}
 	return n
 }
 
+// HasWriteCall returns true if the query contains a call which mutates data.
+func (q *Query) HasWriteCall() bool {
+	for _, call := range q.Calls {
+		switch call.Name {
+		case "Set", "Clear", "SetRowAttrs", "SetColumnAttrs", "ClearRow", "Store", "Delete":
+			return true
+		}
+	}
+	return false
+}
+
 // String returns a string representation of the query.
 func (q *Query) String() string {
 	a := make([]string, len(q.Calls))
@@ -369,6 +388,19 @@ func (c *Call) UintSliceArg(key string) ([]uint64, bool, error) {
 			ret[i] = uint64(v)
 		}
 		return ret, true, nil
+	case []interface{}:
+		ret := make([]uint64, len(tval))
+		for i, v := range tval {
+			switch tv := v.(type) {
+			case int64:
+				ret[i] = uint64(tv)
+			case uint64:
+				ret[i] = tv
+			default:
+				return nil, true, fmt.Errorf("unexpected value type %T in UintSliceArg, val %v", tv, tv)
+			}
+		}
+		return ret, true, nil
 	default:
 		return nil, true, fmt.Errorf("unexpected type %T in UintSliceArg, val %v", tval, tval)
 	}
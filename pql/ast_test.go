@@ -67,3 +67,13 @@ func TestCondition_Value(t *testing.T) {
 		}
 	})
 }
+
+// Ensure every mutating call is counted as a write.
+func TestQuery_WriteCallN(t *testing.T) {
+	q, err := pql.ParseString(`Set(1, f=1) Clear(1, f=1) ClearRow(f=1) Store(Row(f=1), f=2) Delete(Row(f=2)) Count(Row(f=1))`)
+	if err != nil {
+		t.Fatal(err)
+	} else if n := q.WriteCallN(); n != 5 {
+		t.Fatalf("unexpected write count: %d", n)
+	}
+}
@@ -574,6 +574,18 @@ func TestPQLDeepEquality(t *testing.T) {
 					},
 				},
 			}},
+		{
+			name: "RangeBetweenStrings",
+			call: `Row(a >< ["2019-01-01T00:00", "2019-02-01T00:00"])`,
+			exp: &Call{
+				Name: "Row",
+				Args: map[string]interface{}{
+					"a": &Condition{
+						Op:    BETWEEN,
+						Value: []interface{}{"2019-01-01T00:00", "2019-02-01T00:00"},
+					},
+				},
+			}},
 		{
 			name: "Sum",
 			call: "Sum(field=f)",
@@ -4501,6 +4501,24 @@ func (op *op) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// tornOp returns true if data holds an op which runs to the end of data, or
+// past it, such that it may have been cut short while being written.
+func tornOp(data []byte) bool {
+	if len(data) < minOpSize {
+		return true
+	}
+	value := binary.LittleEndian.Uint64(data[1:9])
+	switch opType(data[0]) {
+	case opTypeAdd, opTypeRemove:
+		return len(data) <= minOpSize
+	case opTypeAddBatch, opTypeRemoveBatch:
+		return value <= maxBatchSize && uint64(len(data)) <= 13+value*8
+	case opTypeAddRoaring, opTypeRemoveRoaring:
+		return uint64(len(data)) <= 13+4+value
+	}
+	return false
+}
+
 // size returns the encoded size of the op, in bytes.
 func (op *op) size() int {
 	switch op.typ {
@@ -4643,6 +4661,21 @@ func trailingZeroN(v uint64) int {
 	return bits.TrailingZeros64(v)
 }
 
+// OpLogError is returned when an op in the ops log cannot be read, such as
+// when the last op was only partially written. Offset is the position of the
+// op within the data, so the data preceding it is valid. Torn is set when the
+// op is the last one in the data and runs to its end, as it would if it was
+// only partially written; otherwise the ops log is corrupt.
+type OpLogError struct {
+	Offset int
+	Torn   bool
+	Err    error
+}
+
+func (e *OpLogError) Error() string {
+	return fmt.Sprintf("reading op at offset %d: %s", e.Offset, e.Err)
+}
+
 // ErrorList represents a list of errors.
 type ErrorList []error
 
@@ -205,8 +205,7 @@ func (b *Bitmap) unmarshalPilosaRoaring(data []byte) error {
 		// Unmarshal the op and apply it.
 		var opr op
 		if err := opr.UnmarshalBinary(buf); err != nil {
-			// FIXME(benbjohnson): return error with position so file can be trimmed.
-			return err
+			return &OpLogError{Offset: len(data) - len(buf), Torn: tornOp(buf), Err: err}
 		}
 		opr.apply(b)
 		// Increase the op count.
@@ -32,6 +32,26 @@ type Row struct {
 
 	// Attributes associated with the row.
 	Attrs map[string]interface{}
+
+	// Cursor for the next page of a row paginated with Options(limit=).
+	// Nil if there are no more columns.
+	Next *RowCursor
+}
+
+// RowCursor identifies the last column in a page of a row. It is passed as
+// the after argument of Options() to retrieve the following page.
+type RowCursor struct {
+	Column uint64
+	Key    string
+}
+
+// MarshalJSON marshals RowCursor to JSON such that
+// either the Key or the Column is included.
+func (c *RowCursor) MarshalJSON() ([]byte, error) {
+	if c.Key != "" {
+		return json.Marshal(c.Key)
+	}
+	return json.Marshal(c.Column)
 }
 
 // NewRow returns a new instance of Row.
@@ -305,9 +325,11 @@ func (r *Row) MarshalJSON() ([]byte, error) {
 		Attrs   map[string]interface{} `json:"attrs"`
 		Columns []uint64               `json:"columns"`
 		Keys    []string               `json:"keys,omitempty"`
+		Next    *RowCursor             `json:"next,omitempty"`
 	}
 	o.Columns = r.Columns()
 	o.Keys = r.Keys
+	o.Next = r.Next
 
 	o.Attrs = r.Attrs
 	if o.Attrs == nil {
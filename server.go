@@ -131,6 +131,41 @@ func OptServerAntiEntropyInterval(interval time.Duration) ServerOption {
 	}
 }
 
+// OptServerDurability is a functional option on Server used to set when
+// fragment mutations are synced to disk. The interval only applies to the
+// DurabilityInterval mode.
+func OptServerDurability(mode string, interval time.Duration) ServerOption {
+	return func(s *Server) error {
+		switch mode {
+		case DurabilityNone, DurabilityAlways:
+		case DurabilityInterval:
+			if interval <= 0 {
+				return errors.New("durability interval must be positive")
+			}
+		default:
+			return errors.Wrapf(ErrInvalidDurability, "%q", mode)
+		}
+		s.holder.durability = mode
+		s.holder.durabilityInterval = interval
+		return nil
+	}
+}
+
+// OptServerChangeDataCapture is a functional option on Server used to enable
+// logging every mutation applied to the node for change data capture. The
+// oldest events are truncated once the log exceeds maxSize bytes, unless
+// maxSize is zero.
+func OptServerChangeDataCapture(enabled bool, maxSize int64) ServerOption {
+	return func(s *Server) error {
+		s.holder.changeLog = nil
+		if enabled {
+			s.holder.changeLog = NewChangeLog()
+			s.holder.changeLog.MaxSize = maxSize
+		}
+		return nil
+	}
+}
+
 // OptServerLongQueryTime is a functional option on Server
 // used to set long query duration.
 func OptServerLongQueryTime(dur time.Duration) ServerOption {
@@ -340,12 +375,16 @@ func NewServer(opts ...ServerOption) (*Server, error) {
 
 	s.holder.Path = path
 	s.holder.translateFile.Path = filepath.Join(path, ".keys")
+	if s.holder.changeLog != nil {
+		s.holder.changeLog.Path = filepath.Join(path, ".cdc")
+	}
 	s.holder.Logger = s.logger
 	s.holder.Stats.SetLogger(s.logger)
 
 	s.cluster.Path = path
 	s.cluster.logger = s.logger
 	s.cluster.holder = s.holder
+	s.holder.isCoordinator = s.cluster.isCoordinator
 
 	// Get or create NodeID.
 	s.nodeID = s.loadNodeID()
@@ -629,8 +668,9 @@ func (s *Server) receiveMessage(m Message) error {
 		if f == nil {
 			return fmt.Errorf("local field not found: %s", obj.Field)
 		}
+		// Ignore missing views, which may have already expired locally.
 		err := f.deleteView(obj.View)
-		if err != nil {
+		if err != nil && err != ErrInvalidView {
 			return err
 		}
 	case *ClusterStatus:
@@ -659,6 +699,14 @@ func (s *Server) receiveMessage(m Message) error {
 		}
 	case *RecalculateCaches:
 		s.holder.recalculateCaches()
+	case *PauseWritesMessage:
+		if !obj.Pause {
+			s.holder.writes.resume()
+		} else if obj.Renew {
+			return s.holder.writes.renew(obj.Timeout)
+		} else {
+			s.holder.writes.pause(obj.Timeout)
+		}
 	case *NodeEvent:
 		err := s.cluster.ReceiveEvent(obj)
 		if err != nil {
@@ -127,6 +127,23 @@ type Config struct {
 		Interval toml.Duration `toml:"interval"`
 	} `toml:"anti-entropy"`
 
+	Durability struct {
+		// Mode determines when mutations are synced to disk. It can be
+		// none, interval, or always.
+		Mode string `toml:"mode"`
+		// Interval at which mutations are synced in the interval mode.
+		Interval toml.Duration `toml:"interval"`
+	} `toml:"durability"`
+
+	CDC struct {
+		// Enabled logs every mutation applied to the node so that it can
+		// be read from the /cdc endpoint.
+		Enabled bool `toml:"enabled"`
+		// MaxSize is the number of bytes of events retained before the
+		// oldest events are truncated. Zero retains every event.
+		MaxSize int64 `toml:"max-size"`
+	} `toml:"cdc"`
+
 	Metric struct {
 		// Service can be statsd, expvar, or none.
 		Service string `toml:"service"`
@@ -197,6 +214,13 @@ func NewConfig() *Config {
 	// AntiEntropy config.
 	c.AntiEntropy.Interval = toml.Duration(10 * time.Minute)
 
+	// Durability config.
+	c.Durability.Mode = "none"
+	c.Durability.Interval = toml.Duration(time.Second)
+
+	// CDC config.
+	c.CDC.MaxSize = 1 << 30
+
 	// Metric config.
 	c.Metric.Service = "none"
 	c.Metric.PollInterval = toml.Duration(0 * time.Minute)
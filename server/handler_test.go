@@ -535,6 +535,37 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Query stream", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(`Row(f0=30) Count(Row(f0=30))`))
+		r.Header.Set("Accept", "application/x-ndjson")
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Fatalf("unexpected content type: %s", ct)
+		}
+
+		exp := fmt.Sprintf(`{"call":0,"result":{"attrs":{"a":"b","c":1,"d":true},"columns":[%d,%d]}}`+"\n", pilosa.ShardWidth+1, pilosa.ShardWidth+2) +
+			fmt.Sprintf(`{"call":0,"result":{"attrs":{},"columns":[%d]}}`+"\n", (3*pilosa.ShardWidth)+4) +
+			`{"call":1,"result":3}` + "\n"
+		if body := w.Body.String(); body != exp {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	})
+
+	t.Run("Query stream err", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader(`Row(row=30)`))
+		r.Header.Set("Accept", "application/x-ndjson")
+		h.ServeHTTP(w, r)
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", w.Code)
+		} else if body := w.Body.String(); body != `{"error":"executing: map reduce: field not found"}`+"\n" {
+			t.Fatalf("unexpected body: %q", body)
+		}
+	})
+
 	t.Run("Query empty", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/i0/query", strings.NewReader("")))
@@ -659,6 +690,34 @@ func TestHandler_Endpoints(t *testing.T) {
 		}
 	})
 
+	t.Run("Delete columns", func(t *testing.T) {
+		cluster.CreateField(t, "dc", pilosa.IndexOptions{}, "f")
+		cluster.Query(t, "dc", `Set(1, f=1) Set(2, f=1) Set(3, f=2)`)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/dc/delete-columns", strings.NewReader(`{"columnIDs":[1,3]}`)))
+		if w.Code != gohttp.StatusOK {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		} else if body := w.Body.String(); body != `{"deleted":2}`+"\n" {
+			t.Fatalf("unexpected body: %s", body)
+		}
+		if res := cluster.Query(t, "dc", `Union(Row(f=1), Row(f=2))`).Results[0].(*pilosa.Row); !reflect.DeepEqual(res.Columns(), []uint64{2}) {
+			t.Fatalf("unexpected columns: %v", res.Columns())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/dc/delete-columns", strings.NewReader(`{"columnKeys":["a"]}`)))
+		if w.Code != gohttp.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, test.MustNewHTTPRequest("POST", "/index/nosuchindex/delete-columns", strings.NewReader(`{"columnIDs":[1]}`)))
+		if w.Code != gohttp.StatusNotFound {
+			t.Fatalf("unexpected status code: %d, body: %s", w.Code, w.Body.String())
+		}
+	})
+
 	t.Run("delete index", func(t *testing.T) {
 		hldr.MustCreateIndexIfNotExists("i", pilosa.IndexOptions{})
 		w := httptest.NewRecorder()
@@ -302,6 +302,8 @@ func (m *Command) SetupServer() error {
 
 	serverOptions := []pilosa.ServerOption{
 		pilosa.OptServerAntiEntropyInterval(time.Duration(m.Config.AntiEntropy.Interval)),
+		pilosa.OptServerDurability(m.Config.Durability.Mode, time.Duration(m.Config.Durability.Interval)),
+		pilosa.OptServerChangeDataCapture(m.Config.CDC.Enabled, m.Config.CDC.MaxSize),
 		pilosa.OptServerLongQueryTime(time.Duration(m.Config.Cluster.LongQueryTime)),
 		pilosa.OptServerDataDir(m.Config.DataDir),
 		pilosa.OptServerReplicaN(m.Config.Cluster.ReplicaN),
@@ -0,0 +1,193 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/cespare/xxhash"
+)
+
+// Sketch dimensions. These must be the same on every node so that sketches
+// from different shards can be merged.
+const (
+	// countMinWidth and countMinDepth bound count-min estimates to within
+	// e/countMinWidth (about 0.27%) of the total count, with a probability
+	// of 1-e^-countMinDepth (about 98%).
+	countMinWidth = 1024
+	countMinDepth = 4
+
+	// hllPrecision gives HyperLogLog estimates a standard error of
+	// 1.04/sqrt(2^hllPrecision), about 1.6%.
+	hllPrecision = 12
+
+	// maxRowSketches is the maximum number of row sketches kept by each
+	// fragment.
+	maxRowSketches = 1000
+)
+
+// sketchHash returns the hash of an ID used by all sketches.
+func sketchHash(id uint64) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], id)
+	return xxhash.Sum64(buf[:])
+}
+
+// CountMinSketch is a count-min sketch of row counts, stored as
+// countMinDepth rows of countMinWidth counters. It is exported because the
+// proto package needs access to it.
+type CountMinSketch []uint64
+
+// newCountMinSketch returns an empty count-min sketch.
+func newCountMinSketch() CountMinSketch {
+	return make(CountMinSketch, countMinDepth*countMinWidth)
+}
+
+// add adds n to the count of id. Counts are removed by adding the two's
+// complement of the count, which wraps around to subtract it.
+func (s CountMinSketch) add(id uint64, n uint64) {
+	h := sketchHash(id)
+	h1, h2 := h&math.MaxUint32, h>>32
+	for i := uint64(0); i < countMinDepth; i++ {
+		s[i*countMinWidth+(h1+i*h2)%countMinWidth] += n
+	}
+}
+
+// estimate returns an estimate of the count of id which is never less than
+// its actual count.
+func (s CountMinSketch) estimate(id uint64) uint64 {
+	h := sketchHash(id)
+	h1, h2 := h&math.MaxUint32, h>>32
+	min := uint64(math.MaxUint64)
+	for i := uint64(0); i < countMinDepth; i++ {
+		if n := s[i*countMinWidth+(h1+i*h2)%countMinWidth]; n < min {
+			min = n
+		}
+	}
+	return min
+}
+
+// merge adds the counts of other to the sketch.
+func (s CountMinSketch) merge(other CountMinSketch) {
+	for i, n := range other {
+		s[i] += n
+	}
+}
+
+// clone returns a copy of the sketch.
+func (s CountMinSketch) clone() CountMinSketch {
+	other := make(CountMinSketch, len(s))
+	copy(other, s)
+	return other
+}
+
+// TopNSketch holds the candidate rows of an approximate TopN() call and a
+// count-min sketch of the counts of every row. It is exported because the
+// proto package needs access to it.
+type TopNSketch struct {
+	Pairs  []Pair
+	Sketch CountMinSketch
+}
+
+// merge returns the candidates of both results and the sum of their
+// sketches. The receiver's sketch is modified if it is set.
+func (t TopNSketch) merge(other TopNSketch) TopNSketch {
+	if t.Sketch == nil {
+		t.Sketch = newCountMinSketch()
+	}
+	if other.Sketch != nil {
+		t.Sketch.merge(other.Sketch)
+	}
+	t.Pairs = Pairs(t.Pairs).Add(other.Pairs)
+	return t
+}
+
+// top returns the n candidates with the highest estimated counts, or every
+// candidate if n is zero.
+func (t TopNSketch) top(n int) []Pair {
+	pairs := make([]Pair, len(t.Pairs))
+	for i, p := range t.Pairs {
+		pairs[i] = Pair{ID: p.ID, Count: t.Sketch.estimate(p.ID)}
+	}
+	sort.Sort(Pairs(pairs))
+
+	if n > 0 && n < len(pairs) {
+		pairs = pairs[:n]
+	}
+	return pairs
+}
+
+// hyperLogLog is a HyperLogLog sketch of column IDs, stored as one register
+// per bucket.
+type hyperLogLog []uint8
+
+// newHyperLogLog returns an empty HyperLogLog sketch.
+func newHyperLogLog() hyperLogLog {
+	return make(hyperLogLog, 1<<hllPrecision)
+}
+
+// add adds a column to the sketch.
+func (s hyperLogLog) add(id uint64) {
+	h := sketchHash(id)
+
+	// The leading bits choose the register, and the register keeps the
+	// longest run of leading zeros in the remaining bits. A sentinel bit
+	// caps the run at the number of remaining bits.
+	j := h >> (64 - hllPrecision)
+	n := uint8(bits.LeadingZeros64(h<<hllPrecision|1<<(hllPrecision-1))) + 1
+	if n > s[j] {
+		s[j] = n
+	}
+}
+
+// addRow adds every column of a row to the sketch.
+func (s hyperLogLog) addRow(row *Row) {
+	for _, id := range row.Columns() {
+		s.add(id)
+	}
+}
+
+// merge sets the sketch to the union of itself and other.
+func (s hyperLogLog) merge(other hyperLogLog) {
+	for i, n := range other {
+		if n > s[i] {
+			s[i] = n
+		}
+	}
+}
+
+// count returns the estimated number of distinct columns in the sketch.
+func (s hyperLogLog) count() uint64 {
+	m := float64(len(s))
+
+	var sum float64
+	var zeros int
+	for _, n := range s {
+		sum += 1 / float64(uint64(1)<<n)
+		if n == 0 {
+			zeros++
+		}
+	}
+	est := 0.7213 / (1 + 1.079/m) * m * m / sum
+
+	// Use linear counting for small cardinalities, where it is more accurate.
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Ensure count-min estimates are within their error bound and can be merged.
+func TestCountMinSketch(t *testing.T) {
+	a, b := newCountMinSketch(), newCountMinSketch()
+	var total uint64
+	for id := uint64(0); id < 5000; id++ {
+		a.add(id, id%100)
+		b.add(id, 1)
+		total += id%100 + 1
+	}
+	a.merge(b)
+
+	bound := uint64(math.E / countMinWidth * float64(total))
+	var over int
+	for id := uint64(0); id < 5000; id++ {
+		exp := id%100 + 1
+		if n := a.estimate(id); n < exp {
+			t.Fatalf("estimate of %d below count: %d < %d", id, n, exp)
+		} else if n > exp+bound {
+			over++
+		}
+	}
+	if over > 5000/20 {
+		t.Fatalf("too many estimates outside the error bound: %d", over)
+	}
+
+	// Counts can be removed by adding their two's complement.
+	c, n := newCountMinSketch(), uint64(10)
+	c.add(1, n)
+	c.add(2, 5)
+	c.add(1, -n)
+	if n := c.estimate(1); n != 0 {
+		t.Fatalf("unexpected estimate after removal: %d", n)
+	} else if n := c.estimate(2); n != 5 {
+		t.Fatalf("unexpected estimate: %d", n)
+	}
+}
+
+// Ensure TopNSketch merges candidates and ranks them by estimated count.
+func TestTopNSketch(t *testing.T) {
+	a := TopNSketch{Pairs: []Pair{{ID: 1, Count: 10}, {ID: 2, Count: 8}}, Sketch: newCountMinSketch()}
+	a.Sketch.add(1, 10)
+	a.Sketch.add(2, 8)
+	a.Sketch.add(3, 7)
+	b := TopNSketch{Pairs: []Pair{{ID: 3, Count: 9}}, Sketch: newCountMinSketch()}
+	b.Sketch.add(3, 9)
+	b.Sketch.add(1, 1)
+
+	var result TopNSketch
+	result = result.merge(a).merge(b).merge(TopNSketch{})
+	if pairs := result.top(2); !reflect.DeepEqual(pairs, []Pair{{ID: 3, Count: 16}, {ID: 1, Count: 11}}) {
+		t.Fatalf("unexpected pairs: %+v", pairs)
+	} else if a.Sketch.estimate(3) != 7 {
+		t.Fatal("merge modified its argument")
+	}
+}
+
+// Ensure HyperLogLog estimates are within their error bound and can be merged.
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []uint64{10, 1000, 100000} {
+		a, b := newHyperLogLog(), newHyperLogLog()
+		for id := uint64(0); id < n; id++ {
+			a.add(id * 3)
+			b.add(id*3 + n*3/2)
+		}
+
+		// Standard error is about 1.6%, so allow for four times that.
+		within := func(est, exp uint64) bool {
+			return math.Abs(float64(est)-float64(exp)) <= 0.065*float64(exp)
+		}
+		if est := a.count(); !within(est, n) {
+			t.Fatalf("unexpected estimate of %d: %d", n, est)
+		}
+		a.merge(b)
+		if est := a.count(); !within(est, n*3/2) {
+			t.Fatalf("unexpected estimate of union of %d: %d", n*3/2, est)
+		}
+	}
+
+	if n := newHyperLogLog().count(); n != 0 {
+		t.Fatalf("unexpected estimate of empty sketch: %d", n)
+	}
+}
+
+// Ensure cache sketches follow changes to the cache.
+func TestCache_Sketch(t *testing.T) {
+	for _, c := range []cache{NewRankCache(2), newLRUCache(2)} {
+		c.Add(1, 10)
+		c.Add(2, 20)
+		s := c.Sketch()
+		if n := s.estimate(1); n != 10 {
+			t.Fatalf("%T: unexpected estimate: %d", c, n)
+		}
+
+		// Updates, evictions and trimmed entries are reflected in the sketch.
+		c.Add(1, 15)
+		c.BulkAdd(3, 30)
+		c.BulkAdd(4, 40)
+		c.BulkAdd(5, 50)
+		c.Recalculate()
+		s = c.Sketch()
+		for _, id := range c.IDs() {
+			if n := s.estimate(id); n != c.Get(id) {
+				t.Fatalf("%T: unexpected estimate of %d: %d != %d", c, id, n, c.Get(id))
+			}
+		}
+		if n := s.estimate(1); n != c.Get(1) {
+			t.Fatalf("%T: unexpected estimate of removed entry: %d", c, n)
+		}
+
+		// The returned sketch is a copy.
+		s.add(5, 1)
+		if n := c.Sketch().estimate(5); n != c.Get(5) {
+			t.Fatalf("%T: sketch was modified: %d", c, n)
+		}
+	}
+}
@@ -71,6 +71,66 @@ func (q TimeQuantum) Type() string {
 	return "TimeQuantum"
 }
 
+// RollupPolicy describes how long the views of each unit of a time quantum
+// are kept before they are rolled up into the view of the next coarser unit.
+// It is a comma-separated list of unit:duration pairs, e.g. "H:168h,D:2160h"
+// keeps hourly views for 7 days and daily views for 90 days.
+type RollupPolicy string
+
+// retentions returns the retention of each unit in the policy.
+func (p RollupPolicy) retentions() (map[rune]time.Duration, error) {
+	m := make(map[rune]time.Duration)
+	if p == "" {
+		return m, nil
+	}
+	for _, rule := range strings.Split(string(p), ",") {
+		parts := strings.SplitN(strings.TrimSpace(rule), ":", 2)
+		if len(parts) != 2 || len(parts[0]) != 1 || !strings.Contains("YMDH", parts[0]) {
+			return nil, fmt.Errorf("invalid rollup rule: %q", rule)
+		}
+		unit := rune(parts[0][0])
+		if _, ok := m[unit]; ok {
+			return nil, fmt.Errorf("duplicate rollup rule for unit: %c", unit)
+		}
+		d, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rollup duration: %q", parts[1])
+		} else if d <= 0 {
+			return nil, fmt.Errorf("rollup duration must be positive: %q", parts[1])
+		}
+		m[unit] = d
+	}
+	return m, nil
+}
+
+// validate returns an error if the policy cannot be applied to a field with
+// the time quantum q. Each unit must have a coarser unit in q to be rolled up
+// into, and must not be kept longer than the coarser units.
+func (p RollupPolicy) validate(q TimeQuantum) error {
+	m, err := p.retentions()
+	if err != nil {
+		return err
+	}
+	var prev time.Duration
+	for i := len(q) - 1; i >= 0; i-- {
+		unit := rune(q[i])
+		d, ok := m[unit]
+		if !ok {
+			continue
+		} else if i == 0 {
+			return fmt.Errorf("cannot roll up coarsest unit of time quantum: %c", unit)
+		} else if prev > d {
+			return fmt.Errorf("unit %c cannot be rolled up before finer units", unit)
+		}
+		prev = d
+		delete(m, unit)
+	}
+	for unit := range m {
+		return fmt.Errorf("unit not in time quantum: %c", unit)
+	}
+	return nil
+}
+
 // viewByTimeUnit returns the view name for time with a given quantum unit.
 func viewByTimeUnit(name string, t time.Time, unit rune) string {
 	switch unit {
@@ -216,6 +276,40 @@ func nextDayGTE(t time.Time, end time.Time) bool {
 	return end.After(next)
 }
 
+// truncateTime returns the start of the time quantum unit containing t.
+// The unit may also be 'W' for a week, which starts on Monday as in ISO 8601.
+func truncateTime(t time.Time, unit rune) time.Time {
+	switch unit {
+	case 'Y':
+		return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+	case 'M':
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case 'W':
+		return time.Date(t.Year(), t.Month(), t.Day()-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case 'D':
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	}
+}
+
+// addTimeUnit returns t advanced by one time quantum unit. The time t
+// must be the start of a unit, as returned by truncateTime.
+func addTimeUnit(t time.Time, unit rune) time.Time {
+	switch unit {
+	case 'Y':
+		return t.AddDate(1, 0, 0)
+	case 'M':
+		return t.AddDate(0, 1, 0)
+	case 'W':
+		return t.AddDate(0, 0, 7)
+	case 'D':
+		return t.AddDate(0, 0, 1)
+	default:
+		return t.Add(time.Hour)
+	}
+}
+
 // parseTime parses a string or int64 into a time.Time value.
 func parseTime(t interface{}) (time.Time, error) {
 	var err error
@@ -281,7 +375,7 @@ func timeOfView(v string, adj bool) (time.Time, error) {
 		return time.Time{}, nil
 	}
 
-	layout := "2006010203"
+	layout := "2006010215"
 	timePart := viewTimePart(v)
 
 	switch len(timePart) {
@@ -332,3 +426,18 @@ func viewTimePart(v string) string {
 	parts := strings.Split(v, "_")
 	return parts[len(parts)-1]
 }
+
+// parentTimeView returns the view of the next coarser unit containing the
+// time view v, or an empty string if v is a yearly view.
+func parentTimeView(v string) string {
+	i := strings.LastIndex(v, "_")
+	if i < 0 {
+		return ""
+	}
+	switch n := len(v) - i - 1; n {
+	case 6, 8, 10:
+		return v[:len(v)-2]
+	default:
+		return ""
+	}
+}
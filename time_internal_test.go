@@ -252,6 +252,12 @@ func TestTimeOfView(t *testing.T) {
 				time.Date(2019, 2, 3, 9, 0, 0, 0, time.UTC),
 				"",
 			},
+			{
+				"std_2019020315",
+				time.Date(2019, 2, 3, 15, 0, 0, 0, time.UTC),
+				time.Date(2019, 2, 3, 16, 0, 0, 0, time.UTC),
+				"",
+			},
 			{
 				"foo",
 				time.Time{},
@@ -319,3 +325,48 @@ func parseTimeQuantum(v string) (TimeQuantum, error) {
 	}
 	return q, nil
 }
+
+func TestRollupPolicy_Validate(t *testing.T) {
+	for i, test := range []struct {
+		policy RollupPolicy
+		q      TimeQuantum
+		expErr string
+	}{
+		{"", "YMDH", ""},
+		{"H:168h", "YMDH", ""},
+		{"H:168h, D:2160h", "YMDH", ""},
+		{"H:168h,M:8760h", "DH", "unit not in time quantum: M"},
+		{"D:2160h", "DH", "cannot roll up coarsest unit of time quantum: D"},
+		{"H:2160h,D:168h", "YMDH", "unit D cannot be rolled up before finer units"},
+		{"H:168h,H:24h", "YMDH", "duplicate rollup rule for unit: H"},
+		{"H", "YMDH", `invalid rollup rule: "H"`},
+		{"X:1h", "YMDH", `invalid rollup rule: "X:1h"`},
+		{"H:7d", "YMDH", `invalid rollup duration: "7d"`},
+		{"H:-1h", "YMDH", `rollup duration must be positive: "-1h"`},
+	} {
+		if err := test.policy.validate(test.q); err != nil {
+			if err.Error() != test.expErr {
+				t.Errorf("test %d got unexpected error: %s", i, err)
+			}
+		} else if test.expErr != "" {
+			t.Errorf("test %d expected error: %s but got none", i, test.expErr)
+		}
+	}
+}
+
+func TestParentTimeView(t *testing.T) {
+	for _, test := range []struct {
+		view string
+		exp  string
+	}{
+		{"standard_2019020308", "standard_20190203"},
+		{"standard_20190203", "standard_201902"},
+		{"standard_201902", "standard_2019"},
+		{"standard_2019", ""},
+		{"standard", ""},
+	} {
+		if parent := parentTimeView(test.view); parent != test.exp {
+			t.Errorf("parentTimeView(%q)=%q, expected %q", test.view, parent, test.exp)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"math"
+	"time"
+
+	"github.com/pilosa/pilosa/pql"
+	"github.com/pkg/errors"
+)
+
+// Timestamp field units.
+const (
+	TimeUnitSeconds      = "s"
+	TimeUnitMilliseconds = "ms"
+	TimeUnitMicroseconds = "us"
+	TimeUnitNanoseconds  = "ns"
+)
+
+// ErrInvalidTimeUnit is returned when a timestamp field unit is not supported.
+var ErrInvalidTimeUnit = errors.New("invalid time unit")
+
+// timeUnitDuration returns the duration of a single unit, or zero if the
+// unit is not supported.
+func timeUnitDuration(unit string) time.Duration {
+	switch unit {
+	case TimeUnitSeconds:
+		return time.Second
+	case TimeUnitMilliseconds:
+		return time.Millisecond
+	case TimeUnitMicroseconds:
+		return time.Microsecond
+	case TimeUnitNanoseconds:
+		return time.Nanosecond
+	default:
+		return 0
+	}
+}
+
+// isValidTimeUnit returns true if unit is a supported timestamp field unit.
+func isValidTimeUnit(unit string) bool {
+	return timeUnitDuration(unit) != 0
+}
+
+// timestampValue converts t to the number of units since the Unix epoch.
+// Any precision finer than the unit is truncated.
+func timestampValue(t time.Time, unit string) (int64, error) {
+	d := timeUnitDuration(unit)
+	if d == 0 {
+		return 0, ErrInvalidTimeUnit
+	}
+	perSec := int64(time.Second / d)
+
+	secs := t.Unix()
+	if secs > math.MaxInt64/perSec-1 || secs < math.MinInt64/perSec+1 {
+		return 0, errors.Errorf("timestamp out of range: %s", t.Format(time.RFC3339Nano))
+	}
+	return secs*perSec + int64(t.Nanosecond())/int64(d), nil
+}
+
+// valueTimestamp converts a number of units since the Unix epoch to a time.
+func valueTimestamp(v int64, unit string) time.Time {
+	d := timeUnitDuration(unit)
+	if d == 0 {
+		d = time.Second
+	}
+	perSec := int64(time.Second / d)
+	return time.Unix(v/perSec, (v%perSec)*int64(d)).UTC()
+}
+
+// parseTimestamp parses an RFC3339 string. The shorter TimeFormat used
+// elsewhere in PQL is also accepted and is interpreted as UTC.
+func parseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(TimeFormat, s)
+	if err != nil {
+		return time.Time{}, errors.Errorf("cannot parse timestamp: %q", s)
+	}
+	return t, nil
+}
+
+// timestampArgValue converts a PQL argument value to a number of units since
+// the Unix epoch. Strings are parsed as timestamps while integers are
+// assumed to already be expressed in the field's unit. Precision finer than
+// the unit is rounded down unless rounding is decimalRoundCeil. The returned
+// bool is false if the value had precision finer than the unit.
+func timestampArgValue(v interface{}, unit string, rounding decimalRounding) (int64, bool, error) {
+	switch v := v.(type) {
+	case string:
+		t, err := parseTimestamp(v)
+		if err != nil {
+			return 0, false, err
+		}
+		value, err := timestampValue(t, unit)
+		if err != nil {
+			return 0, false, err
+		}
+		if int64(t.Nanosecond())%int64(timeUnitDuration(unit)) == 0 {
+			return value, true, nil
+		} else if rounding == decimalRoundCeil {
+			value++
+		}
+		return value, false, nil
+	case int64:
+		return v, true, nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false, errors.Errorf("timestamp value out of range: %d", v)
+		}
+		return int64(v), true, nil
+	default:
+		return 0, false, errors.Errorf("invalid timestamp value type: %T", v)
+	}
+}
+
+// timestampCondition returns a copy of cond with its values converted to
+// the number of units since the Unix epoch. Precision finer than the unit
+// is rounded in the direction which preserves the meaning of the
+// comparison. Returns a nil condition if no value can match, such as when
+// testing for equality with a value between two units.
+func timestampCondition(cond *pql.Condition, unit string) (*pql.Condition, error) {
+	if cond.Value == nil {
+		return cond, nil
+	}
+
+	switch cond.Op {
+	case pql.BETWEEN:
+		values, ok := cond.Value.([]interface{})
+		if !ok || len(values) != 2 {
+			return nil, errors.New("Row(): BETWEEN condition requires exactly two values")
+		}
+		lo, _, err := timestampArgValue(values[0], unit, decimalRoundCeil)
+		if err != nil {
+			return nil, err
+		}
+		hi, _, err := timestampArgValue(values[1], unit, decimalRoundFloor)
+		if err != nil {
+			return nil, err
+		} else if lo > hi {
+			return nil, nil
+		}
+		return &pql.Condition{Op: cond.Op, Value: []interface{}{lo, hi}}, nil
+	}
+
+	rounding := decimalRoundFloor
+	if cond.Op == pql.LT || cond.Op == pql.GTE {
+		rounding = decimalRoundCeil
+	}
+	value, exact, err := timestampArgValue(cond.Value, unit, rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	// A value between two units is never equal to a stored value.
+	if !exact {
+		switch cond.Op {
+		case pql.EQ:
+			return nil, nil
+		case pql.NEQ:
+			return &pql.Condition{Op: pql.NEQ, Value: nil}, nil
+		}
+	}
+	return &pql.Condition{Op: cond.Op, Value: value}, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2017 Pilosa Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilosa
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pilosa/pilosa/pql"
+)
+
+func TestTimestampValue(t *testing.T) {
+	ts := time.Date(2019, 1, 1, 0, 0, 1, 123456789, time.UTC)
+	tests := []struct {
+		t    time.Time
+		unit string
+		exp  int64
+		err  bool
+	}{
+		{t: ts, unit: TimeUnitSeconds, exp: 1546300801},
+		{t: ts, unit: TimeUnitMilliseconds, exp: 1546300801123},
+		{t: ts, unit: TimeUnitMicroseconds, exp: 1546300801123456},
+		{t: ts, unit: TimeUnitNanoseconds, exp: 1546300801123456789},
+		{t: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), unit: TimeUnitMilliseconds, exp: -1000},
+		{t: time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), unit: TimeUnitNanoseconds, err: true},
+		{t: ts, unit: "h", err: true},
+	}
+	for i, test := range tests {
+		v, err := timestampValue(test.t, test.unit)
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if v != test.exp {
+			t.Errorf("test %d: expected %d, got %d", i, test.exp, v)
+		}
+
+		// Ensure the value converts back to the original time.
+		if ts := valueTimestamp(v, test.unit); !ts.Equal(test.t.Truncate(timeUnitDuration(test.unit))) {
+			t.Errorf("test %d: expected %s, got %s", i, test.t, ts)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp time.Time
+		err bool
+	}{
+		{s: "2019-01-01T00:00", exp: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "2019-01-01T10:20:30Z", exp: time.Date(2019, 1, 1, 10, 20, 30, 0, time.UTC)},
+		{s: "2019-01-01T10:20:30.25-05:00", exp: time.Date(2019, 1, 1, 15, 20, 30, 250000000, time.UTC)},
+		{s: "2019-01-01", err: true},
+		{s: "now", err: true},
+	}
+	for i, test := range tests {
+		v, err := parseTimestamp(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("test %d: expected error", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if !v.Equal(test.exp) {
+			t.Errorf("test %d: expected %s, got %s", i, test.exp, v)
+		}
+	}
+}
+
+func TestTimestampCondition(t *testing.T) {
+	tests := []struct {
+		cond *pql.Condition
+		exp  *pql.Condition
+	}{
+		{
+			cond: &pql.Condition{Op: pql.GT, Value: "2019-01-01T00:00"},
+			exp:  &pql.Condition{Op: pql.GT, Value: int64(1546300800)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.LTE, Value: int64(1546300800)},
+			exp:  &pql.Condition{Op: pql.LTE, Value: int64(1546300800)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{"2019-01-01T00:00", "2019-01-01T00:01:00Z"}},
+			exp:  &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{int64(1546300800), int64(1546300860)}},
+		},
+		{
+			cond: &pql.Condition{Op: pql.NEQ, Value: nil},
+			exp:  &pql.Condition{Op: pql.NEQ, Value: nil},
+		},
+		{
+			cond: &pql.Condition{Op: pql.GT, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  &pql.Condition{Op: pql.GT, Value: int64(1546300800)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.GTE, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  &pql.Condition{Op: pql.GTE, Value: int64(1546300801)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.LT, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  &pql.Condition{Op: pql.LT, Value: int64(1546300801)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.LTE, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  &pql.Condition{Op: pql.LTE, Value: int64(1546300800)},
+		},
+		{
+			cond: &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{"2019-01-01T00:00:00.5Z", "2019-01-01T00:00:02.5Z"}},
+			exp:  &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{int64(1546300801), int64(1546300802)}},
+		},
+		{
+			cond: &pql.Condition{Op: pql.BETWEEN, Value: []interface{}{"2019-01-01T00:00:00.2Z", "2019-01-01T00:00:00.8Z"}},
+			exp:  nil,
+		},
+		{
+			cond: &pql.Condition{Op: pql.EQ, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  nil,
+		},
+		{
+			cond: &pql.Condition{Op: pql.NEQ, Value: "2019-01-01T00:00:00.5Z"},
+			exp:  &pql.Condition{Op: pql.NEQ, Value: nil},
+		},
+	}
+	for i, test := range tests {
+		cond, err := timestampCondition(test.cond, TimeUnitSeconds)
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		} else if !reflect.DeepEqual(cond, test.exp) {
+			t.Errorf("test %d: expected %v, got %v", i, test.exp, cond)
+		}
+	}
+}
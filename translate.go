@@ -377,6 +377,32 @@ func (s *TranslateFile) replayEntries() error {
 	}
 }
 
+// restoreEntries appends each log entry read from r to a store whose log
+// is offset bytes long. Entries are also streamed to any stores replicating
+// from this one.
+func (s *TranslateFile) restoreEntries(r io.Reader, offset int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.n != offset {
+		return fmt.Errorf("pilosa: translate store size %d does not match backup offset %d", s.n, offset)
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		var entry LogEntry
+		if _, err := entry.ReadFrom(br); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "reading entry")
+		}
+
+		if err := s.appendEntry(&entry); err != nil {
+			return errors.Wrap(err, "appending entry")
+		}
+	}
+}
+
 // monitorReplication is executed in a separate goroutine and continually streams
 // from the primary store until this store is closed.
 func (s *TranslateFile) monitorReplication() {
@@ -583,6 +609,25 @@ func (s *TranslateFile) TranslateColumnsToUint64(index string, values []string)
 	return ret, nil
 }
 
+// lookupColumns returns the ids of the values which have an associated id,
+// without creating ids for the others. Returns false if any value has no id.
+func (s *TranslateFile) lookupColumns(index string, values []string) ([]uint64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	idx := s.cols[index]
+	if idx == nil {
+		return nil, len(values) == 0
+	}
+	ids := make([]uint64, 0, len(values))
+	for i := range values {
+		if v, ok := idx.idByKey([]byte(values[i])); ok {
+			ids = append(ids, v)
+		}
+	}
+	return ids, len(ids) == len(values)
+}
+
 // TranslateColumnToString converts a uint64 id to its associated string value.
 // If the id is not associated with a string value then a blank string is returned.
 func (s *TranslateFile) TranslateColumnToString(index string, value uint64) (string, error) {
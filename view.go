@@ -60,6 +60,8 @@ type view struct {
 	rowAttrStore  AttrStore
 	logger        logger.Logger
 	snapshotQueue chan *fragment
+	durability    string
+	changeLog     *ChangeLog
 }
 
 // newView returns a new instance of View.
@@ -208,7 +210,7 @@ func (v *view) close() error {
 // flags returns a set of flags for the underlying fragments.
 func (v *view) flags() byte {
 	var flag byte
-	if v.fieldType == FieldTypeInt {
+	if v.fieldType == FieldTypeInt || v.fieldType == FieldTypeDecimal || v.fieldType == FieldTypeTimestamp {
 		flag |= roaringFlagBSIv2
 	}
 	return flag
@@ -308,6 +310,8 @@ func (v *view) newFragment(path string, shard uint64) *fragment {
 	frag.Logger = v.logger
 	frag.stats = v.stats
 	frag.snapshotQueue = v.snapshotQueue
+	frag.durability = v.durability
+	frag.changeLog = v.changeLog
 	if v.fieldType == FieldTypeMutex {
 		frag.mutexVector = newRowsVector(frag)
 	} else if v.fieldType == FieldTypeBool {
@@ -391,14 +395,15 @@ func (v *view) value(columnID uint64, bitDepth uint) (value int64, exists bool,
 	return frag.value(columnID, bitDepth)
 }
 
-// setValue uses a column of bits to set a multi-bit value.
-func (v *view) setValue(columnID uint64, bitDepth uint, value int64) (changed bool, err error) {
+// setValue uses a column of bits to set a multi-bit value. If the value
+// changed then events are logged along with it.
+func (v *view) setValue(columnID uint64, bitDepth uint, value int64, events ...ChangeEvent) (changed bool, err error) {
 	shard := columnID / ShardWidth
 	frag, err := v.CreateFragmentIfNotExists(shard)
 	if err != nil {
 		return changed, err
 	}
-	return frag.setValue(columnID, bitDepth, value)
+	return frag.setValue(columnID, bitDepth, value, events...)
 }
 
 // sum returns the sum & count of a field.